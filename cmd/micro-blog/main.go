@@ -2,15 +2,24 @@ package main
 
 import (
 	"context"
+	"flag"
 	log "log/slog"
 
 	"micro-blog/internal/app"
 )
 
 func main() {
+	bootstrapAdmin := flag.String("bootstrap-admin", "", "имя пользователя, которого назначить администратором при запуске")
+	flag.Parse()
+
+	var admins []string
+	if *bootstrapAdmin != "" {
+		admins = append(admins, *bootstrapAdmin)
+	}
+
 	ctx := context.Background()
 
-	a, err := app.NewApp(ctx)
+	a, err := app.NewApp(ctx, admins...)
 	if err != nil {
 		log.Error("failed to initialize app", "error", err)
 		panic(err)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	log "log/slog"
 	"net/http"
 	"os"
@@ -11,13 +12,19 @@ import (
 	"syscall"
 	"time"
 
+	"micro-blog/internal/clock"
 	"micro-blog/internal/config"
 	"micro-blog/internal/config/env"
 	"micro-blog/internal/handler"
 	asyncLogger "micro-blog/internal/logger"
+	"micro-blog/internal/model"
+	"micro-blog/internal/periodic"
+	"micro-blog/internal/policy"
 	"micro-blog/internal/queue"
 	"micro-blog/internal/repository"
 	"micro-blog/internal/service"
+	"micro-blog/internal/spam"
+	"micro-blog/internal/storage"
 	"micro-blog/pkg/pkglogger"
 )
 
@@ -26,14 +33,22 @@ type App struct {
 	router    http.Handler
 	logger    *asyncLogger.AsyncLogger
 	likeQueue *queue.LikeQueue
+	voteQueue *queue.VoteQueue
+	sweeper   *periodic.Runner
+	scheduler *periodic.Runner
+	lifter    *periodic.Runner
+	watcher   *policy.Watcher
 }
 
 const (
 	bufferLogSize   = 100
 	bufferLikeQueue = 100
+	bufferVoteQueue = 100
 )
 
-func NewApp(ctx context.Context) (*App, error) {
+// NewApp собирает приложение. bootstrapAdmins дополняет список
+// администраторов из конфига, например значением флага командной строки.
+func NewApp(ctx context.Context, bootstrapAdmins ...string) (*App, error) {
 	_ = pkglogger.InitLogger()
 	logger := asyncLogger.NewAsyncLogger(bufferLogSize)
 
@@ -42,11 +57,70 @@ func NewApp(ctx context.Context) (*App, error) {
 		return nil, fmt.Errorf("error loading http config: %w", err)
 	}
 
+	mediaCfg, err := env.MediaConfigLoad()
+	if err != nil {
+		return nil, fmt.Errorf("error loading media config: %w", err)
+	}
+
+	postCfg, err := env.PostConfigLoad()
+	if err != nil {
+		return nil, fmt.Errorf("error loading post config: %w", err)
+	}
+
+	schedulerCfg, err := env.SchedulerConfigLoad()
+	if err != nil {
+		return nil, fmt.Errorf("error loading scheduler config: %w", err)
+	}
+
+	sanctionCfg, err := env.SanctionConfigLoad()
+	if err != nil {
+		return nil, fmt.Errorf("error loading sanction config: %w", err)
+	}
+
+	policyCfg, err := env.PolicyConfigLoad()
+	if err != nil {
+		return nil, fmt.Errorf("error loading content policy config: %w", err)
+	}
+
+	spamCfg, err := env.SpamConfigLoad()
+	if err != nil {
+		return nil, fmt.Errorf("error loading spam config: %w", err)
+	}
+
+	rbacCfg, err := env.RBACConfigLoad()
+	if err != nil {
+		return nil, fmt.Errorf("error loading rbac config: %w", err)
+	}
+
 	//init repo
 	repo := repository.NewRepository()
 
+	// init media storage
+	mediaStore, err := storage.NewLocalStore(mediaCfg.GetDir())
+	if err != nil {
+		return nil, err
+	}
+
 	// init service
-	serv := service.NewService(repo)
+	serv := service.NewService(repo, mediaStore, mediaLimits(mediaCfg), postLimits(postCfg))
+
+	// bootstrap admins
+	admins := append(rbacCfg.GetBootstrapAdmins(), bootstrapAdmins...)
+	if err = bootstrap(ctx, serv, admins, os.Stdout, logger); err != nil {
+		return nil, err
+	}
+
+	// init content policy
+	postPolicy, wordFilters, err := contentPolicy(policyCfg)
+	if err != nil {
+		return nil, fmt.Errorf("error building content policy: %w", err)
+	}
+	serv.PostService.AttachContentPolicy(postPolicy, serv.ModerationService)
+	policyWatcher := policy.NewWatcher(wordFilters, policyCfg.GetReloadInterval(), logger)
+
+	// init spam detector
+	limits := spamLimits(spamCfg)
+	serv.SpamService.AttachDetector(spam.NewDetector(limits), limits.QuarantineScore, limits.ScoreDecay)
 
 	// init likeQueue
 	queueLikes := queue.NewLikeQueue(serv, bufferLikeQueue, logger)
@@ -54,22 +128,138 @@ func NewApp(ctx context.Context) (*App, error) {
 	// ataching queueLike
 	serv.PostService.AttachLikeQueue(queueLikes)
 
+	// init voteQueue
+	queueVotes := queue.NewVoteQueue(serv, bufferVoteQueue, logger)
+	serv.PostService.AttachVoteQueue(queueVotes)
+
+	// init sweeper
+	postSweeper := periodic.StartTask("purge expired posts", serv.PostService.PurgeExpired,
+		clock.Real{}, postCfg.GetSweepInterval(), logger)
+
+	// init scheduler
+	postScheduler := periodic.StartTask("publish scheduled drafts", serv.DraftService.PublishDue,
+		clock.Real{}, schedulerCfg.GetInterval(), logger)
+
+	// init sanction lifter
+	sanctionLifter := periodic.StartTask("lift expired sanctions", serv.ModerationService.LiftExpiredSanctions,
+		clock.Real{}, sanctionCfg.GetLiftInterval(), logger)
+
 	//init router
-	r := handler.NewRouter(serv, logger)
+	r := handler.NewRouter(serv, logger, mediaCfg.GetMaxUploadSize())
 
 	return &App{
 			router:    r,
 			httpCfg:   htppCfg,
 			logger:    logger,
 			likeQueue: queueLikes,
+			voteQueue: queueVotes,
+			sweeper:   postSweeper,
+			scheduler: postScheduler,
+			lifter:    sanctionLifter,
+			watcher:   policyWatcher,
 		},
 		nil
 
 }
 
+// bootstrap назначает администраторов и выводит их ключи доступа в out.
+// Ключи не попадают ни в лог, ни на диск: репозитории живут в памяти, и
+// после перезапуска ключи все равно выдаются заново.
+func bootstrap(ctx context.Context, serv *service.Service, names []string, out io.Writer, logger *asyncLogger.AsyncLogger) error {
+	for _, name := range names {
+		admin, err := serv.UserService.BootstrapAdmin(ctx, name)
+		if err != nil {
+			return fmt.Errorf("error bootstrapping admin %q: %w", name, err)
+		}
+
+		token, err := serv.AccessTokenService.BootstrapAccessToken(ctx, admin.ID)
+		if err != nil {
+			return fmt.Errorf("error issuing token for admin %q: %w", name, err)
+		}
+		if _, err = fmt.Fprintf(out, "admin %s access token: %s\n", admin.Name, token); err != nil {
+			return fmt.Errorf("error printing token for admin %q: %w", name, err)
+		}
+		logger.Info("admin bootstrapped", log.String("name", admin.Name), log.String("id", admin.ID.String()))
+	}
+	return nil
+}
+
+func mediaLimits(cfg config.MediaConfig) model.MediaLimits {
+	return model.MediaLimits{
+		MaxImageSize:  cfg.GetMaxImageSize(),
+		MaxVideoSize:  cfg.GetMaxVideoSize(),
+		ThumbnailSize: cfg.GetThumbnailSize(),
+	}
+}
+
+func postLimits(cfg config.PostConfig) model.PostLimits {
+	return model.PostLimits{
+		MaxTextLength: cfg.GetMaxTextLength(),
+		EditWindow:    cfg.GetEditWindow(),
+		MaxTTL:        cfg.GetMaxTTL(),
+		MaxPinned:     cfg.GetMaxPinned(),
+	}
+}
+
+func spamLimits(cfg config.SpamConfig) model.SpamLimits {
+	return model.SpamLimits{
+		Window:                cfg.GetWindow(),
+		MaxPosts:              cfg.GetMaxPosts(),
+		MaxPostsPerIP:         cfg.GetMaxPostsPerIP(),
+		MaxLikes:              cfg.GetMaxLikes(),
+		MaxLikesPerIP:         cfg.GetMaxLikesPerIP(),
+		MaxRegistrationsPerIP: cfg.GetMaxRegistrationsPerIP(),
+		NewAccountAge:         cfg.GetNewAccountAge(),
+		DuplicateSimilarity:   cfg.GetDuplicateSimilarity(),
+		DuplicateHistory:      cfg.GetDuplicateHistory(),
+		MaxDuplicatesPerIP:    cfg.GetMaxDuplicatesPerIP(),
+		QuarantineScore:       cfg.GetQuarantineScore(),
+		ScoreDecay:            cfg.GetScoreDecay(),
+	}
+}
+
+// contentPolicy собирает правила контент-политики из конфига и
+// возвращает списки слов отдельно, чтобы следить за их файлами.
+func contentPolicy(cfg config.PolicyConfig) (*policy.Pipeline, []*policy.WordFilter, error) {
+	var rules []policy.Rule
+	var filters []*policy.WordFilter
+
+	for _, list := range cfg.GetWordLists() {
+		filter, err := policy.NewWordFilter(list.File, model.PolicyAction(list.Action))
+		if err != nil {
+			return nil, nil, fmt.Errorf("word list %s: %w", list.File, err)
+		}
+		filters = append(filters, filter)
+		rules = append(rules, filter)
+	}
+
+	if domains := cfg.GetBlockedDomains(); len(domains) > 0 {
+		blocklist, err := policy.NewDomainBlocklist(domains, model.PolicyAction(cfg.GetBlockedDomainsAction()))
+		if err != nil {
+			return nil, nil, fmt.Errorf("blocked domains: %w", err)
+		}
+		rules = append(rules, blocklist)
+	}
+
+	if maxMentions := cfg.GetMaxMentions(); maxMentions > 0 {
+		limit, err := policy.NewMentionLimit(maxMentions, model.PolicyAction(cfg.GetMaxMentionsAction()))
+		if err != nil {
+			return nil, nil, fmt.Errorf("max mentions: %w", err)
+		}
+		rules = append(rules, limit)
+	}
+
+	return policy.NewPipeline(rules...), filters, nil
+}
+
 func (a *App) Run() error {
 	defer a.logger.Close()
 	defer a.likeQueue.Close()
+	defer a.voteQueue.Close()
+	defer a.sweeper.Close()
+	defer a.scheduler.Close()
+	defer a.lifter.Close()
+	defer a.watcher.Close()
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%s", a.httpCfg.GetPort()),
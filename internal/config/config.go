@@ -18,6 +18,65 @@ type HTTPConfig interface {
 	GetIdleTimeout() time.Duration
 }
 
+type MediaConfig interface {
+	GetDir() string
+	GetMaxImageSize() int64
+	GetMaxVideoSize() int64
+	GetMaxUploadSize() int64
+	GetThumbnailSize() int
+}
+
+type SchedulerConfig interface {
+	GetInterval() time.Duration
+}
+
+type PostConfig interface {
+	GetMaxTextLength() int
+	GetEditWindow() time.Duration
+	GetMaxTTL() time.Duration
+	GetSweepInterval() time.Duration
+	GetMaxPinned() int
+}
+
+type SanctionConfig interface {
+	GetLiftInterval() time.Duration
+}
+
+// WordListConfig - файл со словами и выражениями контент-политики и
+// действие при совпадении.
+type WordListConfig struct {
+	File   string `yaml:"file"`
+	Action string `yaml:"action"`
+}
+
+type PolicyConfig interface {
+	GetReloadInterval() time.Duration
+	GetWordLists() []WordListConfig
+	GetBlockedDomains() []string
+	GetBlockedDomainsAction() string
+	GetMaxMentions() int
+	GetMaxMentionsAction() string
+}
+
+type SpamConfig interface {
+	GetWindow() time.Duration
+	GetMaxPosts() int
+	GetMaxPostsPerIP() int
+	GetMaxLikes() int
+	GetMaxLikesPerIP() int
+	GetMaxRegistrationsPerIP() int
+	GetNewAccountAge() time.Duration
+	GetDuplicateSimilarity() float64
+	GetDuplicateHistory() int
+	GetMaxDuplicatesPerIP() int
+	GetQuarantineScore() int
+	GetScoreDecay() time.Duration
+}
+
+type RBACConfig interface {
+	GetBootstrapAdmins() []string
+}
+
 func LoadEnv(path string) error {
 	if err := godotenv.Load(path); err != nil {
 		return fmt.Errorf("error loading .env file: %w", err)
@@ -0,0 +1,54 @@
+package env
+
+import (
+	"fmt"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"micro-blog/internal/config"
+)
+
+type mediaConfig struct {
+	Dir           string `yaml:"dir" env-default:"./data/media"`
+	MaxImageSize  int64  `yaml:"max_image_size" env-default:"10485760"`
+	MaxVideoSize  int64  `yaml:"max_video_size" env-default:"52428800"`
+	ThumbnailSize int    `yaml:"thumbnail_size" env-default:"320"`
+}
+
+func MediaConfigLoad() (*mediaConfig, error) {
+	path, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg struct {
+		Media mediaConfig `yaml:"media"`
+	}
+
+	if err = cleanenv.ReadConfig(path, &cfg); err != nil {
+		return nil, fmt.Errorf("%s", err)
+	}
+
+	return &cfg.Media, nil
+}
+
+func (cfg *mediaConfig) GetDir() string {
+	return cfg.Dir
+}
+
+func (cfg *mediaConfig) GetMaxImageSize() int64 {
+	return cfg.MaxImageSize
+}
+
+func (cfg *mediaConfig) GetMaxVideoSize() int64 {
+	return cfg.MaxVideoSize
+}
+
+// GetMaxUploadSize - предел тела запроса загрузки: наибольший из лимитов
+// по типам файлов.
+func (cfg *mediaConfig) GetMaxUploadSize() int64 {
+	return max(cfg.MaxImageSize, cfg.MaxVideoSize)
+}
+
+func (cfg *mediaConfig) GetThumbnailSize() int {
+	return cfg.ThumbnailSize
+}
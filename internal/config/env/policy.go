@@ -0,0 +1,59 @@
+package env
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"micro-blog/internal/config"
+)
+
+type policyConfig struct {
+	ReloadInterval       time.Duration           `yaml:"reload_interval" env-default:"30s"`
+	WordLists            []config.WordListConfig `yaml:"word_lists"`
+	BlockedDomains       []string                `yaml:"blocked_domains"`
+	BlockedDomainsAction string                  `yaml:"blocked_domains_action" env-default:"reject"`
+	MaxMentions          int                     `yaml:"max_mentions" env-default:"0"`
+	MaxMentionsAction    string                  `yaml:"max_mentions_action" env-default:"hold"`
+}
+
+func PolicyConfigLoad() (*policyConfig, error) {
+	path, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg struct {
+		Policy policyConfig `yaml:"content_policy"`
+	}
+
+	if err = cleanenv.ReadConfig(path, &cfg); err != nil {
+		return nil, fmt.Errorf("%s", err)
+	}
+
+	return &cfg.Policy, nil
+}
+
+func (cfg *policyConfig) GetReloadInterval() time.Duration {
+	return cfg.ReloadInterval
+}
+
+func (cfg *policyConfig) GetWordLists() []config.WordListConfig {
+	return cfg.WordLists
+}
+
+func (cfg *policyConfig) GetBlockedDomains() []string {
+	return cfg.BlockedDomains
+}
+
+func (cfg *policyConfig) GetBlockedDomainsAction() string {
+	return cfg.BlockedDomainsAction
+}
+
+func (cfg *policyConfig) GetMaxMentions() int {
+	return cfg.MaxMentions
+}
+
+func (cfg *policyConfig) GetMaxMentionsAction() string {
+	return cfg.MaxMentionsAction
+}
@@ -0,0 +1,54 @@
+package env
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"micro-blog/internal/config"
+)
+
+type postConfig struct {
+	MaxTextLength int           `yaml:"max_text_length" env-default:"500"`
+	EditWindow    time.Duration `yaml:"edit_window" env-default:"15m"`
+	MaxTTL        time.Duration `yaml:"max_ttl" env-default:"720h"`
+	SweepInterval time.Duration `yaml:"sweep_interval" env-default:"1m"`
+	MaxPinned     int           `yaml:"max_pinned" env-default:"3"`
+}
+
+func PostConfigLoad() (*postConfig, error) {
+	path, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg struct {
+		Posts postConfig `yaml:"posts"`
+	}
+
+	if err = cleanenv.ReadConfig(path, &cfg); err != nil {
+		return nil, fmt.Errorf("%s", err)
+	}
+
+	return &cfg.Posts, nil
+}
+
+func (cfg *postConfig) GetMaxTextLength() int {
+	return cfg.MaxTextLength
+}
+
+func (cfg *postConfig) GetEditWindow() time.Duration {
+	return cfg.EditWindow
+}
+
+func (cfg *postConfig) GetMaxTTL() time.Duration {
+	return cfg.MaxTTL
+}
+
+func (cfg *postConfig) GetSweepInterval() time.Duration {
+	return cfg.SweepInterval
+}
+
+func (cfg *postConfig) GetMaxPinned() int {
+	return cfg.MaxPinned
+}
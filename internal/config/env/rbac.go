@@ -0,0 +1,33 @@
+package env
+
+import (
+	"fmt"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"micro-blog/internal/config"
+)
+
+type rbacConfig struct {
+	BootstrapAdmins []string `yaml:"bootstrap_admins"`
+}
+
+func RBACConfigLoad() (*rbacConfig, error) {
+	path, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg struct {
+		RBAC rbacConfig `yaml:"rbac"`
+	}
+
+	if err = cleanenv.ReadConfig(path, &cfg); err != nil {
+		return nil, fmt.Errorf("%s", err)
+	}
+
+	return &cfg.RBAC, nil
+}
+
+func (cfg *rbacConfig) GetBootstrapAdmins() []string {
+	return cfg.BootstrapAdmins
+}
@@ -0,0 +1,34 @@
+package env
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"micro-blog/internal/config"
+)
+
+type sanctionConfig struct {
+	LiftInterval time.Duration `yaml:"lift_interval" env-default:"1m"`
+}
+
+func SanctionConfigLoad() (*sanctionConfig, error) {
+	path, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg struct {
+		Sanctions sanctionConfig `yaml:"sanctions"`
+	}
+
+	if err = cleanenv.ReadConfig(path, &cfg); err != nil {
+		return nil, fmt.Errorf("%s", err)
+	}
+
+	return &cfg.Sanctions, nil
+}
+
+func (cfg *sanctionConfig) GetLiftInterval() time.Duration {
+	return cfg.LiftInterval
+}
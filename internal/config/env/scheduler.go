@@ -0,0 +1,34 @@
+package env
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"micro-blog/internal/config"
+)
+
+type schedulerConfig struct {
+	Interval time.Duration `yaml:"interval" env-default:"10s"`
+}
+
+func SchedulerConfigLoad() (*schedulerConfig, error) {
+	path, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg struct {
+		Scheduler schedulerConfig `yaml:"scheduler"`
+	}
+
+	if err = cleanenv.ReadConfig(path, &cfg); err != nil {
+		return nil, fmt.Errorf("%s", err)
+	}
+
+	return &cfg.Scheduler, nil
+}
+
+func (cfg *schedulerConfig) GetInterval() time.Duration {
+	return cfg.Interval
+}
@@ -0,0 +1,89 @@
+package env
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"micro-blog/internal/config"
+)
+
+type spamConfig struct {
+	Window                time.Duration `yaml:"window" env-default:"1m"`
+	MaxPosts              int           `yaml:"max_posts" env-default:"10"`
+	MaxPostsPerIP         int           `yaml:"max_posts_per_ip" env-default:"30"`
+	MaxLikes              int           `yaml:"max_likes" env-default:"60"`
+	MaxLikesPerIP         int           `yaml:"max_likes_per_ip" env-default:"180"`
+	MaxRegistrationsPerIP int           `yaml:"max_registrations_per_ip" env-default:"5"`
+	NewAccountAge         time.Duration `yaml:"new_account_age" env-default:"24h"`
+	DuplicateSimilarity   float64       `yaml:"duplicate_similarity" env-default:"0.8"`
+	DuplicateHistory      int           `yaml:"duplicate_history" env-default:"20"`
+	MaxDuplicatesPerIP    int           `yaml:"max_duplicates_per_ip" env-default:"5"`
+	QuarantineScore       int           `yaml:"quarantine_score" env-default:"20"`
+	ScoreDecay            time.Duration `yaml:"score_decay" env-default:"1h"`
+}
+
+func SpamConfigLoad() (*spamConfig, error) {
+	path, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg struct {
+		Spam spamConfig `yaml:"spam"`
+	}
+
+	if err = cleanenv.ReadConfig(path, &cfg); err != nil {
+		return nil, fmt.Errorf("%s", err)
+	}
+
+	return &cfg.Spam, nil
+}
+
+func (cfg *spamConfig) GetWindow() time.Duration {
+	return cfg.Window
+}
+
+func (cfg *spamConfig) GetMaxPosts() int {
+	return cfg.MaxPosts
+}
+
+func (cfg *spamConfig) GetMaxPostsPerIP() int {
+	return cfg.MaxPostsPerIP
+}
+
+func (cfg *spamConfig) GetMaxLikes() int {
+	return cfg.MaxLikes
+}
+
+func (cfg *spamConfig) GetMaxLikesPerIP() int {
+	return cfg.MaxLikesPerIP
+}
+
+func (cfg *spamConfig) GetMaxRegistrationsPerIP() int {
+	return cfg.MaxRegistrationsPerIP
+}
+
+func (cfg *spamConfig) GetNewAccountAge() time.Duration {
+	return cfg.NewAccountAge
+}
+
+func (cfg *spamConfig) GetDuplicateSimilarity() float64 {
+	return cfg.DuplicateSimilarity
+}
+
+func (cfg *spamConfig) GetDuplicateHistory() int {
+	return cfg.DuplicateHistory
+}
+
+func (cfg *spamConfig) GetMaxDuplicatesPerIP() int {
+	return cfg.MaxDuplicatesPerIP
+}
+
+func (cfg *spamConfig) GetQuarantineScore() int {
+	return cfg.QuarantineScore
+}
+
+func (cfg *spamConfig) GetScoreDecay() time.Duration {
+	return cfg.ScoreDecay
+}
@@ -0,0 +1,38 @@
+package converter
+
+import (
+	"github.com/google/uuid"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/model"
+)
+
+func ToAuditEntryRespFromModel(entry *model.AuditEntry) *dto.AuditEntryResp {
+	resp := &dto.AuditEntryResp{
+		ID:         entry.ID.String(),
+		Action:     string(entry.Action),
+		TargetType: string(entry.TargetType),
+		IP:         entry.IP,
+		RequestID:  entry.RequestID,
+		Details:    entry.Details,
+		CreatedAt:  entry.CreatedAt,
+	}
+	if entry.ActorID != uuid.Nil {
+		resp.ActorID = entry.ActorID.String()
+	}
+	if entry.TargetID != uuid.Nil {
+		resp.TargetID = entry.TargetID.String()
+	}
+	return resp
+}
+
+func ToAuditPageRespFromModel(page *model.AuditPage) *dto.AuditPageResp {
+	entries := make([]*dto.AuditEntryResp, len(page.Entries))
+	for i, entry := range page.Entries {
+		entries[i] = ToAuditEntryRespFromModel(entry)
+	}
+
+	return &dto.AuditPageResp{
+		Entries:    entries,
+		NextCursor: toCursorResp(page.NextCursor),
+	}
+}
@@ -0,0 +1,56 @@
+package converter
+
+import (
+	"github.com/google/uuid"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/model"
+)
+
+func ToBookmarkModelFromReq(req *dto.BookmarkReq, userID uuid.UUID, rawPostID string) (*model.Bookmark, error) {
+	postID, err := uuid.Parse(rawPostID)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionID, err := parseOptionalUUID(req.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Bookmark{
+		UserID:       userID,
+		PostID:       postID,
+		CollectionID: collectionID,
+	}, nil
+}
+
+func ToBookmarksPageRespFromModel(page *model.BookmarkPage) *dto.BookmarksPageResp {
+	bookmarks := make([]*dto.BookmarkResp, len(page.Bookmarks))
+	for i, item := range page.Bookmarks {
+		resp := &dto.BookmarkResp{
+			PostID:    item.Bookmark.PostID.String(),
+			CreatedAt: item.Bookmark.CreatedAt,
+			Available: item.Post != nil,
+		}
+		if item.Bookmark.CollectionID != uuid.Nil {
+			resp.CollectionID = item.Bookmark.CollectionID.String()
+		}
+		if item.Post != nil {
+			resp.Post = ToPostRespFromModel(item.Post)
+		}
+		bookmarks[i] = resp
+	}
+
+	return &dto.BookmarksPageResp{
+		Bookmarks:  bookmarks,
+		NextCursor: toCursorResp(page.NextCursor),
+	}
+}
+
+func ToCollectionRespFromModel(collection *model.BookmarkCollection) *dto.CollectionResp {
+	return &dto.CollectionResp{
+		ID:        collection.ID.String(),
+		Name:      collection.Name,
+		CreatedAt: collection.CreatedAt,
+	}
+}
@@ -0,0 +1,98 @@
+package converter
+
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/model"
+)
+
+func ToMemberIDsFromReq(req *dto.CreateConversationReq) ([]uuid.UUID, error) {
+	memberIDs := make([]uuid.UUID, len(req.MemberIDs))
+	for i, raw := range req.MemberIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		memberIDs[i] = id
+	}
+	return memberIDs, nil
+}
+
+func ToConversationRespFromModel(conversation *model.Conversation) *dto.ConversationResp {
+	memberIDs := make([]string, len(conversation.MemberIDs))
+	for i, id := range conversation.MemberIDs {
+		memberIDs[i] = id.String()
+	}
+
+	resp := &dto.ConversationResp{
+		ID:          conversation.ID.String(),
+		MemberIDs:   memberIDs,
+		CreatedAt:   conversation.CreatedAt,
+		UnreadCount: conversation.UnreadCount,
+	}
+	if conversation.LastMessage != nil {
+		resp.LastMessage = ToMessageRespFromModel(conversation.LastMessage)
+	}
+	if conversation.LastReadMessageID != uuid.Nil {
+		resp.LastReadMessageID = conversation.LastReadMessageID.String()
+	}
+	return resp
+}
+
+func ToConversationsPageRespFromModel(page *model.ConversationPage) *dto.ConversationsPageResp {
+	conversations := make([]*dto.ConversationResp, len(page.Conversations))
+	for i, conversation := range page.Conversations {
+		conversations[i] = ToConversationRespFromModel(conversation)
+	}
+
+	return &dto.ConversationsPageResp{
+		Conversations: conversations,
+		NextCursor:    toConversationCursorResp(page.NextCursor),
+	}
+}
+
+// toConversationCursorResp кодирует курсор списка переписок как
+// "<снимок>.<позиция>"; пустая строка - последняя страница.
+func toConversationCursorResp(cursor model.ConversationCursor) string {
+	if cursor.IsZero() {
+		return ""
+	}
+	return strconv.FormatInt(cursor.Snapshot, 10) + "." + strconv.FormatInt(cursor.Before, 10)
+}
+
+func ToMessageModelFromReq(req *dto.SendMessageReq, senderID uuid.UUID, rawConversationID string) (*model.Message, error) {
+	conversationID, err := uuid.Parse(rawConversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Message{
+		ConversationID: conversationID,
+		SenderID:       senderID,
+		Text:           req.Text,
+	}, nil
+}
+
+func ToMessageRespFromModel(message *model.Message) *dto.MessageResp {
+	return &dto.MessageResp{
+		ID:             message.ID.String(),
+		ConversationID: message.ConversationID.String(),
+		SenderID:       message.SenderID.String(),
+		Text:           message.Text,
+		CreatedAt:      message.CreatedAt,
+	}
+}
+
+func ToMessagesPageRespFromModel(page *model.MessagePage) *dto.MessagesPageResp {
+	messages := make([]*dto.MessageResp, len(page.Messages))
+	for i, message := range page.Messages {
+		messages[i] = ToMessageRespFromModel(message)
+	}
+
+	return &dto.MessagesPageResp{
+		Messages:   messages,
+		NextCursor: toCursorResp(page.NextCursor),
+	}
+}
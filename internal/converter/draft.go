@@ -0,0 +1,75 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/model"
+)
+
+func ToDraftModelFromReq(req *dto.DraftReq, authorID uuid.UUID) (*model.Draft, error) {
+	var err error
+	attachmentIDs := make([]uuid.UUID, len(req.AttachmentIDs))
+	for i, raw := range req.AttachmentIDs {
+		if attachmentIDs[i], err = uuid.Parse(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	sensitiveAttachmentIDs := make([]uuid.UUID, len(req.SensitiveAttachmentIDs))
+	for i, raw := range req.SensitiveAttachmentIDs {
+		if sensitiveAttachmentIDs[i], err = uuid.Parse(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	draft := &model.Draft{
+		AuthorID:               authorID,
+		Text:                   req.Text,
+		AttachmentIDs:          attachmentIDs,
+		Visibility:             model.Visibility(req.Visibility),
+		TTL:                    time.Duration(req.TTLSeconds) * time.Second,
+		Poll:                   ToPollModelFromReq(req.Poll),
+		ContentWarning:         req.ContentWarning,
+		Sensitive:              req.Sensitive,
+		SensitiveAttachmentIDs: sensitiveAttachmentIDs,
+	}
+	if req.PublishAt != nil {
+		draft.PublishAt = *req.PublishAt
+	}
+	return draft, nil
+}
+
+func ToDraftRespFromModel(draft *model.Draft) *dto.DraftResp {
+	attachmentIDs := make([]string, len(draft.AttachmentIDs))
+	for i, id := range draft.AttachmentIDs {
+		attachmentIDs[i] = id.String()
+	}
+
+	sensitiveAttachmentIDs := make([]string, len(draft.SensitiveAttachmentIDs))
+	for i, id := range draft.SensitiveAttachmentIDs {
+		sensitiveAttachmentIDs[i] = id.String()
+	}
+
+	resp := &dto.DraftResp{
+		ID:                     draft.ID.String(),
+		Text:                   draft.Text,
+		AttachmentIDs:          attachmentIDs,
+		Visibility:             string(draft.Visibility),
+		TTLSeconds:             int64(draft.TTL / time.Second),
+		ContentWarning:         draft.ContentWarning,
+		Sensitive:              draft.Sensitive,
+		SensitiveAttachmentIDs: sensitiveAttachmentIDs,
+		CreatedAt:              draft.CreatedAt,
+		UpdatedAt:              draft.UpdatedAt,
+	}
+	if draft.Poll != nil {
+		resp.Poll = ToPollRespFromModel(draft.Poll)
+	}
+	if draft.Scheduled() {
+		publishAt := draft.PublishAt
+		resp.PublishAt = &publishAt
+	}
+	return resp
+}
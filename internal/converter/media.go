@@ -0,0 +1,20 @@
+package converter
+
+import (
+	"github.com/google/uuid"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/model"
+)
+
+func ToMediaRespFromModel(media *model.Media) *dto.MediaResp {
+	resp := &dto.MediaResp{
+		ID:          media.ID.String(),
+		Kind:        string(media.Kind),
+		ContentType: media.ContentType,
+		Size:        media.Size,
+	}
+	if media.ThumbnailID != uuid.Nil {
+		resp.ThumbnailID = media.ThumbnailID.String()
+	}
+	return resp
+}
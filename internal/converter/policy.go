@@ -0,0 +1,22 @@
+package converter
+
+import (
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/model"
+)
+
+func ToPolicyErrorRespFromModel(err *model.PolicyError) *dto.ErrorResponse {
+	violations := make([]*dto.PolicyViolationResp, len(err.Violations))
+	for i, violation := range err.Violations {
+		violations[i] = &dto.PolicyViolationResp{
+			Rule:   violation.Rule,
+			Action: string(violation.Action),
+			Detail: violation.Detail,
+		}
+	}
+
+	return &dto.ErrorResponse{
+		Message:    err.Error(),
+		Violations: violations,
+	}
+}
@@ -0,0 +1,61 @@
+package converter
+
+import (
+	"github.com/google/uuid"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/model"
+)
+
+func ToPollModelFromReq(req *dto.PollReq) *model.Poll {
+	if req == nil {
+		return nil
+	}
+
+	options := make([]model.PollOption, len(req.Options))
+	for i, text := range req.Options {
+		options[i] = model.PollOption{Text: text}
+	}
+
+	return &model.Poll{
+		Options:  options,
+		Multiple: req.Multiple,
+		ClosesAt: req.ClosesAt,
+	}
+}
+
+func ToPollRespFromModel(poll *model.Poll) *dto.PollResp {
+	options := make([]*dto.PollOptionResp, len(poll.Options))
+	for i, option := range poll.Options {
+		options[i] = &dto.PollOptionResp{Text: option.Text}
+		if !poll.ResultsHidden {
+			votes := option.Votes
+			options[i].Votes = &votes
+		}
+	}
+
+	resp := &dto.PollResp{
+		Options:       options,
+		Multiple:      poll.Multiple,
+		ClosesAt:      poll.ClosesAt,
+		MyChoices:     poll.MyChoices,
+		ResultsHidden: poll.ResultsHidden,
+	}
+	if !poll.ResultsHidden {
+		votersCount := poll.VotersCount
+		resp.VotersCount = &votersCount
+	}
+	return resp
+}
+
+func ToVoteModelFromReq(req *dto.VoteReq, userID uuid.UUID, rawPostID string) (*model.PollVote, error) {
+	postID, err := uuid.Parse(rawPostID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PollVote{
+		PostID:  postID,
+		UserID:  userID,
+		Choices: req.Choices,
+	}, nil
+}
@@ -1,29 +1,159 @@
 package converter
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"micro-blog/internal/handler/dto"
 	"micro-blog/internal/model"
 )
 
-func ToPostModelFromReq(req *dto.CreatePostReq) (*model.Post, error) {
-	authorID, err := uuid.Parse(req.AuthorID)
+func ToPostModelFromReq(req *dto.CreatePostReq, authorID uuid.UUID) (*model.Post, error) {
+	var err error
+	attachmentIDs := make([]uuid.UUID, len(req.AttachmentIDs))
+	for i, raw := range req.AttachmentIDs {
+		if attachmentIDs[i], err = uuid.Parse(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	replyToID, err := parseOptionalUUID(req.ReplyToID)
+	if err != nil {
+		return nil, err
+	}
+
+	repostOfID, err := parseOptionalUUID(req.RepostOfID)
 	if err != nil {
 		return nil, err
 	}
 
+	sensitiveAttachmentIDs := make([]uuid.UUID, len(req.SensitiveAttachmentIDs))
+	for i, raw := range req.SensitiveAttachmentIDs {
+		if sensitiveAttachmentIDs[i], err = uuid.Parse(raw); err != nil {
+			return nil, err
+		}
+	}
+
 	return &model.Post{
-		ID:       uuid.Nil,
-		AuthorID: authorID,
-		Text:     req.Text,
+		ID:                     uuid.Nil,
+		AuthorID:               authorID,
+		ReplyToID:              replyToID,
+		RepostOfID:             repostOfID,
+		Text:                   req.Text,
+		Poll:                   ToPollModelFromReq(req.Poll),
+		AttachmentIDs:          attachmentIDs,
+		Visibility:             model.Visibility(req.Visibility),
+		TTL:                    time.Duration(req.TTLSeconds) * time.Second,
+		Sensitive:              req.Sensitive,
+		ContentWarning:         req.ContentWarning,
+		SensitiveAttachmentIDs: sensitiveAttachmentIDs,
 	}, nil
 }
 
 func ToPostRespFromModel(post *model.Post) *dto.PostResp {
-	return &dto.PostResp{
-		ID:       post.ID.String(),
-		AuthorID: post.AuthorID.String(),
-		Text:     post.Text,
-		Likes:    post.Likes,
+	reactions := make(map[string]int, len(post.Reactions))
+	for reactionType, count := range post.Reactions {
+		reactions[string(reactionType)] = count
+	}
+
+	attachmentIDs := make([]string, len(post.AttachmentIDs))
+	for i, id := range post.AttachmentIDs {
+		attachmentIDs[i] = id.String()
+	}
+
+	sensitiveAttachmentIDs := make([]string, len(post.SensitiveAttachmentIDs))
+	for i, id := range post.SensitiveAttachmentIDs {
+		sensitiveAttachmentIDs[i] = id.String()
+	}
+
+	entities := make([]*dto.EntityResp, len(post.Entities))
+	for i, entity := range post.Entities {
+		entities[i] = ToEntityRespFromModel(entity)
+	}
+
+	resp := &dto.PostResp{
+		ID:                     post.ID.String(),
+		AuthorID:               post.AuthorID.String(),
+		Text:                   post.Text,
+		LikeCount:              post.Reactions[model.ReactionLike],
+		LikedByMe:              post.MyReaction == model.ReactionLike,
+		Reactions:              reactions,
+		MyReaction:             string(post.MyReaction),
+		Visibility:             string(post.Visibility),
+		AttachmentIDs:          attachmentIDs,
+		Entities:               entities,
+		CreatedAt:              post.CreatedAt,
+		Pinned:                 !post.PinnedAt.IsZero(),
+		Sensitive:              post.Sensitive,
+		Held:                   post.Held,
+		ContentWarning:         post.ContentWarning,
+		SensitiveAttachmentIDs: sensitiveAttachmentIDs,
+		Collapsed:              post.Collapsed,
+	}
+	if !post.EditedAt.IsZero() {
+		editedAt := post.EditedAt
+		resp.Edited = true
+		resp.EditedAt = &editedAt
+	}
+	if post.Poll != nil {
+		resp.Poll = ToPollRespFromModel(post.Poll)
+	}
+	if post.IsReply() {
+		resp.ReplyToID = post.ReplyToID.String()
+	}
+	if post.IsRepost() {
+		resp.RepostOfID = post.RepostOfID.String()
+	}
+	if !post.ExpiresAt.IsZero() {
+		expiresAt := post.ExpiresAt
+		resp.ExpiresAt = &expiresAt
+	}
+	return resp
+}
+
+func ToPostsPageRespFromModel(page *model.PostPage) *dto.PostsPageResp {
+	posts := make([]*dto.PostResp, len(page.Posts))
+	for i, post := range page.Posts {
+		posts[i] = ToPostRespFromModel(post)
+	}
+
+	return &dto.PostsPageResp{
+		Posts:      posts,
+		NextCursor: toCursorResp(page.NextCursor),
+	}
+}
+
+func ToRevisionRespFromModel(revision *model.PostRevision) *dto.RevisionResp {
+	entities := make([]*dto.EntityResp, len(revision.Entities))
+	for i, entity := range revision.Entities {
+		entities[i] = ToEntityRespFromModel(entity)
+	}
+
+	return &dto.RevisionResp{
+		Number:    revision.Number,
+		Text:      revision.Text,
+		Entities:  entities,
+		CreatedAt: revision.CreatedAt,
+	}
+}
+
+func ToEntityRespFromModel(entity model.Entity) *dto.EntityResp {
+	resp := &dto.EntityResp{
+		Type:  string(entity.Type),
+		Start: entity.Start,
+		End:   entity.End,
+		Text:  entity.Text,
+	}
+	if entity.UserID != uuid.Nil {
+		resp.UserID = entity.UserID.String()
+	}
+	return resp
+}
+
+// parseOptionalUUID разбирает необязательный UUID: пустая строка дает uuid.Nil.
+func parseOptionalUUID(raw string) (uuid.UUID, error) {
+	if raw == "" {
+		return uuid.Nil, nil
 	}
+	return uuid.Parse(raw)
 }
@@ -0,0 +1,73 @@
+package converter
+
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/model"
+)
+
+func ToLikeModel(userID uuid.UUID, postIDStr string) (*model.Reaction, error) {
+	return toReactionModel(userID, postIDStr, model.ReactionLike)
+}
+
+func ToReactionModelFromReq(req *dto.ReactionRequest, userID uuid.UUID, postIDStr string) (*model.Reaction, error) {
+	return toReactionModel(userID, postIDStr, model.ReactionType(req.Type))
+}
+
+func ToRemoveReactionModel(userID uuid.UUID, postIDStr string) (*model.Reaction, error) {
+	return toReactionModel(userID, postIDStr, model.ReactionNone)
+}
+
+func toReactionModel(userID uuid.UUID, postIDStr string, reactionType model.ReactionType) (*model.Reaction, error) {
+	postID, err := uuid.Parse(postIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Reaction{
+		UserID: userID,
+		PostID: postID,
+		Type:   reactionType,
+	}, nil
+}
+
+func ToLikesPageRespFromModel(page *model.ReactionPage) *dto.LikesPageResp {
+	likes := make([]*dto.LikerResp, len(page.Reactions))
+	for i, reaction := range page.Reactions {
+		likes[i] = &dto.LikerResp{
+			UserID: reaction.User.ID.String(),
+			Name:   reaction.User.Name,
+		}
+	}
+
+	return &dto.LikesPageResp{
+		Likes:      likes,
+		NextCursor: toCursorResp(page.NextCursor),
+	}
+}
+
+func ToReactionsPageRespFromModel(page *model.ReactionPage) *dto.ReactionsPageResp {
+	reactions := make([]*dto.ReactionResp, len(page.Reactions))
+	for i, reaction := range page.Reactions {
+		reactions[i] = &dto.ReactionResp{
+			UserID: reaction.User.ID.String(),
+			Name:   reaction.User.Name,
+			Type:   string(reaction.Type),
+		}
+	}
+
+	return &dto.ReactionsPageResp{
+		Reactions:  reactions,
+		NextCursor: toCursorResp(page.NextCursor),
+	}
+}
+
+// toCursorResp превращает курсор в строку ответа; нулевой курсор - конец выдачи.
+func toCursorResp(cursor int64) string {
+	if cursor == 0 {
+		return ""
+	}
+	return strconv.FormatInt(cursor, 10)
+}
@@ -0,0 +1,22 @@
+package converter
+
+import (
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/model"
+)
+
+func ToRelationsPageRespFromModel(page *model.RelationPage) *dto.RelationsPageResp {
+	users := make([]*dto.RelatedUserResp, len(page.Users))
+	for i, related := range page.Users {
+		users[i] = &dto.RelatedUserResp{
+			UserID:    related.User.ID.String(),
+			Name:      related.User.Name,
+			CreatedAt: related.CreatedAt,
+		}
+	}
+
+	return &dto.RelationsPageResp{
+		Users:      users,
+		NextCursor: toCursorResp(page.NextCursor),
+	}
+}
@@ -0,0 +1,125 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/model"
+)
+
+func ToReportModelFromReq(
+	req *dto.CreateReportReq,
+	reporterID uuid.UUID,
+	targetType model.ReportTarget,
+	rawTargetID string,
+) (*model.Report, error) {
+	targetID, err := uuid.Parse(rawTargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Report{
+		ReporterID: reporterID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     model.ReportReason(req.Reason),
+		Comment:    req.Comment,
+	}, nil
+}
+
+func ToModerationActionModelFromReq(
+	req *dto.ModerationActionReq,
+	actorID uuid.UUID,
+	rawReportID string,
+) (*model.ModerationAction, error) {
+	reportID, err := uuid.Parse(rawReportID)
+	if err != nil {
+		return nil, err
+	}
+
+	action := &model.ModerationAction{
+		ReportID: reportID,
+		ActorID:  actorID,
+		Type:     model.ModerationActionType(req.Action),
+		Note:     req.Note,
+	}
+	if req.SuspendUntil != nil {
+		action.SuspendUntil = *req.SuspendUntil
+	}
+	return action, nil
+}
+
+func ToReportRespFromModel(report *model.Report) *dto.ReportResp {
+	resp := &dto.ReportResp{
+		ID:         report.ID.String(),
+		TargetType: string(report.TargetType),
+		TargetID:   report.TargetID.String(),
+		Reason:     string(report.Reason),
+		Comment:    report.Comment,
+		Status:     string(report.Status),
+		CreatedAt:  report.CreatedAt,
+		Actions:    make([]*dto.ModerationActionResp, len(report.Actions)),
+	}
+	if report.ReporterID != uuid.Nil {
+		resp.ReporterID = report.ReporterID.String()
+	}
+	if !report.ClosedAt.IsZero() {
+		closedAt := report.ClosedAt
+		resp.ClosedAt = &closedAt
+	}
+
+	for i, action := range report.Actions {
+		actionResp := &dto.ModerationActionResp{
+			ActorID:   action.ActorID.String(),
+			Action:    string(action.Type),
+			Note:      action.Note,
+			CreatedAt: action.CreatedAt,
+		}
+		if !action.SuspendUntil.IsZero() {
+			suspendUntil := action.SuspendUntil
+			actionResp.SuspendUntil = &suspendUntil
+		}
+		resp.Actions[i] = actionResp
+	}
+	return resp
+}
+
+func ToReportsPageRespFromModel(page *model.ReportPage) *dto.ReportsPageResp {
+	reports := make([]*dto.ReportResp, len(page.Reports))
+	for i, report := range page.Reports {
+		reports[i] = ToReportRespFromModel(report)
+	}
+
+	return &dto.ReportsPageResp{
+		Reports:    reports,
+		NextCursor: toCursorResp(page.NextCursor),
+	}
+}
+
+func ToSanctionModelFromReq(req *dto.SanctionReq, sanctionType model.SanctionType) *model.Sanction {
+	return &model.Sanction{
+		Type:   sanctionType,
+		Reason: req.Reason,
+		Until:  req.Until,
+	}
+}
+
+func ToUserSanctionsRespFromModel(user *model.User) *dto.UserSanctionsResp {
+	now := time.Now()
+	resp := &dto.UserSanctionsResp{
+		ID:   user.ID.String(),
+		Name: user.Name,
+	}
+	if user.Suspended(now) {
+		until := user.SuspendedUntil
+		resp.SuspendedUntil = &until
+		resp.SuspensionReason = user.SuspensionReason
+	}
+	if user.ShadowBanned(now) {
+		until := user.ShadowBannedUntil
+		resp.ShadowBannedUntil = &until
+		resp.ShadowBanReason = user.ShadowBanReason
+	}
+	return resp
+}
@@ -0,0 +1,43 @@
+package converter
+
+import (
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/model"
+)
+
+func ToSpamAccountRespFromModel(account *model.FlaggedAccount) *dto.SpamAccountResp {
+	flag := account.Flag
+	resp := &dto.SpamAccountResp{
+		UserID:    account.User.ID.String(),
+		Name:      account.User.Name,
+		Score:     flag.Score,
+		Signals:   make(map[string]int, len(flag.Signals)),
+		LastIP:    flag.LastIP,
+		FlaggedAt: flag.FlaggedAt,
+		UpdatedAt: flag.UpdatedAt,
+	}
+	for signal, count := range flag.Signals {
+		resp.Signals[string(signal)] = count
+	}
+	if !account.User.CreatedAt.IsZero() {
+		createdAt := account.User.CreatedAt
+		resp.CreatedAt = &createdAt
+	}
+	if flag.Quarantined() {
+		quarantinedAt := flag.QuarantinedAt
+		resp.QuarantinedAt = &quarantinedAt
+	}
+	return resp
+}
+
+func ToSpamAccountsPageRespFromModel(page *model.FlaggedAccountPage) *dto.SpamAccountsPageResp {
+	accounts := make([]*dto.SpamAccountResp, len(page.Accounts))
+	for i, account := range page.Accounts {
+		accounts[i] = ToSpamAccountRespFromModel(account)
+	}
+
+	return &dto.SpamAccountsPageResp{
+		Accounts:   accounts,
+		NextCursor: toCursorResp(page.NextCursor),
+	}
+}
@@ -13,8 +13,70 @@ func ToUserModelFromReq(req *dto.CreateUserReq) *model.User {
 	}
 }
 
-func ToUserRespFromModel(user *model.User) *dto.CreateUserResp {
+func ToUserRespFromModel(user *model.User, token string) *dto.CreateUserResp {
 	return &dto.CreateUserResp{
-		ID: user.ID.String(),
+		ID:    user.ID.String(),
+		Token: token,
 	}
 }
+
+func ToRenameUserRespFromModel(user *model.User) *dto.RenameUserResp {
+	return &dto.RenameUserResp{
+		ID:   user.ID.String(),
+		Name: user.Name,
+	}
+}
+
+func ToUserRoleRespFromModel(user *model.User) *dto.UserRoleResp {
+	role := user.Role
+	if role == "" {
+		role = model.RoleUser
+	}
+
+	return &dto.UserRoleResp{
+		ID:   user.ID.String(),
+		Name: user.Name,
+		Role: string(role),
+	}
+}
+
+func ToProfileUpdateFromReq(req *dto.UpdateProfileReq) *model.ProfileUpdate {
+	return &model.ProfileUpdate{
+		DisplayName:     req.DisplayName,
+		Bio:             req.Bio,
+		Location:        req.Location,
+		Website:         req.Website,
+		AvatarID:        req.AvatarID,
+		Private:         req.Private,
+		ExpandSensitive: req.ExpandSensitive,
+	}
+}
+
+func ToProfileRespFromModel(profile *model.Profile) *dto.UserProfileResp {
+	return &dto.UserProfileResp{
+		ID:             profile.User.ID.String(),
+		Name:           profile.User.Name,
+		DisplayName:    profile.User.DisplayName,
+		Bio:            profile.User.Bio,
+		Location:       profile.User.Location,
+		Website:        profile.User.Website,
+		AvatarID:       profile.User.AvatarID,
+		Private:        profile.User.Private,
+		PostsCount:     profile.PostsCount,
+		FollowersCount: profile.FollowersCount,
+		FollowingCount: profile.FollowingCount,
+	}
+}
+
+// ToOwnProfileRespFromModel - профиль для его владельца: вместе с
+// публичными полями выводит личные настройки.
+func ToOwnProfileRespFromModel(profile *model.Profile) *dto.UserProfileResp {
+	resp := ToProfileRespFromModel(profile)
+	expandSensitive := profile.User.ExpandSensitive
+	resp.ExpandSensitive = &expandSensitive
+	return resp
+}
+
+func ToAccessTokenResp(token string) *dto.AccessTokenResp {
+	return &dto.AccessTokenResp{Token: token}
+}
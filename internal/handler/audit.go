@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/converter"
+	"micro-blog/internal/handler/pkg/response"
+	"micro-blog/internal/logger"
+	"micro-blog/internal/middleware"
+	"micro-blog/internal/model"
+	"micro-blog/pkg/pkglogger"
+)
+
+type AuditService interface {
+	ListAuditLog(
+		ctx context.Context,
+		actorID uuid.UUID,
+		filter model.AuditFilter,
+		cursor int64,
+		limit int,
+	) (*model.AuditPage, error)
+	ExportAuditLog(
+		ctx context.Context,
+		actorID uuid.UUID,
+		filter model.AuditFilter,
+		yield func(entry *model.AuditEntry) error,
+	) error
+}
+
+// AuditHandler отдает журнал аудита /admin/audit постранично и целиком
+// в формате JSON Lines.
+type AuditHandler struct {
+	Service AuditService
+	logger  logger.Logger
+}
+
+func NewAuditHandler(service AuditService, logger logger.Logger) *AuditHandler {
+	return &AuditHandler{
+		Service: service,
+		logger:  logger,
+	}
+}
+
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	cursor, limit, err := parsePage(r)
+	if err != nil {
+		response.WriteError(w, ErrPageParams, http.StatusBadRequest)
+		h.logger.Info(ErrPageParams, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	filter, err := parseAuditFilter(r)
+	if err != nil {
+		response.WriteError(w, ErrFilterParams, http.StatusBadRequest)
+		h.logger.Info(ErrFilterParams, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	page, err := h.Service.ListAuditLog(r.Context(), userID, filter, cursor, limit)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to list audit log", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful list audit log")
+	response.SuccessJSON(w, converter.ToAuditPageRespFromModel(page), http.StatusOK)
+}
+
+// Export выгружает все подходящие записи по одной JSON-записи на строку.
+// Ответ пишется по мере чтения журнала, поэтому ошибку посреди выгрузки
+// клиенту уже не сообщить - она только логируется.
+func (h *AuditHandler) Export(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	filter, err := parseAuditFilter(r)
+	if err != nil {
+		response.WriteError(w, ErrFilterParams, http.StatusBadRequest)
+		h.logger.Info(ErrFilterParams, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	// Заголовки пишутся перед первой записью, чтобы отказ в доступе
+	// успел вернуться обычной ошибкой.
+	started := false
+	start := func() {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", `attachment; filename="audit.jsonl"`)
+		w.WriteHeader(http.StatusOK)
+		started = true
+	}
+
+	encoder := json.NewEncoder(w)
+	err = h.Service.ExportAuditLog(r.Context(), userID, filter, func(entry *model.AuditEntry) error {
+		if !started {
+			start()
+		}
+		return encoder.Encode(converter.ToAuditEntryRespFromModel(entry))
+	})
+	switch {
+	case err != nil && !started:
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to export audit log", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	case err != nil:
+		h.logger.ErrorContext(r.Context(), "audit log export interrupted", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	case !started:
+		start()
+	}
+
+	h.logger.InfoContext(r.Context(), "successful export audit log")
+}
+
+func (h *AuditHandler) user(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// parseAuditFilter читает фильтры журнала: action, actor_id, target_id,
+// since и until (RFC 3339).
+func parseAuditFilter(r *http.Request) (model.AuditFilter, error) {
+	query := r.URL.Query()
+
+	var filter model.AuditFilter
+	if raw := query.Get("action"); raw != "" {
+		filter.Action = model.AuditAction(raw)
+		if !filter.Action.Valid() {
+			return model.AuditFilter{}, errFilterParams
+		}
+	}
+
+	ids := []struct {
+		name  string
+		value *uuid.UUID
+	}{
+		{name: "actor_id", value: &filter.ActorID},
+		{name: "target_id", value: &filter.TargetID},
+	}
+	for _, param := range ids {
+		raw := query.Get(param.name)
+		if raw == "" {
+			continue
+		}
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return model.AuditFilter{}, errFilterParams
+		}
+		*param.value = id
+	}
+
+	times := []struct {
+		name  string
+		value *time.Time
+	}{
+		{name: "since", value: &filter.Since},
+		{name: "until", value: &filter.Until},
+	}
+	for _, param := range times {
+		raw := query.Get(param.name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return model.AuditFilter{}, errFilterParams
+		}
+		*param.value = t
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/converter"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/handler/pkg/response"
+	"micro-blog/internal/logger"
+	"micro-blog/internal/middleware"
+	"micro-blog/internal/model"
+	"micro-blog/pkg/pkglogger"
+)
+
+type BookmarkService interface {
+	AddBookmark(ctx context.Context, bookmark *model.Bookmark) error
+	RemoveBookmark(ctx context.Context, userID, postID uuid.UUID) error
+	ListBookmarks(
+		ctx context.Context,
+		userID uuid.UUID,
+		collectionID uuid.UUID,
+		cursor int64,
+		limit int,
+	) (*model.BookmarkPage, error)
+	CreateCollection(ctx context.Context, ownerID uuid.UUID, name string) (*model.BookmarkCollection, error)
+	ListCollections(ctx context.Context, ownerID uuid.UUID) ([]*model.BookmarkCollection, error)
+	DeleteCollection(ctx context.Context, ownerID, collectionID uuid.UUID) error
+}
+
+// BookmarkHandler обслуживает закладки текущего пользователя; все ручки
+// требуют идентификации.
+type BookmarkHandler struct {
+	Service BookmarkService
+	logger  logger.Logger
+}
+
+func NewBookmarkHandler(service BookmarkService, logger logger.Logger) *BookmarkHandler {
+	return &BookmarkHandler{
+		Service: service,
+		logger:  logger,
+	}
+}
+
+func (h *BookmarkHandler) Add(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	// Тело необязательно: без него закладка создается вне коллекций.
+	var req dto.BookmarkReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	v := getValidator(r)
+	if err := v.Struct(req); err != nil {
+		response.WriteError(w, ErrRequestFields, http.StatusBadRequest)
+		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	bookmark, err := converter.ToBookmarkModelFromReq(&req, userID, r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	if err = h.Service.AddBookmark(r.Context(), bookmark); err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to add bookmark", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "bookmark successful added")
+	response.SuccessCode(w, http.StatusOK)
+}
+
+func (h *BookmarkHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	postID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	if err = h.Service.RemoveBookmark(r.Context(), userID, postID); err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to remove bookmark", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "bookmark successful removed")
+	response.SuccessCode(w, http.StatusOK)
+}
+
+func (h *BookmarkHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	collectionID := uuid.Nil
+	if raw := r.URL.Query().Get("collection_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+			h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+			return
+		}
+		collectionID = id
+	}
+
+	cursor, limit, err := parsePage(r)
+	if err != nil {
+		response.WriteError(w, ErrPageParams, http.StatusBadRequest)
+		h.logger.Info(ErrPageParams, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	page, err := h.Service.ListBookmarks(r.Context(), userID, collectionID, cursor, limit)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to list bookmarks", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful list bookmarks")
+	response.SuccessJSON(w, converter.ToBookmarksPageRespFromModel(page), http.StatusOK)
+}
+
+func (h *BookmarkHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateCollectionReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	v := getValidator(r)
+	if err := v.Struct(req); err != nil {
+		response.WriteError(w, ErrRequestFields, http.StatusBadRequest)
+		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	collection, err := h.Service.CreateCollection(r.Context(), userID, req.Name)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to create bookmark collection", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "bookmark collection successful created")
+	response.SuccessJSON(w, converter.ToCollectionRespFromModel(collection), http.StatusCreated)
+}
+
+func (h *BookmarkHandler) ListCollections(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	collections, err := h.Service.ListCollections(r.Context(), userID)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to list bookmark collections", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	collectionsResp := make([]*dto.CollectionResp, len(collections))
+	for i, collection := range collections {
+		collectionsResp[i] = converter.ToCollectionRespFromModel(collection)
+	}
+
+	h.logger.InfoContext(r.Context(), "successful list bookmark collections")
+	response.SuccessJSON(w, collectionsResp, http.StatusOK)
+}
+
+func (h *BookmarkHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	if err = h.Service.DeleteCollection(r.Context(), userID, collectionID); err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to delete bookmark collection", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "bookmark collection successful deleted")
+	response.SuccessCode(w, http.StatusOK)
+}
+
+func (h *BookmarkHandler) user(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
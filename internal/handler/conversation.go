@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/converter"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/handler/pkg/response"
+	"micro-blog/internal/logger"
+	"micro-blog/internal/middleware"
+	"micro-blog/internal/model"
+	"micro-blog/pkg/pkglogger"
+)
+
+type ConversationService interface {
+	CreateConversation(ctx context.Context, creatorID uuid.UUID, memberIDs []uuid.UUID) (*model.Conversation, error)
+	GetConversation(ctx context.Context, userID, conversationID uuid.UUID) (*model.Conversation, error)
+	ListConversations(ctx context.Context, userID uuid.UUID, cursor model.ConversationCursor, limit int) (*model.ConversationPage, error)
+	SendMessage(ctx context.Context, message *model.Message) (*model.Message, error)
+	ListMessages(
+		ctx context.Context,
+		userID uuid.UUID,
+		conversationID uuid.UUID,
+		cursor int64,
+		limit int,
+	) (*model.MessagePage, error)
+	MarkRead(ctx context.Context, userID, conversationID, messageID uuid.UUID) error
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+// ConversationHandler обслуживает личные переписки текущего пользователя;
+// все ручки требуют идентификации.
+type ConversationHandler struct {
+	Service ConversationService
+	logger  logger.Logger
+}
+
+func NewConversationHandler(service ConversationService, logger logger.Logger) *ConversationHandler {
+	return &ConversationHandler{
+		Service: service,
+		logger:  logger,
+	}
+}
+
+func (h *ConversationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateConversationReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	v := getValidator(r)
+	if err := v.Struct(req); err != nil {
+		response.WriteError(w, ErrRequestFields, http.StatusBadRequest)
+		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	memberIDs, err := converter.ToMemberIDsFromReq(&req)
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	conversation, err := h.Service.CreateConversation(r.Context(), userID, memberIDs)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to create conversation", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "conversation successful created")
+	response.SuccessJSON(w, converter.ToConversationRespFromModel(conversation), http.StatusCreated)
+}
+
+func (h *ConversationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	conversationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	conversation, err := h.Service.GetConversation(r.Context(), userID, conversationID)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to get conversation", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful get conversation")
+	response.SuccessJSON(w, converter.ToConversationRespFromModel(conversation), http.StatusOK)
+}
+
+func (h *ConversationHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	cursor, limit, err := parseConversationPage(r)
+	if err != nil {
+		response.WriteError(w, ErrPageParams, http.StatusBadRequest)
+		h.logger.Info(ErrPageParams, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	page, err := h.Service.ListConversations(r.Context(), userID, cursor, limit)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to list conversations", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful list conversations")
+	response.SuccessJSON(w, converter.ToConversationsPageRespFromModel(page), http.StatusOK)
+}
+
+func (h *ConversationHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.SendMessageReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	v := getValidator(r)
+	if err := v.Struct(req); err != nil {
+		response.WriteError(w, ErrRequestFields, http.StatusBadRequest)
+		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	message, err := converter.ToMessageModelFromReq(&req, userID, r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	sent, err := h.Service.SendMessage(r.Context(), message)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to send message", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "message successful sent")
+	response.SuccessJSON(w, converter.ToMessageRespFromModel(sent), http.StatusCreated)
+}
+
+func (h *ConversationHandler) ListMessages(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	conversationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	cursor, limit, err := parsePage(r)
+	if err != nil {
+		response.WriteError(w, ErrPageParams, http.StatusBadRequest)
+		h.logger.Info(ErrPageParams, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	page, err := h.Service.ListMessages(r.Context(), userID, conversationID, cursor, limit)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to list messages", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful list messages")
+	response.SuccessJSON(w, converter.ToMessagesPageRespFromModel(page), http.StatusOK)
+}
+
+func (h *ConversationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	conversationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	// Тело необязательно: без него прочитанными отмечаются все сообщения.
+	var req dto.MarkReadReq
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	v := getValidator(r)
+	if err = v.Struct(req); err != nil {
+		response.WriteError(w, ErrRequestFields, http.StatusBadRequest)
+		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	messageID := uuid.Nil
+	if req.MessageID != "" {
+		messageID = uuid.MustParse(req.MessageID)
+	}
+
+	if err = h.Service.MarkRead(r.Context(), userID, conversationID, messageID); err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to mark conversation read", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "conversation successful marked read")
+	response.SuccessCode(w, http.StatusOK)
+}
+
+func (h *ConversationHandler) Unread(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	unread, err := h.Service.CountUnread(r.Context(), userID)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to count unread messages", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful count unread messages")
+	response.SuccessJSON(w, dto.UnreadResp{Unread: unread}, http.StatusOK)
+}
+
+func (h *ConversationHandler) user(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/converter"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/handler/pkg/response"
+	"micro-blog/internal/logger"
+	"micro-blog/internal/middleware"
+	"micro-blog/internal/model"
+	"micro-blog/pkg/pkglogger"
+)
+
+type DraftService interface {
+	CreateDraft(ctx context.Context, draft *model.Draft) (*model.Draft, error)
+	GetDraft(ctx context.Context, authorID uuid.UUID, draftID uuid.UUID) (*model.Draft, error)
+	ListDrafts(ctx context.Context, authorID uuid.UUID) ([]*model.Draft, error)
+	UpdateDraft(ctx context.Context, draft *model.Draft) (*model.Draft, error)
+	DeleteDraft(ctx context.Context, authorID uuid.UUID, draftID uuid.UUID) error
+	PublishDraft(ctx context.Context, authorID uuid.UUID, draftID uuid.UUID) (*model.Post, error)
+}
+
+type DraftHandler struct {
+	Service DraftService
+	logger  logger.Logger
+}
+
+func NewDraftHandler(service DraftService, logger logger.Logger) *DraftHandler {
+	return &DraftHandler{
+		Service: service,
+		logger:  logger,
+	}
+}
+
+func (h *DraftHandler) Create(w http.ResponseWriter, r *http.Request) {
+	authorID, ok := h.author(w, r)
+	if !ok {
+		return
+	}
+
+	draftModel, ok := h.decode(w, r, authorID)
+	if !ok {
+		return
+	}
+
+	draft, err := h.Service.CreateDraft(r.Context(), draftModel)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to create draft", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "draft successful created")
+	response.SuccessJSON(w, converter.ToDraftRespFromModel(draft), http.StatusCreated)
+}
+
+func (h *DraftHandler) List(w http.ResponseWriter, r *http.Request) {
+	authorID, ok := h.author(w, r)
+	if !ok {
+		return
+	}
+
+	drafts, err := h.Service.ListDrafts(r.Context(), authorID)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to list drafts", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	draftsResp := make([]*dto.DraftResp, len(drafts))
+	for i, draft := range drafts {
+		draftsResp[i] = converter.ToDraftRespFromModel(draft)
+	}
+
+	h.logger.InfoContext(r.Context(), "successful list drafts")
+	response.SuccessJSON(w, draftsResp, http.StatusOK)
+}
+
+func (h *DraftHandler) Get(w http.ResponseWriter, r *http.Request) {
+	authorID, draftID, ok := h.authorAndDraft(w, r)
+	if !ok {
+		return
+	}
+
+	draft, err := h.Service.GetDraft(r.Context(), authorID, draftID)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to get draft", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful get draft")
+	response.SuccessJSON(w, converter.ToDraftRespFromModel(draft), http.StatusOK)
+}
+
+func (h *DraftHandler) Update(w http.ResponseWriter, r *http.Request) {
+	authorID, draftID, ok := h.authorAndDraft(w, r)
+	if !ok {
+		return
+	}
+
+	draftModel, ok := h.decode(w, r, authorID)
+	if !ok {
+		return
+	}
+	draftModel.ID = draftID
+
+	draft, err := h.Service.UpdateDraft(r.Context(), draftModel)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to update draft", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "draft successful updated")
+	response.SuccessJSON(w, converter.ToDraftRespFromModel(draft), http.StatusOK)
+}
+
+func (h *DraftHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	authorID, draftID, ok := h.authorAndDraft(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.DeleteDraft(r.Context(), authorID, draftID); err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to delete draft", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "draft successful deleted")
+	response.SuccessCode(w, http.StatusOK)
+}
+
+func (h *DraftHandler) Publish(w http.ResponseWriter, r *http.Request) {
+	authorID, draftID, ok := h.authorAndDraft(w, r)
+	if !ok {
+		return
+	}
+
+	post, err := h.Service.PublishDraft(r.Context(), authorID, draftID)
+	if writePolicyError(w, err) {
+		h.logger.Info("draft rejected by content policy", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to publish draft", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "draft successful published")
+	response.SuccessJSON(w, converter.ToPostRespFromModel(post), http.StatusCreated)
+}
+
+// author возвращает текущего пользователя. Анонимам черновики недоступны.
+func (h *DraftHandler) author(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	authorID := middleware.UserIDFromContext(r.Context())
+	if authorID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return uuid.Nil, false
+	}
+	return authorID, true
+}
+
+func (h *DraftHandler) authorAndDraft(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	authorID, ok := h.author(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	draftID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return authorID, draftID, true
+}
+
+func (h *DraftHandler) decode(w http.ResponseWriter, r *http.Request, authorID uuid.UUID) (*model.Draft, bool) {
+	var req dto.DraftReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return nil, false
+	}
+
+	v := getValidator(r)
+	if err := v.Struct(req); err != nil {
+		response.WriteError(w, ErrRequestFields, http.StatusBadRequest)
+		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
+		return nil, false
+	}
+
+	draft, err := converter.ToDraftModelFromReq(&req, authorID)
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return nil, false
+	}
+	return draft, true
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+// AuditEntryResp - запись журнала аудита. ActorID пуст у действий
+// системы и анонимных запросов.
+type AuditEntryResp struct {
+	ID         string            `json:"id"`
+	Action     string            `json:"action"`
+	ActorID    string            `json:"actor_id,omitempty"`
+	TargetType string            `json:"target_type,omitempty"`
+	TargetID   string            `json:"target_id,omitempty"`
+	IP         string            `json:"ip,omitempty"`
+	RequestID  string            `json:"request_id,omitempty"`
+	Details    map[string]string `json:"details,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+type AuditPageResp struct {
+	Entries    []*AuditEntryResp `json:"entries"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
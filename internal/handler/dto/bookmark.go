@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+type BookmarkReq struct {
+	CollectionID string `json:"collection_id" validate:"omitempty,uuid"`
+}
+
+// BookmarkResp - закладка. Если пост удален или недоступен, available
+// равно false, а post не заполняется.
+type BookmarkResp struct {
+	PostID       string    `json:"post_id"`
+	CollectionID string    `json:"collection_id,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	Available    bool      `json:"available"`
+	Post         *PostResp `json:"post,omitempty"`
+}
+
+type BookmarksPageResp struct {
+	Bookmarks  []*BookmarkResp `json:"bookmarks"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+type CreateCollectionReq struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type CollectionResp struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
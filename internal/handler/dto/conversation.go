@@ -0,0 +1,48 @@
+package dto
+
+import "time"
+
+type CreateConversationReq struct {
+	MemberIDs []string `json:"member_ids" validate:"min=1,max=7,dive,uuid"`
+}
+
+// ConversationResp - переписка глазами текущего участника: unread_count
+// и last_read_message_id у каждого участника свои.
+type ConversationResp struct {
+	ID                string       `json:"id"`
+	MemberIDs         []string     `json:"member_ids"`
+	CreatedAt         time.Time    `json:"created_at"`
+	LastMessage       *MessageResp `json:"last_message,omitempty"`
+	UnreadCount       int          `json:"unread_count"`
+	LastReadMessageID string       `json:"last_read_message_id,omitempty"`
+}
+
+type ConversationsPageResp struct {
+	Conversations []*ConversationResp `json:"conversations"`
+	NextCursor    string              `json:"next_cursor,omitempty"`
+}
+
+type SendMessageReq struct {
+	Text string `json:"text" validate:"required"`
+}
+
+type MessageResp struct {
+	ID             string    `json:"id"`
+	ConversationID string    `json:"conversation_id"`
+	SenderID       string    `json:"sender_id"`
+	Text           string    `json:"text"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type MessagesPageResp struct {
+	Messages   []*MessageResp `json:"messages"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+type MarkReadReq struct {
+	MessageID string `json:"message_id" validate:"omitempty,uuid"`
+}
+
+type UnreadResp struct {
+	Unread int `json:"unread"`
+}
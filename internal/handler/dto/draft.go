@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+// DraftReq - содержимое черновика. PUT заменяет черновик целиком, поэтому
+// отсутствующий publish_at снимает публикацию с расписания.
+type DraftReq struct {
+	Text                   string     `json:"text"`
+	AttachmentIDs          []string   `json:"attachment_ids" validate:"max=4,dive,uuid"`
+	Visibility             string     `json:"visibility" validate:"omitempty,oneof=public unlisted followers mentioned"`
+	TTLSeconds             int64      `json:"ttl_seconds" validate:"min=0"`
+	Poll                   *PollReq   `json:"poll"`
+	ContentWarning         string     `json:"content_warning"`
+	Sensitive              bool       `json:"sensitive"`
+	SensitiveAttachmentIDs []string   `json:"sensitive_attachment_ids" validate:"max=4,dive,uuid"`
+	PublishAt              *time.Time `json:"publish_at"`
+}
+
+type DraftResp struct {
+	ID                     string     `json:"id"`
+	Text                   string     `json:"text"`
+	AttachmentIDs          []string   `json:"attachment_ids"`
+	Visibility             string     `json:"visibility"`
+	TTLSeconds             int64      `json:"ttl_seconds,omitempty"`
+	Poll                   *PollResp  `json:"poll,omitempty"`
+	ContentWarning         string     `json:"content_warning,omitempty"`
+	Sensitive              bool       `json:"sensitive"`
+	SensitiveAttachmentIDs []string   `json:"sensitive_attachment_ids,omitempty"`
+	PublishAt              *time.Time `json:"publish_at,omitempty"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+}
@@ -3,4 +3,13 @@ package dto
 type ErrorResponse struct {
 	// Сообщение об ошибке, описывающее проблему.
 	Message string `json:"message,omitempty"`
+	// Правила контент-политики, из-за которых отклонен пост.
+	Violations []*PolicyViolationResp `json:"violations,omitempty"`
+}
+
+// PolicyViolationResp - сработавшее правило контент-политики.
+type PolicyViolationResp struct {
+	Rule   string `json:"rule"`
+	Action string `json:"action"`
+	Detail string `json:"detail,omitempty"`
 }
@@ -0,0 +1,9 @@
+package dto
+
+type MediaResp struct {
+	ID          string `json:"id"`
+	Kind        string `json:"kind"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	ThumbnailID string `json:"thumbnail_id,omitempty"`
+}
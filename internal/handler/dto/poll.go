@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type PollReq struct {
+	Options  []string  `json:"options" validate:"min=2,max=4"`
+	Multiple bool      `json:"multiple"`
+	ClosesAt time.Time `json:"closes_at" validate:"required"`
+}
+
+// PollResp - опрос поста. Пока зритель не проголосовал и опрос открыт,
+// results_hidden равно true, а votes и voters_count не заполняются.
+type PollResp struct {
+	Options       []*PollOptionResp `json:"options"`
+	Multiple      bool              `json:"multiple"`
+	ClosesAt      time.Time         `json:"closes_at"`
+	VotersCount   *int              `json:"voters_count,omitempty"`
+	MyChoices     []int             `json:"my_choices,omitempty"`
+	ResultsHidden bool              `json:"results_hidden"`
+}
+
+type PollOptionResp struct {
+	Text  string `json:"text"`
+	Votes *int   `json:"votes,omitempty"`
+}
+
+type VoteReq struct {
+	Choices []int `json:"choices" validate:"min=1,max=4"`
+}
@@ -1,15 +1,73 @@
 package dto
 
-import "github.com/google/uuid"
+import "time"
 
+// CreatePostReq - новый пост. SensitiveAttachmentIDs - вложения из
+// attachment_ids, скрытые до явного раскрытия.
 type CreatePostReq struct {
-	AuthorID string `json:"author_id" validate:"required"`
-	Text     string `json:"text"`
+	Text                   string   `json:"text"`
+	AttachmentIDs          []string `json:"attachment_ids" validate:"max=4,dive,uuid"`
+	Visibility             string   `json:"visibility" validate:"omitempty,oneof=public unlisted followers mentioned"`
+	TTLSeconds             int64    `json:"ttl_seconds" validate:"min=0"`
+	ReplyToID              string   `json:"reply_to_id" validate:"omitempty,uuid"`
+	RepostOfID             string   `json:"repost_of_id" validate:"omitempty,uuid"`
+	Poll                   *PollReq `json:"poll"`
+	ContentWarning         string   `json:"content_warning"`
+	Sensitive              bool     `json:"sensitive"`
+	SensitiveAttachmentIDs []string `json:"sensitive_attachment_ids" validate:"max=4,dive,uuid"`
 }
 
+// PostResp - пост глазами зрителя. Collapsed подсказывает клиенту, что
+// пост с деликатным содержимым нужно показать свернутым.
 type PostResp struct {
-	ID       string      `json:"id"`
-	AuthorID string      `json:"author_id"`
-	Text     string      `json:"text"`
-	Likes    []uuid.UUID `json:"likes"`
+	ID                     string         `json:"id"`
+	AuthorID               string         `json:"author_id"`
+	ReplyToID              string         `json:"reply_to_id,omitempty"`
+	RepostOfID             string         `json:"repost_of_id,omitempty"`
+	Text                   string         `json:"text"`
+	LikeCount              int            `json:"like_count"`
+	LikedByMe              bool           `json:"liked_by_me"`
+	Reactions              map[string]int `json:"reactions"`
+	MyReaction             string         `json:"my_reaction,omitempty"`
+	Visibility             string         `json:"visibility"`
+	AttachmentIDs          []string       `json:"attachment_ids"`
+	Entities               []*EntityResp  `json:"entities"`
+	Poll                   *PollResp      `json:"poll,omitempty"`
+	CreatedAt              time.Time      `json:"created_at"`
+	Edited                 bool           `json:"edited"`
+	EditedAt               *time.Time     `json:"edited_at,omitempty"`
+	ExpiresAt              *time.Time     `json:"expires_at,omitempty"`
+	Pinned                 bool           `json:"pinned"`
+	Sensitive              bool           `json:"sensitive"`
+	Held                   bool           `json:"held,omitempty"`
+	ContentWarning         string         `json:"content_warning,omitempty"`
+	SensitiveAttachmentIDs []string       `json:"sensitive_attachment_ids,omitempty"`
+	Collapsed              bool           `json:"collapsed"`
+}
+
+type PostsPageResp struct {
+	Posts      []*PostResp `json:"posts"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+type EditPostReq struct {
+	Text string `json:"text"`
+}
+
+// RevisionResp - одна из сохраненных версий текста поста.
+type RevisionResp struct {
+	Number    int           `json:"number"`
+	Text      string        `json:"text"`
+	Entities  []*EntityResp `json:"entities"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// EntityResp - ссылка, упоминание или хештег в тексте. Start и End -
+// смещения в кодовых точках Unicode, End не включается.
+type EntityResp struct {
+	Type   string `json:"type"`
+	Start  int    `json:"start"`
+	End    int    `json:"end"`
+	Text   string `json:"text"`
+	UserID string `json:"user_id,omitempty"`
 }
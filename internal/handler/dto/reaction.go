@@ -0,0 +1,26 @@
+package dto
+
+type ReactionRequest struct {
+	Type string `json:"type" validate:"required"`
+}
+
+type LikerResp struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+type LikesPageResp struct {
+	Likes      []*LikerResp `json:"likes"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+type ReactionResp struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+}
+
+type ReactionsPageResp struct {
+	Reactions  []*ReactionResp `json:"reactions"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
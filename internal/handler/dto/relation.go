@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+type RelatedUserResp struct {
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RelationsPageResp struct {
+	Users      []*RelatedUserResp `json:"users"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
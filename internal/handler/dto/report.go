@@ -0,0 +1,65 @@
+package dto
+
+import "time"
+
+type CreateReportReq struct {
+	Reason  string `json:"reason" validate:"required,oneof=spam harassment hate violence sexual misinformation other"`
+	Comment string `json:"comment"`
+}
+
+// ModerationActionReq - решение модератора по жалобе; suspend_until
+// обязателен для действий suspend и shadow_ban.
+type ModerationActionReq struct {
+	Action       string     `json:"action" validate:"required,oneof=resolve dismiss remove_content suspend shadow_ban mark_sensitive"`
+	Note         string     `json:"note"`
+	SuspendUntil *time.Time `json:"suspend_until" validate:"required_if=Action suspend,required_if=Action shadow_ban"`
+}
+
+type ModerationActionResp struct {
+	ActorID      string     `json:"actor_id"`
+	Action       string     `json:"action"`
+	Note         string     `json:"note,omitempty"`
+	SuspendUntil *time.Time `json:"suspend_until,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// ReportResp - жалоба в очереди модерации. ReporterID пуст у жалоб,
+// которые подала система, например на задержанный политикой пост.
+type ReportResp struct {
+	ID         string                  `json:"id"`
+	ReporterID string                  `json:"reporter_id,omitempty"`
+	TargetType string                  `json:"target_type"`
+	TargetID   string                  `json:"target_id"`
+	Reason     string                  `json:"reason"`
+	Comment    string                  `json:"comment,omitempty"`
+	Status     string                  `json:"status"`
+	CreatedAt  time.Time               `json:"created_at"`
+	ClosedAt   *time.Time              `json:"closed_at,omitempty"`
+	Actions    []*ModerationActionResp `json:"actions"`
+}
+
+type ReportsPageResp struct {
+	Reports    []*ReportResp `json:"reports"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+// MarkSensitiveReq - пустое предупреждение оставляет заданное автором.
+type MarkSensitiveReq struct {
+	ContentWarning string `json:"content_warning"`
+}
+
+type SanctionReq struct {
+	Reason string    `json:"reason"`
+	Until  time.Time `json:"until" validate:"required"`
+}
+
+// UserSanctionsResp - действующие санкции пользователя; снятые санкции
+// не выводятся.
+type UserSanctionsResp struct {
+	ID                string     `json:"id"`
+	Name              string     `json:"name"`
+	SuspendedUntil    *time.Time `json:"suspended_until,omitempty"`
+	SuspensionReason  string     `json:"suspension_reason,omitempty"`
+	ShadowBannedUntil *time.Time `json:"shadow_banned_until,omitempty"`
+	ShadowBanReason   string     `json:"shadow_ban_reason,omitempty"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+// SpamAccountResp - аккаунт с подозрениями на спам. Signals - сколько
+// раз сработал каждый признак.
+type SpamAccountResp struct {
+	UserID        string         `json:"user_id"`
+	Name          string         `json:"name"`
+	CreatedAt     *time.Time     `json:"created_at,omitempty"`
+	Score         int            `json:"score"`
+	Signals       map[string]int `json:"signals"`
+	LastIP        string         `json:"last_ip,omitempty"`
+	FlaggedAt     time.Time      `json:"flagged_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	QuarantinedAt *time.Time     `json:"quarantined_at,omitempty"`
+}
+
+type SpamAccountsPageResp struct {
+	Accounts   []*SpamAccountResp `json:"accounts"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
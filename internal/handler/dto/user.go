@@ -4,6 +4,64 @@ type CreateUserReq struct {
 	Name string `json:"name" validate:"required"`
 }
 
+// CreateUserResp - пользователь и его ключ доступа; ключ предъявляется
+// в заголовке Authorization и повторно не показывается.
 type CreateUserResp struct {
-	ID string `json:"id"`
+	ID    string `json:"id"`
+	Token string `json:"token"`
+}
+
+type RenameUserReq struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type RenameUserResp struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ChangeRoleReq struct {
+	Role string `json:"role" validate:"required,oneof=user moderator admin"`
+}
+
+type UserRoleResp struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// UpdateProfileReq - отсутствующие поля не меняются, пустая строка очищает поле.
+type UpdateProfileReq struct {
+	DisplayName     *string `json:"display_name" validate:"omitnil,max=50"`
+	Bio             *string `json:"bio" validate:"omitnil,max=160"`
+	Location        *string `json:"location" validate:"omitnil,max=30"`
+	Website         *string `json:"website" validate:"omitnil,max=100,eq=|http_url"`
+	AvatarID        *string `json:"avatar_id" validate:"omitnil,eq=|uuid"`
+	Private         *bool   `json:"private"`
+	ExpandSensitive *bool   `json:"expand_sensitive"`
+}
+
+type FollowResp struct {
+	Status string `json:"status"`
+}
+
+type UserProfileResp struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	DisplayName    string `json:"display_name"`
+	Bio            string `json:"bio"`
+	Location       string `json:"location"`
+	Website        string `json:"website"`
+	AvatarID       string `json:"avatar_id,omitempty"`
+	Private        bool   `json:"private"`
+	PostsCount     int    `json:"posts_count"`
+	FollowersCount int    `json:"followers_count"`
+	FollowingCount int    `json:"following_count"`
+	// ExpandSensitive - личная настройка, выводится только владельцу профиля.
+	ExpandSensitive *bool `json:"expand_sensitive,omitempty"`
+}
+
+// AccessTokenResp - выданный ключ доступа; повторно его получить нельзя.
+type AccessTokenResp struct {
+	Token string `json:"token"`
 }
@@ -0,0 +1,111 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/middleware"
+)
+
+func TestRouter_AuditLog(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+	rec := app.doAs(t, alice, http.MethodPost, "/register", dto.CreateUserReq{Name: "alice"})
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = app.doAs(t, app.admin, http.MethodPut, "/admin/users/"+alice+"/role", dto.ChangeRoleReq{Role: "moderator"})
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = app.doAs(t, app.moderator, http.MethodPut, "/admin/users/"+bob+"/suspension",
+		dto.SanctionReq{Reason: "spam", Until: time.Now().Add(time.Hour)})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	failed := app.doAs(t, bob, http.MethodPost, "/register", dto.CreateUserReq{Name: "bob"})
+	require.Equal(t, http.StatusForbidden, failed.Code)
+	requestID := failed.Header().Get(middleware.RequestIDHeader)
+	require.NotEmpty(t, requestID)
+
+	list := func(t *testing.T, query string) dto.AuditPageResp {
+		t.Helper()
+		rec := app.doAs(t, app.admin, http.MethodGet, "/admin/audit"+query, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var page dto.AuditPageResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+		return page
+	}
+
+	page := list(t, "?action=login_failed")
+	require.Len(t, page.Entries, 1)
+	entry := page.Entries[0]
+	assert.Empty(t, entry.ActorID)
+	assert.Equal(t, "user", entry.TargetType)
+	assert.Equal(t, bob, entry.TargetID)
+	assert.Equal(t, "192.0.2.1", entry.IP)
+	assert.Equal(t, requestID, entry.RequestID)
+	assert.Equal(t, "bob", entry.Details["name"])
+
+	page = list(t, "?actor_id="+app.moderator)
+	require.Len(t, page.Entries, 1)
+	assert.Equal(t, "user_sanctioned", page.Entries[0].Action)
+	assert.Equal(t, map[string]string{"sanction": "suspension", "reason": "spam", "until": page.Entries[0].Details["until"]},
+		page.Entries[0].Details)
+
+	page = list(t, "?target_id="+alice)
+	actions := make([]string, len(page.Entries))
+	for i, entry := range page.Entries {
+		actions[i] = entry.Action
+	}
+	assert.Equal(t, []string{"role_changed", "login", "user_registered"}, actions)
+	assert.Equal(t, app.admin, page.Entries[0].ActorID)
+
+	// Запуск сам записывает регистрацию и назначение первого
+	// администратора и выдачу ключей сотрудникам.
+	all := list(t, "")
+	require.Len(t, all.Entries, 10)
+	assert.Equal(t, "user_registered", all.Entries[9].Action)
+	assert.Equal(t, "role_changed", all.Entries[8].Action)
+	assert.Empty(t, all.Entries[8].ActorID)
+	assert.Equal(t, "token_issued", all.Entries[7].Action)
+	assert.Empty(t, all.Entries[7].ActorID)
+
+	first := list(t, "?limit=3")
+	require.Len(t, first.Entries, 3)
+	require.NotEmpty(t, first.NextCursor)
+	rest := list(t, "?cursor="+first.NextCursor)
+	assert.Len(t, rest.Entries, 7)
+
+	future := url.QueryEscape(time.Now().Add(time.Hour).Format(time.RFC3339))
+	assert.Empty(t, list(t, "?since="+future).Entries)
+
+	rec = app.doAs(t, app.admin, http.MethodGet, "/admin/audit?action=reboot", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = app.doAs(t, app.admin, http.MethodGet, "/admin/audit?since=yesterday", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = app.doAs(t, app.moderator, http.MethodGet, "/admin/audit", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = app.doAs(t, app.moderator, http.MethodGet, "/admin/audit/export", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = app.doAs(t, app.admin, http.MethodGet, "/admin/audit/export", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+	require.Len(t, lines, len(all.Entries))
+	for i, line := range lines {
+		var exported dto.AuditEntryResp
+		require.NoError(t, json.Unmarshal([]byte(line), &exported))
+		assert.Equal(t, all.Entries[i].ID, exported.ID)
+	}
+
+	rec = app.doAs(t, app.admin, http.MethodGet, "/admin/audit/export?since="+future, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
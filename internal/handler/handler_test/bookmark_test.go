@@ -0,0 +1,90 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/handler/dto"
+)
+
+func TestRouter_Bookmarks(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+
+	first := app.createPost(t, bob, "first")
+	second := app.createPost(t, bob, "second")
+	rec := app.doAs(t, bob, http.MethodPost, "/posts", dto.CreatePostReq{Text: "brief", TTLSeconds: 60})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var brief dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&brief))
+
+	rec = app.do(t, http.MethodPost, "/posts/"+first+"/bookmark", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/bookmarks/collections", dto.CreateCollectionReq{Name: " Reading "})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var collection dto.CollectionResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&collection))
+	assert.Equal(t, "Reading", collection.Name)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/posts/"+first+"/bookmark", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/posts/"+second+"/bookmark", dto.BookmarkReq{CollectionID: collection.ID})
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/posts/"+brief.ID+"/bookmark", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/posts/"+uuid.NewString()+"/bookmark", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/posts/"+first+"/bookmark", dto.BookmarkReq{CollectionID: uuid.NewString()})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	list := func(userID, query string) dto.BookmarksPageResp {
+		t.Helper()
+		rec := app.doAs(t, userID, http.MethodGet, "/bookmarks?"+query, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var page dto.BookmarksPageResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+		return page
+	}
+
+	page := list(alice, "limit=2")
+	require.Len(t, page.Bookmarks, 2)
+	assert.Equal(t, brief.ID, page.Bookmarks[0].PostID)
+	assert.Equal(t, second, page.Bookmarks[1].PostID)
+	assert.Equal(t, collection.ID, page.Bookmarks[1].CollectionID)
+	require.NotEmpty(t, page.NextCursor)
+	page = list(alice, "limit=2&cursor="+page.NextCursor)
+	require.Len(t, page.Bookmarks, 1)
+	assert.Equal(t, first, page.Bookmarks[0].PostID)
+	assert.Empty(t, page.NextCursor)
+
+	page = list(alice, "collection_id="+collection.ID)
+	require.Len(t, page.Bookmarks, 1)
+	assert.Equal(t, second, page.Bookmarks[0].PostID)
+
+	assert.Empty(t, list(bob, "").Bookmarks, "bookmarks are private, even from the post author")
+
+	app.clock.Advance(time.Minute)
+	page = list(alice, "")
+	require.Len(t, page.Bookmarks, 3)
+	assert.False(t, page.Bookmarks[0].Available)
+	assert.Nil(t, page.Bookmarks[0].Post)
+	assert.True(t, page.Bookmarks[1].Available)
+	assert.Equal(t, "second", page.Bookmarks[1].Post.Text)
+
+	rec = app.doAs(t, alice, http.MethodDelete, "/bookmarks/collections/"+collection.ID, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	page = list(alice, "")
+	assert.Empty(t, page.Bookmarks[1].CollectionID)
+
+	rec = app.doAs(t, alice, http.MethodDelete, "/posts/"+first+"/bookmark", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, list(alice, "").Bookmarks, 2)
+}
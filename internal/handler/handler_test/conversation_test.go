@@ -0,0 +1,133 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/handler/dto"
+)
+
+func TestRouter_Conversations(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+	carol := app.register(t, "carol")
+
+	rec := app.do(t, http.MethodPost, "/conversations", dto.CreateConversationReq{MemberIDs: []string{bob}})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/conversations", dto.CreateConversationReq{MemberIDs: []string{uuid.NewString()}})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	create := func(userID string, memberIDs ...string) dto.ConversationResp {
+		t.Helper()
+		rec := app.doAs(t, userID, http.MethodPost, "/conversations", dto.CreateConversationReq{MemberIDs: memberIDs})
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var conversation dto.ConversationResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&conversation))
+		return conversation
+	}
+	send := func(userID, conversationID, text string) dto.MessageResp {
+		t.Helper()
+		rec := app.doAs(t, userID, http.MethodPost, "/conversations/"+conversationID+"/messages", dto.SendMessageReq{Text: text})
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var message dto.MessageResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&message))
+		return message
+	}
+	unread := func(userID string) int {
+		t.Helper()
+		rec := app.doAs(t, userID, http.MethodGet, "/conversations/unread", nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp dto.UnreadResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		return resp.Unread
+	}
+
+	direct := create(alice, bob)
+	// Личная переписка пары пользователей одна, с какой стороны ее ни создавай.
+	assert.Equal(t, direct.ID, create(bob, alice).ID)
+	group := create(alice, bob, carol)
+	assert.Len(t, group.MemberIDs, 3)
+
+	first := send(alice, direct.ID, "hi bob")
+	send(alice, direct.ID, "are you there?")
+	send(carol, group.ID, "hello all")
+
+	rec = app.doAs(t, carol, http.MethodGet, "/conversations/"+direct.ID+"/messages", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = app.doAs(t, carol, http.MethodPost, "/conversations/"+direct.ID+"/messages", dto.SendMessageReq{Text: "intrude"})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	assert.Equal(t, 3, unread(bob))
+	assert.Equal(t, 1, unread(alice))
+
+	rec = app.doAs(t, bob, http.MethodGet, "/conversations?limit=1", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var page dto.ConversationsPageResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+	require.Len(t, page.Conversations, 1)
+	assert.Equal(t, group.ID, page.Conversations[0].ID)
+	assert.Equal(t, 1, page.Conversations[0].UnreadCount)
+	require.NotEmpty(t, page.NextCursor)
+
+	// Новое сообщение между страницами не выталкивает переписку из списка:
+	// курсор держит порядок на момент первой страницы.
+	send(alice, direct.ID, "still there?")
+
+	rec = app.doAs(t, bob, http.MethodGet, "/conversations?limit=1&cursor="+page.NextCursor, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	page = dto.ConversationsPageResp{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+	require.Len(t, page.Conversations, 1)
+	assert.Equal(t, direct.ID, page.Conversations[0].ID)
+	assert.Equal(t, 3, page.Conversations[0].UnreadCount)
+	require.NotNil(t, page.Conversations[0].LastMessage)
+	assert.Equal(t, "still there?", page.Conversations[0].LastMessage.Text)
+	assert.Empty(t, page.NextCursor)
+
+	rec = app.doAs(t, bob, http.MethodGet, "/conversations?cursor=12", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = app.doAs(t, bob, http.MethodGet, "/conversations/"+direct.ID+"/messages?limit=2", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var messages dto.MessagesPageResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&messages))
+	require.Len(t, messages.Messages, 2)
+	assert.Equal(t, "still there?", messages.Messages[0].Text)
+	assert.Equal(t, "are you there?", messages.Messages[1].Text)
+
+	rec = app.doAs(t, bob, http.MethodGet, "/conversations/"+direct.ID+"/messages?cursor="+messages.NextCursor, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	messages = dto.MessagesPageResp{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&messages))
+	require.Len(t, messages.Messages, 1)
+	assert.Equal(t, first.ID, messages.Messages[0].ID)
+
+	rec = app.doAs(t, bob, http.MethodPost, "/conversations/"+direct.ID+"/read", dto.MarkReadReq{MessageID: first.ID})
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 3, unread(bob))
+
+	rec = app.doAs(t, bob, http.MethodPost, "/conversations/"+direct.ID+"/read", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, unread(bob))
+
+	// Маркер прочтения не сдвигается назад.
+	rec = app.doAs(t, bob, http.MethodPost, "/conversations/"+direct.ID+"/read", dto.MarkReadReq{MessageID: first.ID})
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, unread(bob))
+
+	rec = app.doAs(t, bob, http.MethodPost, "/conversations/"+direct.ID+"/read", dto.MarkReadReq{MessageID: uuid.NewString()})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = app.doAs(t, bob, http.MethodGet, "/conversations/"+direct.ID, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var conversation dto.ConversationResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&conversation))
+	assert.Equal(t, 0, conversation.UnreadCount)
+	assert.NotEmpty(t, conversation.LastReadMessageID)
+}
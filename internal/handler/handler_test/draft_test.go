@@ -0,0 +1,124 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/handler/dto"
+)
+
+func TestRouter_Drafts(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+
+	rec := app.do(t, http.MethodPost, "/drafts", dto.DraftReq{Text: "anon"})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/drafts", dto.DraftReq{Text: "first draft"})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var draft dto.DraftResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&draft))
+	assert.Nil(t, draft.PublishAt)
+
+	assert.Empty(t, app.listPosts(t, alice), "drafts are not visible as posts")
+
+	rec = app.doAs(t, bob, http.MethodGet, "/drafts/"+draft.ID, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	past := time.Now().Add(-time.Hour)
+	rec = app.doAs(t, alice, http.MethodPut, "/drafts/"+draft.ID, dto.DraftReq{Text: "edited", PublishAt: &past})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodPut, "/drafts/"+draft.ID, dto.DraftReq{Text: "edited"})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodGet, "/drafts", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var drafts []dto.DraftResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&drafts))
+	require.Len(t, drafts, 1)
+	assert.Equal(t, "edited", drafts[0].Text)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/drafts/"+draft.ID+"/publish", nil)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodGet, "/drafts/"+draft.ID, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	posts := app.listPosts(t, alice)
+	require.Len(t, posts, 1)
+	assert.Equal(t, "edited", posts[0].Text)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/drafts", dto.DraftReq{Text: "to delete"})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&draft))
+	rec = app.doAs(t, bob, http.MethodDelete, "/drafts/"+draft.ID, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = app.doAs(t, alice, http.MethodDelete, "/drafts/"+draft.ID, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRouter_ScheduledDraftPublishedWhenDue(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+
+	publishAt := time.Now().Add(time.Hour)
+	rec := app.doAs(t, alice, http.MethodPost, "/drafts", dto.DraftReq{Text: "scheduled", PublishAt: &publishAt})
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	published, err := app.serv.DraftService.PublishDue(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+	assert.Empty(t, app.listPosts(t, alice))
+
+	published, err = app.serv.DraftService.PublishDue(context.Background(), publishAt)
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+
+	posts := app.listPosts(t, alice)
+	require.Len(t, posts, 1)
+	assert.Equal(t, "scheduled", posts[0].Text)
+
+	rec = app.doAs(t, alice, http.MethodGet, "/drafts", nil)
+	var drafts []dto.DraftResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&drafts))
+	assert.Empty(t, drafts)
+}
+
+func TestRouter_DraftKeepsPostOptions(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+
+	closesAt := time.Now().Add(24 * time.Hour)
+	rec := app.doAs(t, alice, http.MethodPost, "/drafts", dto.DraftReq{
+		Text:           "vote",
+		TTLSeconds:     3600,
+		Poll:           &dto.PollReq{Options: []string{"yes", "no"}, ClosesAt: closesAt},
+		ContentWarning: "spoilers",
+		Sensitive:      true,
+	})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var draft dto.DraftResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&draft))
+	assert.Equal(t, int64(3600), draft.TTLSeconds)
+	require.NotNil(t, draft.Poll)
+	assert.Equal(t, "spoilers", draft.ContentWarning)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/drafts/"+draft.ID+"/publish", nil)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var post dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&post))
+	assert.NotNil(t, post.ExpiresAt)
+	require.NotNil(t, post.Poll)
+	assert.Len(t, post.Poll.Options, 2)
+	assert.Equal(t, "spoilers", post.ContentWarning)
+	assert.True(t, post.Sensitive)
+}
@@ -0,0 +1,177 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/handler/dto"
+)
+
+func (a *testApp) upload(t *testing.T, userID string, data []byte) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", "upload.bin")
+	require.NoError(t, err)
+	_, err = fw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/media", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	a.authorize(t, req, userID)
+	rec := httptest.NewRecorder()
+	a.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 100, 50))))
+	return buf.Bytes()
+}
+
+func TestRouter_UploadAndServeMedia(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+
+	rec := app.upload(t, alice, testPNG(t))
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var media dto.MediaResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&media))
+	assert.Equal(t, "image/png", media.ContentType)
+	assert.NotEmpty(t, media.ThumbnailID)
+
+	// До публикации файл видит только владелец, и он не кешируется.
+	rec = app.do(t, http.MethodGet, "/media/"+media.ID, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = app.doAs(t, alice, http.MethodGet, "/media/"+media.ID, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "private, no-store", rec.Header().Get("Cache-Control"))
+
+	rec = app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: "pic", AttachmentIDs: []string{media.ID}})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var post dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&post))
+	assert.Equal(t, []string{media.ID}, post.AttachmentIDs)
+
+	rec = app.do(t, http.MethodGet, "/media/"+media.ID, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Cache-Control"), "immutable")
+	full := rec.Body.Bytes()
+
+	req := httptest.NewRequest(http.MethodGet, "/media/"+media.ID, nil)
+	req.Header.Set("Range", "bytes=0-7")
+	rec = httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusPartialContent, rec.Code)
+	assert.Equal(t, full[:8], rec.Body.Bytes())
+
+	req = httptest.NewRequest(http.MethodGet, "/media/"+media.ID, nil)
+	req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+	rec = httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+
+	rec = app.do(t, http.MethodGet, "/media/"+media.ThumbnailID, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	thumb, _, err := image.Decode(rec.Body)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 32, 16), thumb.Bounds())
+
+	bob := app.register(t, "bob")
+	rec = app.doAs(t, bob, http.MethodPost, "/posts", dto.CreatePostReq{Text: "stolen", AttachmentIDs: []string{media.ID}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRouter_MediaVisibility(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+	carol := app.register(t, "carol")
+
+	rec := app.upload(t, alice, testPNG(t))
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var media dto.MediaResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&media))
+
+	rec = app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{
+		Text:          "for followers",
+		Visibility:    "followers",
+		AttachmentIDs: []string{media.ID},
+	})
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = app.doAs(t, bob, http.MethodPost, "/users/"+alice+"/follow", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	for _, id := range []string{media.ID, media.ThumbnailID} {
+		rec = app.do(t, http.MethodGet, "/media/"+id, nil)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		rec = app.doAs(t, carol, http.MethodGet, "/media/"+id, nil)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		rec = app.doAs(t, bob, http.MethodGet, "/media/"+id, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "private, no-store", rec.Header().Get("Cache-Control"))
+	}
+}
+
+func TestRouter_AvatarIsPublic(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+
+	rec := app.upload(t, alice, testPNG(t))
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var media dto.MediaResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&media))
+
+	rec = app.doAs(t, alice, http.MethodPatch, "/users/me", dto.UpdateProfileReq{AvatarID: &media.ID})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	for _, id := range []string{media.ID, media.ThumbnailID} {
+		rec = app.do(t, http.MethodGet, "/media/"+id, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("Cache-Control"), "public")
+	}
+
+	// Замененный аватар снова виден только владельцу.
+	empty := ""
+	rec = app.doAs(t, alice, http.MethodPatch, "/users/me", dto.UpdateProfileReq{AvatarID: &empty})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = app.do(t, http.MethodGet, "/media/"+media.ID, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRouter_UploadMediaRejects(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+
+	rec := app.upload(t, "", testPNG(t))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = app.upload(t, alice, []byte("<html><script>alert(1)</script></html>"))
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+
+	// Предел тела запроса берется из настроек медиа.
+	rec = app.upload(t, alice, append(testPNG(t), bytes.Repeat([]byte{0}, 3<<20)...))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
@@ -0,0 +1,288 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/model"
+	"micro-blog/internal/policy"
+)
+
+func TestRouter_Moderation(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+	carol := app.register(t, "carol")
+	bobPost := app.createPost(t, bob, "buy cheap followers")
+
+	report := func(userID, path string, req dto.CreateReportReq) *httptest.ResponseRecorder {
+		return app.doAs(t, userID, http.MethodPost, path, req)
+	}
+
+	rec := app.do(t, http.MethodPost, "/posts/"+bobPost+"/report", dto.CreateReportReq{Reason: "spam"})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = report(alice, "/posts/"+bobPost+"/report", dto.CreateReportReq{Reason: "boring"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = report(bob, "/posts/"+bobPost+"/report", dto.CreateReportReq{Reason: "spam"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "cannot report own post")
+
+	rec = report(alice, "/posts/"+bobPost+"/report", dto.CreateReportReq{Reason: "spam", Comment: "ads"})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var postReport dto.ReportResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&postReport))
+	assert.Equal(t, "open", postReport.Status)
+	assert.Equal(t, "post", postReport.TargetType)
+
+	rec = report(alice, "/posts/"+bobPost+"/report", dto.CreateReportReq{Reason: "hate"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = report(alice, "/users/"+carol+"/report", dto.CreateReportReq{Reason: "harassment"})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var userReport dto.ReportResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&userReport))
+
+	// Очередь видна только модераторам.
+	rec = app.doAs(t, alice, http.MethodGet, "/admin/reports", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = app.doAs(t, app.moderator, http.MethodGet, "/admin/reports?status=closed", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = app.doAs(t, app.moderator, http.MethodGet, "/admin/reports?status=open&limit=1", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var page dto.ReportsPageResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+	require.Len(t, page.Reports, 1)
+	assert.Equal(t, userReport.ID, page.Reports[0].ID)
+	assert.NotEmpty(t, page.NextCursor)
+
+	actions := "/admin/reports/" + postReport.ID + "/actions"
+	rec = app.doAs(t, alice, http.MethodPost, actions, dto.ModerationActionReq{Action: "resolve"})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = app.doAs(t, app.moderator, http.MethodPost, actions, dto.ModerationActionReq{Action: "remove_content", Note: "spam"})
+	require.Equal(t, http.StatusOK, rec.Code)
+	var moderated dto.ReportResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&moderated))
+	assert.Equal(t, "resolved", moderated.Status)
+	assert.NotNil(t, moderated.ClosedAt)
+	require.Len(t, moderated.Actions, 1)
+	assert.Equal(t, app.moderator, moderated.Actions[0].ActorID)
+	assert.Equal(t, "remove_content", moderated.Actions[0].Action)
+	assert.False(t, moderated.Actions[0].CreatedAt.IsZero())
+
+	rec = app.do(t, http.MethodGet, "/posts/"+bobPost, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = app.doAs(t, app.moderator, http.MethodPost, actions, dto.ModerationActionReq{Action: "dismiss"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	actions = "/admin/reports/" + userReport.ID + "/actions"
+	rec = app.doAs(t, app.moderator, http.MethodPost, actions, dto.ModerationActionReq{Action: "suspend"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "suspend_until is required")
+	rec = app.doAs(t, app.moderator, http.MethodPost, actions, dto.ModerationActionReq{Action: "remove_content"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "users cannot be removed")
+
+	until := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+	rec = app.doAs(t, app.moderator, http.MethodPost, actions, dto.ModerationActionReq{
+		Action:       "suspend",
+		Note:         "threats",
+		SuspendUntil: &until,
+	})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	suspended, err := app.repo.GetUserById(uuid.MustParse(carol))
+	require.NoError(t, err)
+	assert.True(t, suspended.SuspendedUntil.Equal(until))
+	assert.Equal(t, "threats", suspended.SuspensionReason)
+
+	rec = app.doAs(t, app.moderator, http.MethodGet, "/admin/reports/"+userReport.ID, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var detail dto.ReportResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&detail))
+	require.Len(t, detail.Actions, 1)
+	assert.Equal(t, "suspend", detail.Actions[0].Action)
+	require.NotNil(t, detail.Actions[0].SuspendUntil)
+	assert.True(t, detail.Actions[0].SuspendUntil.Equal(until))
+
+	rec = app.doAs(t, app.moderator, http.MethodGet, "/admin/reports?status=open", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+	assert.Empty(t, page.Reports)
+	rec = app.doAs(t, app.moderator, http.MethodGet, "/admin/reports/"+uuid.NewString(), nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRouter_Sanctions(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+	carol := app.register(t, "carol")
+	alicePost := app.createPost(t, alice, "hello")
+	bobPost := app.createPost(t, bob, "buy cheap followers")
+
+	until := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	suspension := "/admin/users/" + alice + "/suspension"
+	shadowBan := "/admin/users/" + bob + "/shadow-ban"
+
+	rec := app.doAs(t, carol, http.MethodPut, suspension, dto.SanctionReq{Until: until})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = app.doAs(t, app.moderator, http.MethodPut, suspension, dto.SanctionReq{})
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "until is required")
+	rec = app.doAs(t, app.moderator, http.MethodPut, "/admin/users/"+app.admin+"/suspension", dto.SanctionReq{Until: until})
+	assert.Equal(t, http.StatusForbidden, rec.Code, "moderators cannot sanction admins")
+
+	rec = app.doAs(t, app.moderator, http.MethodPut, suspension, dto.SanctionReq{Reason: "threats", Until: until})
+	require.Equal(t, http.StatusOK, rec.Code)
+	var sanctions dto.UserSanctionsResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&sanctions))
+	require.NotNil(t, sanctions.SuspendedUntil)
+	assert.True(t, sanctions.SuspendedUntil.Equal(until))
+	assert.Equal(t, "threats", sanctions.SuspensionReason)
+
+	// Заблокированный пользователь не входит и не пишет, его посты скрыты.
+	rec = app.doAs(t, alice, http.MethodPost, "/register", dto.CreateUserReq{Name: "alice"})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: "still here"})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = app.doAs(t, carol, http.MethodGet, "/posts/"+alicePost, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = app.doAs(t, app.moderator, http.MethodPut, shadowBan, dto.SanctionReq{Until: until})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// Теневой бан незаметен самому пользователю: его посты и лайки видит
+	// только он сам.
+	rec = app.doAs(t, bob, http.MethodPost, "/posts/"+bobPost+"/like", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	app.likeQueue.Close()
+
+	rec = app.doAs(t, bob, http.MethodGet, "/posts/"+bobPost, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var post dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&post))
+	assert.Equal(t, 1, post.LikeCount)
+	rec = app.doAs(t, carol, http.MethodGet, "/posts/"+bobPost, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, app.listPosts(t, carol))
+
+	rec = app.doAs(t, app.moderator, http.MethodDelete, suspension, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var lifted dto.UserSanctionsResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&lifted))
+	assert.Nil(t, lifted.SuspendedUntil)
+	rec = app.doAs(t, app.moderator, http.MethodDelete, shadowBan, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/register", dto.CreateUserReq{Name: "alice"})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	rec = app.doAs(t, carol, http.MethodGet, "/posts/"+bobPost, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&post))
+	assert.Equal(t, 1, post.LikeCount)
+	assert.Len(t, app.listPosts(t, carol), 2)
+}
+
+// Срок блокировки сверяется с часами сервисов: по его истечении
+// пользователь снова входит и подписывается без снятия санкции вручную.
+func TestRouter_SuspensionExpiresByClock(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+
+	until := app.clock.Now().Add(time.Hour)
+	rec := app.doAs(t, app.moderator, http.MethodPut, "/admin/users/"+alice+"/suspension", dto.SanctionReq{Until: until})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/register", dto.CreateUserReq{Name: "alice"})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/users/"+bob+"/follow", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	app.clock.Advance(2 * time.Hour)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/register", dto.CreateUserReq{Name: "alice"})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/users/"+bob+"/follow", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRouter_ContentPolicy(t *testing.T) {
+	app := newTestApp(t)
+
+	words := filepath.Join(t.TempDir(), "words.txt")
+	require.NoError(t, os.WriteFile(words, []byte("gore\n"), 0o644))
+	wordFilter, err := policy.NewWordFilter(words, model.PolicySensitive)
+	require.NoError(t, err)
+	domains, err := policy.NewDomainBlocklist([]string{"bad.example"}, model.PolicyReject)
+	require.NoError(t, err)
+	mentions, err := policy.NewMentionLimit(1, model.PolicyHold)
+	require.NoError(t, err)
+	app.serv.PostService.AttachContentPolicy(policy.NewPipeline(wordFilter, domains, mentions), app.serv.ModerationService)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+	app.register(t, "carol")
+
+	rec := app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: "gore at https://www.bad.example"})
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	var rejected dto.ErrorResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&rejected))
+	assert.Equal(t, []*dto.PolicyViolationResp{
+		{Rule: "blocked_word", Action: "sensitive", Detail: "gore"},
+		{Rule: "blocked_domain", Action: "reject", Detail: "bad.example"},
+	}, rejected.Violations)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: "some gore"})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var post dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&post))
+	assert.True(t, post.Sensitive)
+	assert.False(t, post.Held)
+
+	rec = app.doAs(t, alice, http.MethodPatch, "/posts/"+post.ID, dto.EditPostReq{Text: "see https://bad.example"})
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	// Задержанный пост видит только автор, пока модератор его не одобрит.
+	rec = app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: "hi @bob and @carol"})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&post))
+	assert.True(t, post.Held)
+
+	rec = app.doAs(t, bob, http.MethodGet, "/posts/"+post.ID, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = app.doAs(t, alice, http.MethodGet, "/posts/"+post.ID, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, app.listPosts(t, bob), 1)
+
+	rec = app.doAs(t, app.moderator, http.MethodGet, "/admin/reports?status=open", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var page dto.ReportsPageResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+	require.Len(t, page.Reports, 1)
+	held := page.Reports[0]
+	assert.Equal(t, post.ID, held.TargetID)
+	assert.Equal(t, "content_policy", held.Reason)
+	assert.Empty(t, held.ReporterID)
+	assert.Contains(t, held.Comment, "max_mentions (hold)")
+
+	rec = app.doAs(t, app.moderator, http.MethodPost, "/admin/reports/"+held.ID+"/actions", dto.ModerationActionReq{Action: "dismiss"})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = app.doAs(t, bob, http.MethodGet, "/posts/"+post.ID, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var approved dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&approved))
+	assert.False(t, approved.Held)
+	assert.Len(t, app.listPosts(t, bob), 2)
+}
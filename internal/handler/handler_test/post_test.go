@@ -0,0 +1,716 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/handler/dto"
+)
+
+// Запускать с -race: лайки, создание постов и чтение списка идут одновременно.
+func TestRouter_ConcurrentLikesCreatesAndLists(t *testing.T) {
+	app := newTestApp(t)
+
+	const (
+		users = 20
+		posts = 10
+	)
+
+	author := app.register(t, "author")
+	postIDs := make([]string, posts)
+	for i := range postIDs {
+		postIDs[i] = app.createPost(t, author, fmt.Sprintf("post %d", i))
+	}
+
+	userIDs := make([]string, users)
+	for i := range userIDs {
+		userIDs[i] = app.register(t, fmt.Sprintf("user_%d", i))
+	}
+
+	var wg sync.WaitGroup
+	for _, userID := range userIDs {
+		wg.Add(3)
+
+		go func() {
+			defer wg.Done()
+			for _, postID := range postIDs {
+				rec := app.doAs(t, userID, http.MethodPost, "/posts/"+postID+"/like", nil)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			for i := 0; i < posts; i++ {
+				rec := app.doAs(t, userID, http.MethodPost, "/posts", dto.CreatePostReq{Text: "concurrent"})
+				assert.Equal(t, http.StatusCreated, rec.Code)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			for i := 0; i < posts; i++ {
+				rec := app.do(t, http.MethodGet, "/posts", nil)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Дожидаемся, пока воркер обработает все лайки из очереди.
+	app.likeQueue.Close()
+
+	list := app.listPosts(t, "")
+	assert.Len(t, list, posts+users*posts)
+
+	likes := make(map[string]int, len(list))
+	for _, post := range list {
+		likes[post.ID] = post.LikeCount
+	}
+	for _, postID := range postIDs {
+		assert.Equal(t, users, likes[postID])
+	}
+}
+
+func TestRouter_LikedByMe(t *testing.T) {
+	app := newTestApp(t)
+
+	author := app.register(t, "author")
+	fan := app.register(t, "fan")
+	postID := app.createPost(t, author, "hello")
+
+	app.like(t, fan, postID)
+	app.likeQueue.Close()
+
+	fanView := app.listPosts(t, fan)
+	require.Len(t, fanView, 1)
+	assert.Equal(t, 1, fanView[0].LikeCount)
+	assert.True(t, fanView[0].LikedByMe)
+
+	authorView := app.listPosts(t, author)
+	require.Len(t, authorView, 1)
+	assert.False(t, authorView[0].LikedByMe)
+}
+
+// Автор поста и реакции берется из токена: чужой id в теле запроса игнорируется.
+func TestRouter_ActorComesFromToken(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	mallory := app.register(t, "mallory")
+
+	rec := app.doAs(t, mallory, http.MethodPost, "/posts", map[string]string{"author_id": alice, "text": "hi"})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var post dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&post))
+	assert.Equal(t, mallory, post.AuthorID)
+
+	rec = app.doAs(t, mallory, http.MethodPost, "/posts/"+post.ID+"/reactions", map[string]string{"user_id": alice, "type": "love"})
+	require.Equal(t, http.StatusOK, rec.Code)
+	app.likeQueue.Close()
+
+	aliceView := app.listPosts(t, alice)
+	require.Len(t, aliceView, 1)
+	assert.Empty(t, aliceView[0].MyReaction)
+	assert.Equal(t, "love", app.listPosts(t, mallory)[0].MyReaction)
+
+	for _, path := range []string{"/posts", "/posts/" + post.ID + "/like", "/posts/" + post.ID + "/reactions"} {
+		rec = app.do(t, http.MethodPost, path, map[string]string{"user_id": alice, "author_id": alice, "type": "love"})
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, path)
+	}
+}
+
+func TestRouter_PostLikesPagination(t *testing.T) {
+	app := newTestApp(t)
+
+	author := app.register(t, "author")
+	postID := app.createPost(t, author, "hello")
+
+	const likers = 5
+	for i := 0; i < likers; i++ {
+		app.like(t, app.register(t, fmt.Sprintf("user_%d", i)), postID)
+	}
+	app.likeQueue.Close()
+
+	var names []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, likers, "pagination does not terminate")
+
+		path := "/posts/" + postID + "/likes?limit=2"
+		if cursor != "" {
+			path += "&cursor=" + cursor
+		}
+		rec := app.do(t, http.MethodGet, path, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var page dto.LikesPageResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+		for _, liker := range page.Likes {
+			names = append(names, liker.Name)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	assert.Equal(t, []string{"user_0", "user_1", "user_2", "user_3", "user_4"}, names)
+}
+
+func TestRouter_PostLikesUnknownPost(t *testing.T) {
+	app := newTestApp(t)
+
+	rec := app.do(t, http.MethodGet, "/posts/"+uuid.NewString()+"/likes", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRouter_ReactionSwitchReplacesPrevious(t *testing.T) {
+	app := newTestApp(t)
+
+	author := app.register(t, "author")
+	fan := app.register(t, "fan")
+	postID := app.createPost(t, author, "hello")
+
+	app.like(t, fan, postID)
+	rec := app.doAs(t, fan, http.MethodPost, "/posts/"+postID+"/reactions", dto.ReactionRequest{Type: "love"})
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = app.doAs(t, author, http.MethodPost, "/posts/"+postID+"/reactions", dto.ReactionRequest{Type: ":tada:"})
+	require.Equal(t, http.StatusOK, rec.Code)
+	app.likeQueue.Close()
+
+	list := app.listPosts(t, fan)
+	require.Len(t, list, 1)
+	assert.Equal(t, map[string]int{"love": 1, ":tada:": 1}, list[0].Reactions)
+	assert.Equal(t, "love", list[0].MyReaction)
+	assert.Equal(t, 0, list[0].LikeCount)
+	assert.False(t, list[0].LikedByMe)
+
+	rec = app.do(t, http.MethodGet, "/posts/"+postID+"/reactions?type=love", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var page dto.ReactionsPageResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+	require.Len(t, page.Reactions, 1)
+	assert.Equal(t, "fan", page.Reactions[0].Name)
+}
+
+func TestRouter_RemoveReaction(t *testing.T) {
+	app := newTestApp(t)
+
+	author := app.register(t, "author")
+	postID := app.createPost(t, author, "hello")
+
+	app.like(t, author, postID)
+	rec := app.doAs(t, author, http.MethodDelete, "/posts/"+postID+"/reactions", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	app.likeQueue.Close()
+
+	list := app.listPosts(t, author)
+	require.Len(t, list, 1)
+	assert.Empty(t, list[0].Reactions)
+	assert.Empty(t, list[0].MyReaction)
+}
+
+func TestRouter_InvalidReactionType(t *testing.T) {
+	app := newTestApp(t)
+
+	author := app.register(t, "author")
+	postID := app.createPost(t, author, "hello")
+
+	rec := app.doAs(t, author, http.MethodPost, "/posts/"+postID+"/reactions", dto.ReactionRequest{Type: "meh"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRouter_PostTextEntities(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+
+	rec := app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{
+		Text: "Привет, see https://example.com/a#b, @alice and @nobody_here #Go_lang!",
+	})
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var post dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&post))
+	assert.Equal(t, []*dto.EntityResp{
+		{Type: "url", Start: 12, End: 35, Text: "https://example.com/a#b"},
+		{Type: "mention", Start: 37, End: 43, Text: "alice", UserID: alice},
+		{Type: "mention", Start: 48, End: 60, Text: "nobody_here"},
+		{Type: "hashtag", Start: 61, End: 69, Text: "Go_lang"},
+	}, post.Entities)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: "   "})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRouter_EditPostKeepsRevisions(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+	postID := app.createPost(t, alice, "first")
+
+	rec := app.doAs(t, bob, http.MethodPatch, "/posts/"+postID, dto.EditPostReq{Text: "hijacked"})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = app.do(t, http.MethodPatch, "/posts/"+postID, dto.EditPostReq{Text: "anonymous"})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodPatch, "/posts/"+uuid.NewString(), dto.EditPostReq{Text: "x"})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodPatch, "/posts/"+postID, dto.EditPostReq{Text: "second @bob"})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var edited dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&edited))
+	assert.Equal(t, "second @bob", edited.Text)
+	assert.True(t, edited.Edited)
+	require.NotNil(t, edited.EditedAt)
+	assert.False(t, edited.EditedAt.Before(edited.CreatedAt))
+	require.Len(t, edited.Entities, 1)
+	assert.Equal(t, bob, edited.Entities[0].UserID)
+
+	posts := app.listPosts(t, alice)
+	require.Len(t, posts, 1)
+	assert.Equal(t, "second @bob", posts[0].Text)
+	assert.True(t, posts[0].Edited)
+
+	rec = app.do(t, http.MethodGet, "/posts/"+postID+"/revisions", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var revisions []dto.RevisionResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&revisions))
+	require.Len(t, revisions, 2)
+	assert.Equal(t, 1, revisions[0].Number)
+	assert.Equal(t, "first", revisions[0].Text)
+	assert.Empty(t, revisions[0].Entities)
+	assert.Equal(t, 2, revisions[1].Number)
+	assert.Equal(t, "second @bob", revisions[1].Text)
+	assert.Len(t, revisions[1].Entities, 1)
+
+	rec = app.do(t, http.MethodGet, "/posts/"+uuid.NewString()+"/revisions", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRouter_PostVisibility(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+	carol := app.register(t, "carol")
+
+	post := func(text, visibility string) string {
+		rec := app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: text, Visibility: visibility})
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var resp dto.PostResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		return resp.ID
+	}
+	public := post("public", "")
+	unlisted := post("unlisted", "unlisted")
+	followers := post("followers", "followers")
+	mentioned := post("hi @bob", "mentioned")
+
+	rec := app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: "x", Visibility: "secret"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = app.doAs(t, bob, http.MethodPost, "/users/"+alice+"/follow", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	texts := func(viewerID string) []string {
+		var out []string
+		for _, p := range app.listPosts(t, viewerID) {
+			out = append(out, p.Text)
+		}
+		return out
+	}
+	assert.ElementsMatch(t, []string{"public", "unlisted", "followers", "hi @bob"}, texts(alice))
+	assert.ElementsMatch(t, []string{"public", "followers", "hi @bob"}, texts(bob))
+	assert.ElementsMatch(t, []string{"public"}, texts(carol))
+	assert.ElementsMatch(t, []string{"public"}, texts(""))
+
+	cases := []struct {
+		viewer string
+		postID string
+		want   int
+	}{
+		{viewer: "", postID: public, want: http.StatusOK},
+		{viewer: carol, postID: unlisted, want: http.StatusOK},
+		{viewer: carol, postID: followers, want: http.StatusNotFound},
+		{viewer: bob, postID: followers, want: http.StatusOK},
+		{viewer: carol, postID: mentioned, want: http.StatusNotFound},
+		{viewer: bob, postID: mentioned, want: http.StatusOK},
+	}
+	for _, c := range cases {
+		rec = app.doAs(t, c.viewer, http.MethodGet, "/posts/"+c.postID, nil)
+		assert.Equal(t, c.want, rec.Code, "viewer %q post %s", c.viewer, c.postID)
+
+		rec = app.doAs(t, c.viewer, http.MethodGet, "/posts/"+c.postID+"/reactions", nil)
+		assert.Equal(t, c.want, rec.Code)
+
+		rec = app.doAs(t, c.viewer, http.MethodGet, "/posts/"+c.postID+"/revisions", nil)
+		assert.Equal(t, c.want, rec.Code)
+	}
+
+	rec = app.doAs(t, carol, http.MethodPost, "/posts/"+followers+"/like", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = app.doAs(t, bob, http.MethodPost, "/posts/"+followers+"/like", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRouter_EphemeralPostExpires(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+	permanent := app.createPost(t, alice, "forever")
+
+	rec := app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: "too long", TTLSeconds: 48 * 3600})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: "brief", TTLSeconds: 60})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var post dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&post))
+	require.NotNil(t, post.ExpiresAt)
+	assert.Equal(t, time.Minute, post.ExpiresAt.Sub(post.CreatedAt))
+
+	app.like(t, bob, post.ID)
+	assert.Len(t, app.listPosts(t, bob), 2)
+
+	app.clock.Advance(time.Minute)
+
+	rec = app.do(t, http.MethodGet, "/posts/"+post.ID, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code, "expired post hidden before sweep")
+	rec = app.do(t, http.MethodGet, "/posts/"+post.ID+"/likes", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	posts := app.listPosts(t, bob)
+	require.Len(t, posts, 1)
+	assert.Equal(t, permanent, posts[0].ID)
+
+	purged, err := app.serv.PostService.PurgeExpired(context.Background(), app.clock.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	purged, err = app.serv.PostService.PurgeExpired(context.Background(), app.clock.Now())
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+}
+
+func TestRouter_AuthorFeedWithPinnedPosts(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+
+	var ids []string
+	for i := range 4 {
+		ids = append(ids, app.createPost(t, alice, fmt.Sprintf("post %d", i)))
+		app.clock.Advance(time.Second)
+	}
+	app.createPost(t, bob, "not alice")
+
+	feed := func() []string {
+		rec := app.do(t, http.MethodGet, "/users/"+alice+"/posts", nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var page dto.PostsPageResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+		texts := make([]string, len(page.Posts))
+		for i, p := range page.Posts {
+			texts[i] = p.Text
+			assert.Equal(t, alice, p.AuthorID)
+		}
+		return texts
+	}
+	assert.Equal(t, []string{"post 3", "post 2", "post 1", "post 0"}, feed())
+
+	rec := app.do(t, http.MethodPost, "/posts/"+ids[1]+"/pin", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = app.doAs(t, bob, http.MethodPost, "/posts/"+ids[1]+"/pin", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/posts/"+ids[1]+"/pin", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	app.clock.Advance(time.Second)
+	rec = app.doAs(t, alice, http.MethodPost, "/posts/"+ids[0]+"/pin", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/posts/"+ids[0]+"/pin", nil)
+	assert.Equal(t, http.StatusOK, rec.Code, "repeated pin is a no-op")
+	rec = app.doAs(t, alice, http.MethodPost, "/posts/"+ids[2]+"/pin", nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	assert.Equal(t, []string{"post 0", "post 1", "post 3", "post 2"}, feed())
+
+	rec = app.doAs(t, alice, http.MethodDelete, "/posts/"+ids[0]+"/pin", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"post 1", "post 3", "post 2", "post 0"}, feed())
+
+	rec = app.do(t, http.MethodGet, "/users/"+uuid.NewString()+"/posts", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRouter_AuthorFeedFiltersAndPagination(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+	bobPost := app.createPost(t, bob, "bob's post")
+
+	create := func(req dto.CreatePostReq) string {
+		t.Helper()
+		rec := app.doAs(t, alice, http.MethodPost, "/posts", req)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		var resp dto.PostResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		return resp.ID
+	}
+
+	rec := app.upload(t, alice, testPNG(t))
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var media dto.MediaResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&media))
+	mediaID := media.ID
+	create(dto.CreatePostReq{Text: "plain 1"})
+	create(dto.CreatePostReq{Text: "reply", ReplyToID: bobPost})
+	create(dto.CreatePostReq{RepostOfID: bobPost})
+	create(dto.CreatePostReq{Text: "with media", AttachmentIDs: []string{mediaID}})
+	create(dto.CreatePostReq{Text: "plain 2"})
+
+	feed := func(query string) dto.PostsPageResp {
+		t.Helper()
+		rec := app.do(t, http.MethodGet, "/users/"+alice+"/posts?"+query, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var page dto.PostsPageResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+		return page
+	}
+	texts := func(page dto.PostsPageResp) []string {
+		out := make([]string, len(page.Posts))
+		for i, p := range page.Posts {
+			out[i] = p.Text
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"plain 2", "with media", "", "reply", "plain 1"}, texts(feed("")))
+	assert.Equal(t, []string{"plain 2", "with media", "", "plain 1"}, texts(feed("exclude_replies=true")))
+	assert.Equal(t, []string{"plain 2", "with media", "plain 1"}, texts(feed("exclude_replies=true&exclude_reposts=1")))
+	assert.Equal(t, []string{"with media"}, texts(feed("only_media=true")))
+
+	page := feed("exclude_reposts=true&limit=2")
+	assert.Equal(t, []string{"plain 2", "with media"}, texts(page))
+	require.NotEmpty(t, page.NextCursor)
+	page = feed("exclude_reposts=true&limit=2&cursor=" + page.NextCursor)
+	assert.Equal(t, []string{"reply", "plain 1"}, texts(page))
+	assert.Empty(t, page.NextCursor)
+
+	rec = app.do(t, http.MethodGet, "/users/"+alice+"/posts?only_media=maybe", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	repost := feed("")
+	assert.Equal(t, bobPost, repost.Posts[2].RepostOfID)
+	assert.Equal(t, bobPost, repost.Posts[3].ReplyToID)
+}
+
+func TestRouter_RepostRequiresOpenPost(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+
+	rec := app.doAs(t, bob, http.MethodPost, "/posts", dto.CreatePostReq{Text: "friends only", Visibility: "followers"})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var private dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&private))
+
+	rec = app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: "re", ReplyToID: private.ID})
+	assert.Equal(t, http.StatusNotFound, rec.Code, "cannot reply to a post one cannot see")
+
+	rec = app.doAs(t, alice, http.MethodPost, "/users/"+bob+"/follow", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: "re", ReplyToID: private.ID})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{RepostOfID: private.ID})
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "followers-only post cannot be reposted")
+
+	rec = app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{RepostOfID: uuid.NewString()})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: "re", ReplyToID: uuid.NewString()})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRouter_PollVoting(t *testing.T) {
+	app := newTestApp(t)
+
+	author := app.register(t, "author")
+	voter := app.register(t, "voter")
+	reader := app.register(t, "reader")
+
+	rec := app.doAs(t, author, http.MethodPost, "/posts", dto.CreatePostReq{
+		Text: "tabs or spaces?",
+		Poll: &dto.PollReq{Options: []string{"tabs"}, ClosesAt: app.clock.Now().Add(time.Hour)},
+	})
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = app.doAs(t, author, http.MethodPost, "/posts", dto.CreatePostReq{
+		Text: "tabs or spaces?",
+		Poll: &dto.PollReq{Options: []string{"tabs", "spaces"}, ClosesAt: app.clock.Now().Add(time.Hour)},
+	})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var created dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&created))
+	require.NotNil(t, created.Poll)
+
+	getPoll := func(viewerID string) *dto.PollResp {
+		rec := app.doAs(t, viewerID, http.MethodGet, "/posts/"+created.ID, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var post dto.PostResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&post))
+		require.NotNil(t, post.Poll)
+		return post.Poll
+	}
+	vote := func(userID string, choices ...int) int {
+		return app.doAs(t, userID, http.MethodPost, "/posts/"+created.ID+"/votes", dto.VoteReq{Choices: choices}).Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, vote("", 0))
+	assert.Equal(t, http.StatusBadRequest, vote(voter, 0, 1))
+	assert.Equal(t, http.StatusBadRequest, vote(voter, 2))
+	require.Equal(t, http.StatusAccepted, vote(voter, 1))
+
+	require.Eventually(t, func() bool {
+		return len(getPoll(voter).MyChoices) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	poll := getPoll(voter)
+	assert.False(t, poll.ResultsHidden)
+	require.NotNil(t, poll.VotersCount)
+	assert.Equal(t, 1, *poll.VotersCount)
+	require.NotNil(t, poll.Options[1].Votes)
+	assert.Equal(t, 1, *poll.Options[1].Votes)
+	assert.Equal(t, []int{1}, poll.MyChoices)
+
+	assert.Equal(t, http.StatusConflict, vote(voter, 0))
+
+	// Не проголосовавший не видит результатов, пока опрос открыт.
+	poll = getPoll(reader)
+	assert.True(t, poll.ResultsHidden)
+	assert.Nil(t, poll.VotersCount)
+	assert.Nil(t, poll.Options[1].Votes)
+
+	app.clock.Advance(time.Hour)
+
+	assert.Equal(t, http.StatusConflict, vote(reader, 0))
+	poll = getPoll(reader)
+	assert.False(t, poll.ResultsHidden)
+	require.NotNil(t, poll.VotersCount)
+	assert.Equal(t, 1, *poll.VotersCount)
+}
+
+func TestRouter_SensitiveContent(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+
+	getPost := func(t *testing.T, viewerID, postID string) dto.PostResp {
+		t.Helper()
+		rec := app.doAs(t, viewerID, http.MethodGet, "/posts/"+postID, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var post dto.PostResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&post))
+		return post
+	}
+
+	rec := app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{
+		Text:                   "the butler did it",
+		SensitiveAttachmentIDs: []string{uuid.NewString()},
+	})
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "hidden attachment must be attached")
+
+	rec = app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{
+		Text:           "the butler did it",
+		ContentWarning: "Finale spoilers",
+	})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var created dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&created))
+	assert.Equal(t, "Finale spoilers", created.ContentWarning)
+	assert.False(t, created.Sensitive)
+	assert.True(t, created.Collapsed)
+
+	assert.True(t, getPost(t, bob, created.ID).Collapsed)
+	assert.True(t, getPost(t, "", created.ID).Collapsed)
+
+	expand := true
+	rec = app.doAs(t, bob, http.MethodPatch, "/users/me", dto.UpdateProfileReq{ExpandSensitive: &expand})
+	require.Equal(t, http.StatusOK, rec.Code)
+	var own dto.UserProfileResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&own))
+	require.NotNil(t, own.ExpandSensitive)
+	assert.True(t, *own.ExpandSensitive)
+
+	rec = app.doAs(t, alice, http.MethodGet, "/users/"+bob, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var public dto.UserProfileResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&public))
+	assert.Nil(t, public.ExpandSensitive, "preference is private")
+
+	post := getPost(t, bob, created.ID)
+	assert.False(t, post.Collapsed)
+	assert.Equal(t, "Finale spoilers", post.ContentWarning)
+	for _, listed := range app.listPosts(t, bob) {
+		assert.False(t, listed.Collapsed)
+	}
+
+	// Модератор помечает пост без предупреждения.
+	bobPost := app.createPost(t, bob, "graphic photos")
+	assert.False(t, getPost(t, alice, bobPost).Collapsed)
+
+	flag := "/admin/posts/" + bobPost + "/sensitive"
+	rec = app.doAs(t, alice, http.MethodPut, flag, dto.MarkSensitiveReq{})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = app.doAs(t, app.moderator, http.MethodPut, "/admin/posts/"+uuid.NewString()+"/sensitive", dto.MarkSensitiveReq{})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = app.doAs(t, app.moderator, http.MethodPut, flag, dto.MarkSensitiveReq{ContentWarning: "Gore"})
+	require.Equal(t, http.StatusOK, rec.Code)
+	var flagged dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&flagged))
+	assert.True(t, flagged.Sensitive)
+	assert.Equal(t, "Gore", flagged.ContentWarning)
+
+	post = getPost(t, alice, bobPost)
+	assert.True(t, post.Sensitive)
+	assert.True(t, post.Collapsed)
+
+	// Правка автора не снимает пометку модератора.
+	rec = app.doAs(t, bob, http.MethodPatch, "/posts/"+bobPost, dto.EditPostReq{Text: "nothing to see"})
+	require.Equal(t, http.StatusOK, rec.Code)
+	post = getPost(t, alice, bobPost)
+	assert.True(t, post.Sensitive)
+	assert.Equal(t, "Gore", post.ContentWarning)
+
+	rec = app.doAs(t, app.admin, http.MethodGet, "/admin/audit?action=post_flagged", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var audit dto.AuditPageResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&audit))
+	require.Len(t, audit.Entries, 1)
+	assert.Equal(t, app.moderator, audit.Entries[0].ActorID)
+	assert.Equal(t, bobPost, audit.Entries[0].TargetID)
+}
@@ -0,0 +1,103 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/model"
+)
+
+func TestRouter_Profile(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+	app.createPost(t, alice, "first")
+	app.createPost(t, alice, "second")
+
+	rec := app.doAs(t, bob, http.MethodPost, "/users/"+alice+"/follow", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	bio := "gopher"
+	website := "https://example.com"
+	rec = app.doAs(t, alice, http.MethodPatch, "/users/me", dto.UpdateProfileReq{Bio: &bio, Website: &website})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = app.do(t, http.MethodGet, "/users/by-name/alice", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var profile dto.UserProfileResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&profile))
+	assert.Equal(t, alice, profile.ID)
+	assert.Equal(t, "gopher", profile.Bio)
+	assert.Equal(t, "https://example.com", profile.Website)
+	assert.Equal(t, 2, profile.PostsCount)
+	assert.Equal(t, 1, profile.FollowersCount)
+	assert.Equal(t, 0, profile.FollowingCount)
+
+	rec = app.do(t, http.MethodGet, "/users/"+bob, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&profile))
+	assert.Equal(t, 1, profile.FollowingCount)
+}
+
+func TestRouter_ProfilePostsCount(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	aliceID := uuid.MustParse(alice)
+	app.createPost(t, alice, "public")
+	rec := app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: "quiet", Visibility: "unlisted"})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	_, err := app.repo.CreatePost(&model.Post{AuthorID: aliceID, Text: "held", Held: true})
+	require.NoError(t, err)
+
+	postsCount := func() int {
+		t.Helper()
+		rec := app.do(t, http.MethodGet, "/users/"+alice, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var profile dto.UserProfileResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&profile))
+		return profile.PostsCount
+	}
+
+	assert.Equal(t, 2, postsCount(), "held posts are not counted")
+
+	_, err = app.repo.ShadowBanUser(aliceID, "spam", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, postsCount(), "posts of a hidden author are not counted")
+}
+
+func TestRouter_UpdateProfileValidation(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+
+	for _, website := range []string{"not a url", "javascript:alert(1)", "data:text/html,<script>alert(1)</script>", "ftp://example.com"} {
+		rec := app.doAs(t, alice, http.MethodPatch, "/users/me", dto.UpdateProfileReq{Website: &website})
+		assert.Equal(t, http.StatusBadRequest, rec.Code, website)
+	}
+
+	name := " \u202Eevil\u202C name\x00 "
+	rec := app.doAs(t, alice, http.MethodPatch, "/users/me", dto.UpdateProfileReq{DisplayName: &name})
+	require.Equal(t, http.StatusOK, rec.Code)
+	var profile dto.UserProfileResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&profile))
+	assert.Equal(t, "evil name", profile.DisplayName)
+
+	avatar := uuid.NewString()
+	rec = app.doAs(t, alice, http.MethodPatch, "/users/me", dto.UpdateProfileReq{AvatarID: &avatar})
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "avatar must be an uploaded image")
+
+	rec = app.do(t, http.MethodPatch, "/users/me", dto.UpdateProfileReq{})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = app.do(t, http.MethodGet, "/users/"+uuid.NewString(), nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,219 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/handler/dto"
+)
+
+func TestRouter_BlockAndMute(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+	carol := app.register(t, "carol")
+	dave := app.register(t, "dave")
+
+	rec := app.doAs(t, bob, http.MethodPost, "/users/"+alice+"/follow", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	conversation := app.doAs(t, bob, http.MethodPost, "/conversations", dto.CreateConversationReq{MemberIDs: []string{alice}})
+	require.Equal(t, http.StatusCreated, conversation.Code)
+	var direct dto.ConversationResp
+	require.NoError(t, json.NewDecoder(conversation.Body).Decode(&direct))
+
+	alicePost := app.createPost(t, alice, "alice says")
+	bobPost := app.createPost(t, bob, "bob says")
+	carolPost := app.createPost(t, carol, "carol says")
+
+	rec = app.do(t, http.MethodPost, "/users/"+bob+"/block", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/users/"+alice+"/block", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/users/"+uuid.NewString()+"/block", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	for _, target := range []string{bob, dave} {
+		rec = app.doAs(t, alice, http.MethodPost, "/users/"+target+"/block", nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+	rec = app.doAs(t, alice, http.MethodPost, "/users/"+carol+"/mute", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// Блокировка снимает подписку и действует в обе стороны.
+	rec = app.do(t, http.MethodGet, "/users/"+alice, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var profile dto.UserProfileResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&profile))
+	assert.Equal(t, 0, profile.FollowersCount)
+
+	rec = app.doAs(t, bob, http.MethodPost, "/users/"+alice+"/follow", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = app.doAs(t, bob, http.MethodGet, "/posts/"+alicePost, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = app.doAs(t, bob, http.MethodPost, "/posts/"+alicePost+"/like", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = app.doAs(t, bob, http.MethodPost, "/posts", dto.CreatePostReq{Text: "re", ReplyToID: alicePost})
+	assert.Equal(t, http.StatusNotFound, rec.Code, "cannot reply across a block")
+	rec = app.doAs(t, alice, http.MethodPost, "/posts", dto.CreatePostReq{Text: "re", ReplyToID: bobPost})
+	assert.Equal(t, http.StatusNotFound, rec.Code, "cannot reply across a block")
+	rec = app.doAs(t, bob, http.MethodPost, "/conversations/"+direct.ID+"/messages", dto.SendMessageReq{Text: "hey"})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/conversations", dto.CreateConversationReq{MemberIDs: []string{dave}})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = app.doAs(t, bob, http.MethodPost, "/posts", dto.CreatePostReq{Text: "hi @alice"})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var mention dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&mention))
+	require.Len(t, mention.Entities, 1)
+	assert.Empty(t, mention.Entities[0].UserID)
+
+	feedIDs := func(viewerID string) []string {
+		var ids []string
+		for _, post := range app.listPosts(t, viewerID) {
+			ids = append(ids, post.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{alicePost}, feedIDs(alice))
+	assert.NotContains(t, feedIDs(bob), alicePost)
+	assert.Contains(t, feedIDs(dave), carolPost)
+
+	// Заглушенный пользователь пропадает только из ленты.
+	rec = app.doAs(t, alice, http.MethodGet, "/posts/"+carolPost, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = app.doAs(t, carol, http.MethodGet, "/posts/"+alicePost, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	listRelations := func(path string) dto.RelationsPageResp {
+		t.Helper()
+		rec := app.doAs(t, alice, http.MethodGet, path, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var page dto.RelationsPageResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+		return page
+	}
+
+	page := listRelations("/blocks?limit=1")
+	require.Len(t, page.Users, 1)
+	assert.Equal(t, dave, page.Users[0].UserID)
+	require.NotEmpty(t, page.NextCursor)
+	page = listRelations("/blocks?limit=1&cursor=" + page.NextCursor)
+	require.Len(t, page.Users, 1)
+	assert.Equal(t, bob, page.Users[0].UserID)
+	assert.Equal(t, "bob", page.Users[0].Name)
+	assert.Empty(t, page.NextCursor)
+
+	page = listRelations("/mutes")
+	require.Len(t, page.Users, 1)
+	assert.Equal(t, carol, page.Users[0].UserID)
+
+	rec = app.doAs(t, alice, http.MethodDelete, "/users/"+bob+"/block", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = app.doAs(t, alice, http.MethodDelete, "/users/"+carol+"/mute", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = app.doAs(t, bob, http.MethodGet, "/posts/"+alicePost, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, feedIDs(alice), carolPost)
+	assert.Len(t, listRelations("/blocks").Users, 1)
+	assert.Empty(t, listRelations("/mutes").Users)
+}
+
+func TestRouter_PrivateAccount(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+	carol := app.register(t, "carol")
+	dave := app.register(t, "dave")
+
+	private := true
+	rec := app.doAs(t, alice, http.MethodPatch, "/users/me", dto.UpdateProfileReq{Private: &private})
+	require.Equal(t, http.StatusOK, rec.Code)
+	var profile dto.UserProfileResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&profile))
+	assert.True(t, profile.Private)
+
+	postID := app.createPost(t, alice, "for friends")
+
+	follow := func(followerID, followeeID string) (int, string) {
+		t.Helper()
+		rec := app.doAs(t, followerID, http.MethodPost, "/users/"+followeeID+"/follow", nil)
+		var resp dto.FollowResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		return rec.Code, resp.Status
+	}
+	canSee := func(viewerID string) bool {
+		t.Helper()
+		rec := app.doAs(t, viewerID, http.MethodGet, "/posts/"+postID, nil)
+		return rec.Code == http.StatusOK
+	}
+	authorFeed := func(viewerID string) []*dto.PostResp {
+		t.Helper()
+		rec := app.doAs(t, viewerID, http.MethodGet, "/users/"+alice+"/posts", nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var page dto.PostsPageResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+		return page.Posts
+	}
+
+	for _, followerID := range []string{bob, carol, dave} {
+		code, status := follow(followerID, alice)
+		assert.Equal(t, http.StatusAccepted, code)
+		assert.Equal(t, "requested", status)
+	}
+	code, status := follow(alice, bob)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "following", status)
+
+	assert.True(t, canSee(alice))
+	assert.False(t, canSee(bob))
+	assert.False(t, canSee(""))
+	assert.Empty(t, app.listPosts(t, bob))
+	assert.Empty(t, authorFeed(""))
+
+	rec = app.do(t, http.MethodGet, "/follow-requests", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = app.doAs(t, alice, http.MethodGet, "/follow-requests?limit=2", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var requests dto.RelationsPageResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&requests))
+	require.Len(t, requests.Users, 2)
+	assert.Equal(t, dave, requests.Users[0].UserID)
+	assert.Equal(t, carol, requests.Users[1].UserID)
+	require.NotEmpty(t, requests.NextCursor)
+
+	rec = app.doAs(t, alice, http.MethodPost, "/follow-requests/"+bob+"/approve", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/follow-requests/"+carol+"/reject", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/follow-requests/"+carol+"/approve", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	assert.True(t, canSee(bob))
+	assert.False(t, canSee(carol))
+	assert.Len(t, app.listPosts(t, bob), 1)
+	assert.Len(t, authorFeed(bob), 1)
+	assert.Empty(t, authorFeed(carol))
+
+	// Посты закрытого аккаунта нельзя репостить даже подписчикам.
+	rec = app.doAs(t, bob, http.MethodPost, "/posts", dto.CreatePostReq{RepostOfID: postID})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// Открытие аккаунта одобряет оставшиеся запросы.
+	private = false
+	rec = app.doAs(t, alice, http.MethodPatch, "/users/me", dto.UpdateProfileReq{Private: &private})
+	require.Equal(t, http.StatusOK, rec.Code)
+	profile = dto.UserProfileResp{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&profile))
+	assert.False(t, profile.Private)
+	assert.Equal(t, 2, profile.FollowersCount)
+	assert.True(t, canSee(carol))
+	assert.True(t, canSee(""))
+}
@@ -0,0 +1,179 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/handler"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/middleware"
+	"micro-blog/internal/model"
+	"micro-blog/internal/queue"
+	"micro-blog/internal/repository"
+	"micro-blog/internal/service"
+	"micro-blog/internal/storage"
+	"micro-blog/internal/testutil"
+)
+
+type testApp struct {
+	router    http.Handler
+	serv      *service.Service
+	repo      *repository.Repository
+	clock     *clock.Fake
+	likeQueue *queue.LikeQueue
+	voteQueue *queue.VoteQueue
+	admin     string
+	moderator string
+	// tokens - ключи доступа пользователей; doAs предъявляет их вместе
+	// с X-User-ID.
+	tokens map[string]string
+}
+
+func newTestApp(t *testing.T) *testApp {
+	t.Helper()
+
+	repo := repository.NewRepository()
+	store, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	serv := service.NewService(repo, store, model.MediaLimits{
+		MaxImageSize:  1 << 20,
+		MaxVideoSize:  1 << 20,
+		ThumbnailSize: 32,
+	}, model.PostLimits{MaxTextLength: 500, EditWindow: time.Hour, MaxTTL: 24 * time.Hour, MaxPinned: 2})
+	admin, err := serv.UserService.BootstrapAdmin(context.Background(), "operator")
+	require.NoError(t, err)
+	moderator, err := repo.CreateUser(&model.User{Name: "moderator"})
+	require.NoError(t, err)
+	_, err = repo.SetRole(moderator.ID, model.RoleModerator)
+	require.NoError(t, err)
+	adminToken, err := serv.BootstrapAccessToken(context.Background(), admin.ID)
+	require.NoError(t, err)
+	moderatorToken, err := serv.IssueAccessToken(context.Background(), admin.ID, moderator.ID)
+	require.NoError(t, err)
+	clk := clock.NewFake(time.Now())
+	serv.SetClock(clk)
+	repo.SetClock(clk)
+	likeQueue := queue.NewLikeQueue(serv, 100, testutil.NopLogger{})
+	serv.PostService.AttachLikeQueue(likeQueue)
+	t.Cleanup(likeQueue.Close)
+	voteQueue := queue.NewVoteQueue(serv, 100, testutil.NopLogger{})
+	serv.PostService.AttachVoteQueue(voteQueue)
+	t.Cleanup(voteQueue.Close)
+
+	return &testApp{
+		router:    handler.NewRouter(serv, testutil.NopLogger{}, 1<<20),
+		serv:      serv,
+		repo:      repo,
+		clock:     clk,
+		likeQueue: likeQueue,
+		voteQueue: voteQueue,
+		admin:     admin.ID.String(),
+		moderator: moderator.ID.String(),
+		tokens: map[string]string{
+			admin.ID.String():     adminToken,
+			moderator.ID.String(): moderatorToken,
+		},
+	}
+}
+
+func (a *testApp) do(t *testing.T, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	return a.doAs(t, "", method, path, body)
+}
+
+func (a *testApp) doAs(t *testing.T, userID, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+
+	req := httptest.NewRequest(method, path, &buf)
+	a.authorize(t, req, userID)
+	rec := httptest.NewRecorder()
+	a.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// authorize подписывает запрос ключом доступа пользователя userID.
+// Пользователям, созданным в обход /register, ключ выдается при первом
+// запросе.
+func (a *testApp) authorize(t *testing.T, req *http.Request, userID string) {
+	t.Helper()
+
+	if userID == "" {
+		return
+	}
+	token, ok := a.tokens[userID]
+	if !ok {
+		var err error
+		token, err = a.serv.IssueUserToken(context.Background(), uuid.MustParse(userID))
+		require.NoError(t, err)
+		a.tokens[userID] = token
+	}
+	req.Header.Set(middleware.UserIDHeader, userID)
+	req.Header.Set(middleware.AuthorizationHeader, "Bearer "+token)
+}
+
+// issueToken выдает сотруднику userID ключ доступа от имени администратора.
+func (a *testApp) issueToken(t *testing.T, userID string) {
+	t.Helper()
+
+	rec := a.doAs(t, a.admin, http.MethodPost, "/admin/users/"+userID+"/tokens", nil)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var resp dto.AccessTokenResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	a.tokens[userID] = resp.Token
+}
+
+func (a *testApp) register(t *testing.T, name string) string {
+	t.Helper()
+
+	rec := a.do(t, http.MethodPost, "/register", dto.CreateUserReq{Name: name})
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var resp dto.CreateUserResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.NotEmpty(t, resp.Token)
+	a.tokens[resp.ID] = resp.Token
+	return resp.ID
+}
+
+func (a *testApp) createPost(t *testing.T, authorID, text string) string {
+	t.Helper()
+
+	rec := a.doAs(t, authorID, http.MethodPost, "/posts", dto.CreatePostReq{Text: text})
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var resp dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	return resp.ID
+}
+
+func (a *testApp) like(t *testing.T, userID, postID string) {
+	t.Helper()
+
+	rec := a.doAs(t, userID, http.MethodPost, "/posts/"+postID+"/like", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func (a *testApp) listPosts(t *testing.T, viewerID string) []dto.PostResp {
+	t.Helper()
+
+	rec := a.doAs(t, viewerID, http.MethodGet, "/posts", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	return resp
+}
@@ -0,0 +1,154 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/model"
+	"micro-blog/internal/spam"
+)
+
+func TestRouter_Spam(t *testing.T) {
+	app := newTestApp(t)
+	app.serv.SpamService.AttachDetector(spam.NewDetector(model.SpamLimits{
+		Window:                time.Minute,
+		MaxPosts:              3,
+		MaxLikes:              2,
+		MaxRegistrationsPerIP: 3,
+		DuplicateSimilarity:   0.8,
+		DuplicateHistory:      5,
+	}), 3, time.Hour)
+	app.serv.PostService.AttachContentPolicy(nil, app.serv.ModerationService)
+
+	// Все запросы httptest приходят с одного адреса.
+	alice := app.register(t, "alice")
+	spammer := app.register(t, "spammer")
+	app.register(t, "carol")
+	rec := app.do(t, http.MethodPost, "/register", dto.CreateUserReq{Name: "dave"})
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	// Вход в существующий аккаунт не ограничен.
+	rec = app.doAs(t, alice, http.MethodPost, "/register", dto.CreateUserReq{Name: "alice"})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	alicePost := app.createPost(t, alice, "hello everyone")
+	app.like(t, alice, alicePost)
+	app.like(t, alice, alicePost)
+	rec = app.doAs(t, alice, http.MethodPost, "/posts/"+alicePost+"/like", nil)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	// Повтор текста и превышение частоты набирают 3 очка - карантин.
+	app.createPost(t, spammer, "buy cheap followers now at our shop")
+	app.createPost(t, spammer, "Buy cheap followers NOW at our shop!")
+	app.createPost(t, spammer, "something else entirely")
+	rec = app.doAs(t, spammer, http.MethodPost, "/posts", dto.CreatePostReq{Text: "one more"})
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	app.clock.Advance(2 * time.Minute)
+	rec = app.doAs(t, spammer, http.MethodPost, "/posts", dto.CreatePostReq{Text: "totally normal post"})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var held dto.PostResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&held))
+	assert.True(t, held.Held)
+	rec = app.doAs(t, alice, http.MethodGet, "/posts/"+held.ID, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = app.doAs(t, spammer, http.MethodPost, "/posts/"+alicePost+"/like", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = app.doAs(t, app.moderator, http.MethodGet, "/admin/reports?status=open", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var reports dto.ReportsPageResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&reports))
+	require.Len(t, reports.Reports, 1)
+	assert.Equal(t, held.ID, reports.Reports[0].TargetID)
+	assert.Contains(t, reports.Reports[0].Comment, "spam_quarantine")
+
+	// Список подозрительных аккаунтов - только для администраторов.
+	rec = app.doAs(t, app.moderator, http.MethodGet, "/admin/spam/accounts", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = app.doAs(t, app.admin, http.MethodGet, "/admin/spam/accounts?quarantined=true", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var page dto.SpamAccountsPageResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+	require.Len(t, page.Accounts, 1)
+	account := page.Accounts[0]
+	assert.Equal(t, spammer, account.UserID)
+	assert.Equal(t, 3, account.Score)
+	assert.Equal(t, map[string]int{"duplicate_text": 1, "post_rate": 1}, account.Signals)
+	assert.Equal(t, "192.0.2.1", account.LastIP)
+	assert.NotNil(t, account.QuarantinedAt)
+
+	rec = app.doAs(t, app.admin, http.MethodGet, "/admin/spam/accounts?quarantined=maybe", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = app.doAs(t, app.admin, http.MethodDelete, "/admin/spam/accounts/"+spammer, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = app.doAs(t, app.admin, http.MethodDelete, "/admin/spam/accounts/"+spammer, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	app.like(t, spammer, alicePost)
+	rec = app.doAs(t, app.admin, http.MethodGet, "/admin/spam/accounts", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var cleared dto.SpamAccountsPageResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&cleared))
+	// Остался только лимит реакций alice.
+	require.Len(t, cleared.Accounts, 1)
+	assert.Equal(t, alice, cleared.Accounts[0].UserID)
+	assert.Equal(t, map[string]int{"like_velocity": 1}, cleared.Accounts[0].Signals)
+	assert.Nil(t, cleared.Accounts[0].QuarantinedAt)
+}
+
+func TestRouter_SpamFlagsPagingAndDecay(t *testing.T) {
+	app := newTestApp(t)
+	app.serv.SpamService.AttachDetector(spam.NewDetector(model.SpamLimits{Window: time.Minute, MaxLikes: 1}), 100, time.Hour)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+	carol := app.register(t, "carol")
+	postID := app.createPost(t, alice, "hello")
+
+	// Каждая лишняя реакция сверх лимита - одно очко.
+	overLike := func(userID string, times int) {
+		t.Helper()
+		app.like(t, userID, postID)
+		for range times {
+			rec := app.doAs(t, userID, http.MethodPost, "/posts/"+postID+"/like", nil)
+			require.Equal(t, http.StatusTooManyRequests, rec.Code)
+		}
+	}
+	list := func(query string) dto.SpamAccountsPageResp {
+		t.Helper()
+		rec := app.doAs(t, app.admin, http.MethodGet, "/admin/spam/accounts"+query, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var page dto.SpamAccountsPageResp
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&page))
+		return page
+	}
+
+	overLike(alice, 2)
+	overLike(bob, 1)
+	overLike(carol, 1)
+
+	first := list("?limit=2")
+	require.Len(t, first.Accounts, 2)
+	assert.Equal(t, carol, first.Accounts[0].UserID)
+	assert.Equal(t, bob, first.Accounts[1].UserID)
+	require.NotEmpty(t, first.NextCursor)
+
+	// Новые признаки у alice между страницами не сдвигают ее по списку.
+	app.clock.Advance(3 * time.Hour)
+	overLike(alice, 1)
+
+	second := list("?limit=2&cursor=" + first.NextCursor)
+	require.Len(t, second.Accounts, 1)
+	assert.Equal(t, alice, second.Accounts[0].UserID)
+	// За три часа без признаков два старых очка забылись.
+	assert.Equal(t, 1, second.Accounts[0].Score)
+	assert.Empty(t, second.NextCursor)
+}
@@ -0,0 +1,67 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/middleware"
+)
+
+func TestRouter_AccessTokens(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	reports := func(header, value string) int {
+		req := httptest.NewRequest(http.MethodGet, "/admin/reports", nil)
+		req.Header.Set(header, value)
+		rec := httptest.NewRecorder()
+		app.router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Один X-User-ID роль не подтверждает.
+	assert.Equal(t, http.StatusUnauthorized, reports(middleware.UserIDHeader, app.admin))
+	assert.Equal(t, http.StatusUnauthorized, reports(middleware.AuthorizationHeader, "Bearer mb_forged"))
+	assert.Equal(t, http.StatusUnauthorized, reports(middleware.AuthorizationHeader, app.tokens[app.moderator]))
+	assert.Equal(t, http.StatusOK, reports(middleware.AuthorizationHeader, "Bearer "+app.tokens[app.moderator]))
+
+	// Без ключа X-User-ID не принимается и на обычных ручках.
+	req := httptest.NewRequest(http.MethodGet, "/drafts", nil)
+	req.Header.Set(middleware.UserIDHeader, alice)
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// Войти под занятым именем без ключа владельца нельзя.
+	rec = app.do(t, http.MethodPost, "/register", dto.CreateUserReq{Name: "alice"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	rec = app.doAs(t, alice, http.MethodPost, "/register", dto.CreateUserReq{Name: "alice"})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var login dto.CreateUserResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&login))
+	assert.Equal(t, alice, login.ID)
+	assert.NotEqual(t, app.tokens[alice], login.Token, "login issues a fresh token")
+
+	// Ключ чужого пользователя вместе с X-User-ID отклоняется.
+	req = httptest.NewRequest(http.MethodGet, "/admin/reports", nil)
+	req.Header.Set(middleware.UserIDHeader, alice)
+	req.Header.Set(middleware.AuthorizationHeader, "Bearer "+app.tokens[app.moderator])
+	rec = httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	tokens := "/admin/users/" + alice + "/tokens"
+	rec = app.doAs(t, app.moderator, http.MethodPost, tokens, nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = app.doAs(t, app.admin, http.MethodPost, tokens, nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code, "tokens are issued to staff only")
+
+	rec = app.doAs(t, app.admin, http.MethodDelete, "/admin/users/"+app.moderator+"/tokens", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, http.StatusUnauthorized, reports(middleware.AuthorizationHeader, "Bearer "+app.tokens[app.moderator]))
+}
@@ -0,0 +1,105 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/service"
+)
+
+func TestRouter_RenameKeepsOldHandleAndRedirects(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "Alice")
+	rec := app.do(t, http.MethodPost, "/register", dto.CreateUserReq{Name: "alice "})
+	assert.Equal(t, http.StatusConflict, rec.Code, "names must be case- and space-insensitive")
+
+	rec = app.doAs(t, alice, http.MethodPut, "/users/me/username", dto.RenameUserReq{Name: "alice_new"})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = app.do(t, http.MethodGet, "/users/by-name/alice", nil)
+	require.Equal(t, http.StatusTemporaryRedirect, rec.Code)
+	assert.Equal(t, "/users/by-name/alice_new", rec.Header().Get("Location"))
+
+	rec = app.do(t, http.MethodPost, "/register", dto.CreateUserReq{Name: "alice"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	bob := app.register(t, "bob")
+	rec = app.doAs(t, bob, http.MethodPut, "/users/me/username", dto.RenameUserReq{Name: "ALICE"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodPut, "/users/me/username", dto.RenameUserReq{Name: "alice"})
+	assert.Equal(t, http.StatusOK, rec.Code, "owner can take the held handle back")
+}
+
+func TestRouter_RenameHoldExpiresByClock(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+
+	rec := app.doAs(t, alice, http.MethodPut, "/users/me/username", dto.RenameUserReq{Name: "alice_new"})
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = app.doAs(t, bob, http.MethodPut, "/users/me/username", dto.RenameUserReq{Name: "alice"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	app.clock.Advance(service.UsernameHoldPeriod)
+
+	rec = app.do(t, http.MethodGet, "/users/by-name/alice", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = app.doAs(t, bob, http.MethodPut, "/users/me/username", dto.RenameUserReq{Name: "alice"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRouter_Roles(t *testing.T) {
+	app := newTestApp(t)
+
+	alice := app.register(t, "alice")
+	bob := app.register(t, "bob")
+
+	// Профилировщик доступен только администратору.
+	rec := app.do(t, http.MethodGet, "/debug/pprof/", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = app.doAs(t, app.moderator, http.MethodGet, "/debug/pprof/", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = app.doAs(t, app.admin, http.MethodGet, "/debug/pprof/", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rolePath := "/admin/users/" + alice + "/role"
+	rec = app.doAs(t, app.moderator, http.MethodPut, rolePath, dto.ChangeRoleReq{Role: "moderator"})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = app.doAs(t, app.admin, http.MethodPut, rolePath, dto.ChangeRoleReq{Role: "owner"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = app.doAs(t, app.admin, http.MethodPut, "/admin/users/"+uuid.NewString()+"/role", dto.ChangeRoleReq{Role: "moderator"})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = app.doAs(t, alice, http.MethodGet, "/admin/reports", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = app.doAs(t, app.admin, http.MethodPut, rolePath, dto.ChangeRoleReq{Role: "moderator"})
+	require.Equal(t, http.StatusOK, rec.Code)
+	var role dto.UserRoleResp
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&role))
+	assert.Equal(t, dto.UserRoleResp{ID: alice, Name: "alice", Role: "moderator"}, role)
+
+	// Новая роль действует сразу, с прежним ключом доступа.
+	rec = app.doAs(t, alice, http.MethodGet, "/admin/reports", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Единственного администратора понизить нельзя, пока не назначен второй.
+	rec = app.doAs(t, app.admin, http.MethodPut, "/admin/users/"+app.admin+"/role", dto.ChangeRoleReq{Role: "user"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = app.doAs(t, app.admin, http.MethodPut, "/admin/users/"+bob+"/role", dto.ChangeRoleReq{Role: "admin"})
+	require.Equal(t, http.StatusOK, rec.Code)
+	app.issueToken(t, bob)
+	rec = app.doAs(t, bob, http.MethodPut, "/admin/users/"+app.admin+"/role", dto.ChangeRoleReq{Role: "user"})
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = app.doAs(t, app.admin, http.MethodGet, "/admin/audit", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/converter"
+	"micro-blog/internal/handler/pkg/response"
+	"micro-blog/internal/logger"
+	"micro-blog/internal/middleware"
+	"micro-blog/internal/model"
+	"micro-blog/pkg/pkglogger"
+)
+
+const (
+	mediaFormField = "file"
+	// multipartOverhead - запас на заголовки multipart сверх лимита размера файла.
+	multipartOverhead = 1 << 20
+	// Файлы не меняются после загрузки, поэтому публичные можно кешировать
+	// надолго. Остальные зависят от зрителя и не должны попадать в кеши.
+	mediaCacheControl        = "public, max-age=31536000, immutable"
+	privateMediaCacheControl = "private, no-store"
+)
+
+type MediaService interface {
+	Upload(ctx context.Context, ownerID uuid.UUID, r io.Reader) (*model.Media, error)
+	Open(ctx context.Context, viewerID uuid.UUID, id uuid.UUID) (*model.Media, io.ReadSeekCloser, error)
+}
+
+type MediaHandler struct {
+	Service      MediaService
+	logger       logger.Logger
+	maxBodyBytes int64
+}
+
+func NewMediaHandler(service MediaService, logger logger.Logger, maxUploadSize int64) *MediaHandler {
+	return &MediaHandler{
+		Service:      service,
+		logger:       logger,
+		maxBodyBytes: maxUploadSize + multipartOverhead,
+	}
+}
+
+func (h *MediaHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	ownerID := middleware.UserIDFromContext(r.Context())
+	if ownerID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxBodyBytes)
+
+	file, err := h.formFile(r)
+	if err != nil {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	media, err := h.Service.Upload(r.Context(), ownerID, file)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = model.ErrMediaTooLarge
+		}
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to upload media", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "media successful uploaded")
+	response.SuccessJSON(w, converter.ToMediaRespFromModel(media), http.StatusCreated)
+}
+
+// formFile находит в multipart-теле часть с файлом, не буферизуя тело целиком.
+func (h *MediaHandler) formFile(r *http.Request) (io.Reader, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == mediaFormField {
+			return part, nil
+		}
+	}
+}
+
+func (h *MediaHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	viewerID := middleware.UserIDFromContext(r.Context())
+	media, file, err := h.Service.Open(r.Context(), viewerID, id)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to get media", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", media.ContentType)
+	if media.Public {
+		w.Header().Set("Cache-Control", mediaCacheControl)
+	} else {
+		w.Header().Set("Cache-Control", privateMediaCacheControl)
+	}
+	w.Header().Set("ETag", `"`+media.ID.String()+`"`)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	// ServeContent сам обрабатывает Range, If-None-Match и If-Modified-Since.
+	http.ServeContent(w, r, "", media.CreatedAt, file)
+}
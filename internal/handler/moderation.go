@@ -0,0 +1,311 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/converter"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/handler/pkg/response"
+	"micro-blog/internal/logger"
+	"micro-blog/internal/middleware"
+	"micro-blog/internal/model"
+	"micro-blog/pkg/pkglogger"
+)
+
+type ModerationService interface {
+	ReportContent(ctx context.Context, report *model.Report) (*model.Report, error)
+	ListReports(
+		ctx context.Context,
+		actorID uuid.UUID,
+		status model.ReportStatus,
+		cursor int64,
+		limit int,
+	) (*model.ReportPage, error)
+	GetReport(ctx context.Context, actorID, reportID uuid.UUID) (*model.Report, error)
+	Moderate(ctx context.Context, action *model.ModerationAction) (*model.Report, error)
+	Sanction(ctx context.Context, actorID, userID uuid.UUID, sanction *model.Sanction) (*model.User, error)
+	LiftSanction(ctx context.Context, actorID, userID uuid.UUID, sanctionType model.SanctionType) (*model.User, error)
+	MarkSensitive(ctx context.Context, actorID, postID uuid.UUID, contentWarning string) (*model.Post, error)
+}
+
+// ModerationHandler принимает жалобы пользователей, обслуживает очередь
+// модерации /admin/reports, пометки постов /admin/posts/{id}/... и санкции
+// /admin/users/{id}/...
+type ModerationHandler struct {
+	Service ModerationService
+	logger  logger.Logger
+}
+
+func NewModerationHandler(service ModerationService, logger logger.Logger) *ModerationHandler {
+	return &ModerationHandler{
+		Service: service,
+		logger:  logger,
+	}
+}
+
+func (h *ModerationHandler) ReportPost(w http.ResponseWriter, r *http.Request) {
+	h.report(w, r, model.ReportTargetPost)
+}
+
+func (h *ModerationHandler) ReportUser(w http.ResponseWriter, r *http.Request) {
+	h.report(w, r, model.ReportTargetUser)
+}
+
+func (h *ModerationHandler) report(w http.ResponseWriter, r *http.Request, target model.ReportTarget) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateReportReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	v := getValidator(r)
+	if err := v.Struct(req); err != nil {
+		response.WriteError(w, ErrRequestFields, http.StatusBadRequest)
+		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	report, err := converter.ToReportModelFromReq(&req, userID, target, r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	created, err := h.Service.ReportContent(r.Context(), report)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to create report", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "report successful created")
+	response.SuccessJSON(w, converter.ToReportRespFromModel(created), http.StatusCreated)
+}
+
+func (h *ModerationHandler) ListReports(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	cursor, limit, err := parsePage(r)
+	if err != nil {
+		response.WriteError(w, ErrPageParams, http.StatusBadRequest)
+		h.logger.Info(ErrPageParams, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	status := model.ReportStatus(r.URL.Query().Get("status"))
+	if status != "" && !status.Valid() {
+		response.WriteError(w, ErrFilterParams, http.StatusBadRequest)
+		h.logger.Info(ErrFilterParams, slog.String("status", string(status)))
+		return
+	}
+
+	page, err := h.Service.ListReports(r.Context(), userID, status, cursor, limit)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to list reports", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful list reports")
+	response.SuccessJSON(w, converter.ToReportsPageRespFromModel(page), http.StatusOK)
+}
+
+func (h *ModerationHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	reportID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	report, err := h.Service.GetReport(r.Context(), userID, reportID)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to get report", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful get report")
+	response.SuccessJSON(w, converter.ToReportRespFromModel(report), http.StatusOK)
+}
+
+func (h *ModerationHandler) Moderate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.ModerationActionReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	v := getValidator(r)
+	if err := v.Struct(req); err != nil {
+		response.WriteError(w, ErrRequestFields, http.StatusBadRequest)
+		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	action, err := converter.ToModerationActionModelFromReq(&req, userID, r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	report, err := h.Service.Moderate(r.Context(), action)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to moderate report", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "report successful moderated")
+	response.SuccessJSON(w, converter.ToReportRespFromModel(report), http.StatusOK)
+}
+
+// MarkSensitive помечает пост деликатным по решению модератора.
+func (h *ModerationHandler) MarkSensitive(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	postID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	var req dto.MarkSensitiveReq
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	post, err := h.Service.MarkSensitive(r.Context(), actorID, postID, req.ContentWarning)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to mark post sensitive", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "post successful marked sensitive")
+	response.SuccessJSON(w, converter.ToPostRespFromModel(post), http.StatusOK)
+}
+
+func (h *ModerationHandler) Suspend(w http.ResponseWriter, r *http.Request) {
+	h.sanction(w, r, model.SanctionSuspension)
+}
+
+func (h *ModerationHandler) ShadowBan(w http.ResponseWriter, r *http.Request) {
+	h.sanction(w, r, model.SanctionShadowBan)
+}
+
+func (h *ModerationHandler) LiftSuspension(w http.ResponseWriter, r *http.Request) {
+	h.liftSanction(w, r, model.SanctionSuspension)
+}
+
+func (h *ModerationHandler) LiftShadowBan(w http.ResponseWriter, r *http.Request) {
+	h.liftSanction(w, r, model.SanctionShadowBan)
+}
+
+func (h *ModerationHandler) sanction(w http.ResponseWriter, r *http.Request, sanctionType model.SanctionType) {
+	actorID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	var req dto.SanctionReq
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	v := getValidator(r)
+	if err = v.Struct(req); err != nil {
+		response.WriteError(w, ErrRequestFields, http.StatusBadRequest)
+		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	user, err := h.Service.Sanction(r.Context(), actorID, userID, converter.ToSanctionModelFromReq(&req, sanctionType))
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to sanction user", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "user successful sanctioned", slog.String("sanction", string(sanctionType)))
+	response.SuccessJSON(w, converter.ToUserSanctionsRespFromModel(user), http.StatusOK)
+}
+
+func (h *ModerationHandler) liftSanction(w http.ResponseWriter, r *http.Request, sanctionType model.SanctionType) {
+	actorID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	user, err := h.Service.LiftSanction(r.Context(), actorID, userID, sanctionType)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to lift sanction", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "sanction successful lifted", slog.String("sanction", string(sanctionType)))
+	response.SuccessJSON(w, converter.ToUserSanctionsRespFromModel(user), http.StatusOK)
+}
+
+func (h *ModerationHandler) user(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
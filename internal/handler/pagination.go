@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"micro-blog/internal/model"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+var errPageParams = errors.New("invalid pagination parameters")
+
+var errFilterParams = errors.New("invalid filter parameters")
+
+// parsePage читает cursor и limit из query-параметров.
+// Пустой cursor означает первую страницу.
+func parsePage(r *http.Request) (int64, int, error) {
+	var cursor int64
+	if raw := r.URL.Query().Get("cursor"); raw != "" {
+		c, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || c < 0 {
+			return 0, 0, errPageParams
+		}
+		cursor = c
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		return 0, 0, err
+	}
+	return cursor, limit, nil
+}
+
+// parseConversationPage читает курсор списка переписок вида
+// "<снимок>.<позиция>" и limit.
+func parseConversationPage(r *http.Request) (model.ConversationCursor, int, error) {
+	var cursor model.ConversationCursor
+	if raw := r.URL.Query().Get("cursor"); raw != "" {
+		snapshot, before, ok := strings.Cut(raw, ".")
+		if !ok {
+			return cursor, 0, errPageParams
+		}
+		var err1, err2 error
+		cursor.Snapshot, err1 = strconv.ParseInt(snapshot, 10, 64)
+		cursor.Before, err2 = strconv.ParseInt(before, 10, 64)
+		if err1 != nil || err2 != nil || cursor.Snapshot <= 0 || cursor.Before <= 0 {
+			return model.ConversationCursor{}, 0, errPageParams
+		}
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		return model.ConversationCursor{}, 0, err
+	}
+	return cursor, limit, nil
+}
+
+func parseLimit(r *http.Request) (int, error) {
+	limit := defaultPageLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		l, err := strconv.Atoi(raw)
+		if err != nil || l < 1 {
+			return 0, errPageParams
+		}
+		limit = min(l, maxPageLimit)
+	}
+	return limit, nil
+}
+
+// parseAuthorFeedFilter читает фильтры ленты автора: exclude_replies,
+// exclude_reposts и only_media. Отсутствующий параметр означает false.
+func parseAuthorFeedFilter(r *http.Request) (model.AuthorFeedFilter, error) {
+	query := r.URL.Query()
+
+	var filter model.AuthorFeedFilter
+	params := []struct {
+		name  string
+		value *bool
+	}{
+		{name: "exclude_replies", value: &filter.ExcludeReplies},
+		{name: "exclude_reposts", value: &filter.ExcludeReposts},
+		{name: "only_media", value: &filter.OnlyMedia},
+	}
+
+	for _, param := range params {
+		raw := query.Get(param.name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return model.AuthorFeedFilter{}, errFilterParams
+		}
+		*param.value = value
+	}
+
+	return filter, nil
+}
@@ -8,7 +8,13 @@ import (
 )
 
 func WriteError(w http.ResponseWriter, message string, status int) {
+	WriteErrorResp(w, &dto.ErrorResponse{Message: message}, status)
+}
+
+// WriteErrorResp отправляет ошибку с дополнительными полями, например
+// нарушениями контент-политики.
+func WriteErrorResp(w http.ResponseWriter, resp *dto.ErrorResponse, status int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(dto.ErrorResponse{Message: message})
+	_ = json.NewEncoder(w).Encode(resp)
 }
@@ -3,22 +3,46 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
-	"strings"
 
+	"github.com/google/uuid"
 	"micro-blog/internal/converter"
 	"micro-blog/internal/handler/dto"
 	"micro-blog/internal/handler/pkg/response"
 	"micro-blog/internal/logger"
+	"micro-blog/internal/middleware"
 	"micro-blog/internal/model"
 	"micro-blog/pkg/pkglogger"
 )
 
 type PostService interface {
 	CreatePost(ctx context.Context, post *model.Post) (*model.Post, error)
-	GetListPost(ctx context.Context) ([]*model.Post, error)
-	LikePost(ctx context.Context, like *model.Like) error
+	GetListPost(ctx context.Context, viewerID uuid.UUID) ([]*model.Post, error)
+	ReactToPost(ctx context.Context, reaction *model.Reaction) error
+	GetPost(ctx context.Context, viewerID uuid.UUID, postID uuid.UUID) (*model.Post, error)
+	GetPostReactions(
+		ctx context.Context,
+		viewerID uuid.UUID,
+		postID uuid.UUID,
+		reactionType model.ReactionType,
+		cursor int64,
+		limit int,
+	) (*model.ReactionPage, error)
+	EditPost(ctx context.Context, editorID uuid.UUID, postID uuid.UUID, text string) (*model.Post, error)
+	GetPostRevisions(ctx context.Context, viewerID uuid.UUID, postID uuid.UUID) ([]*model.PostRevision, error)
+	GetAuthorPosts(
+		ctx context.Context,
+		viewerID uuid.UUID,
+		authorID uuid.UUID,
+		filter model.AuthorFeedFilter,
+		cursor int64,
+		limit int,
+	) (*model.PostPage, error)
+	PinPost(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error
+	VotePoll(ctx context.Context, vote *model.PollVote) error
+	UnpinPost(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error
 }
 
 type PostHandler struct {
@@ -34,6 +58,13 @@ func NewPostHandler(service PostService, logger logger.Logger) *PostHandler {
 }
 
 func (h *PostHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
+	authorID := middleware.UserIDFromContext(r.Context())
+	if authorID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return
+	}
+
 	var req dto.CreatePostReq
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -49,7 +80,7 @@ func (h *PostHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	postModel, err := converter.ToPostModelFromReq(&req)
+	postModel, err := converter.ToPostModelFromReq(&req, authorID)
 	if err != nil {
 		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
 		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
@@ -57,8 +88,12 @@ func (h *PostHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	post, err := h.Service.CreatePost(r.Context(), postModel)
+	if writePolicyError(w, err) {
+		h.logger.Info("post rejected by content policy", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
 	if err != nil {
-		response.WriteError(w, err.Error(), http.StatusBadRequest)
+		response.WriteError(w, err.Error(), statusFromError(err))
 		h.logger.Info("error to create post", slog.String(pkglogger.ErrorKey, err.Error()))
 		return
 	}
@@ -69,8 +104,30 @@ func (h *PostHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
 	response.SuccessJSON(w, resp, http.StatusCreated)
 }
 
+func (h *PostHandler) GetPost(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	viewerID := middleware.UserIDFromContext(r.Context())
+	post, err := h.Service.GetPost(r.Context(), viewerID, postID)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to get post", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful get post")
+	response.SuccessJSON(w, converter.ToPostRespFromModel(post), http.StatusOK)
+}
+
 func (h *PostHandler) GetPostList(w http.ResponseWriter, r *http.Request) {
-	posts, err := h.Service.GetListPost(r.Context())
+	viewerID := middleware.UserIDFromContext(r.Context())
+
+	posts, err := h.Service.GetListPost(r.Context(), viewerID)
 	if err != nil {
 		response.WriteError(w, err.Error(), http.StatusBadRequest)
 		h.logger.Info("error to get posts info", slog.String(pkglogger.ErrorKey, err.Error()))
@@ -87,22 +144,32 @@ func (h *PostHandler) GetPostList(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *PostHandler) LikePost(w http.ResponseWriter, r *http.Request) {
-	path := r.URL.Path
-
-	const prefix = "/posts/"
-	const suffix = "/like"
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return
+	}
 
-	if !strings.HasPrefix(path, prefix) || !strings.HasSuffix(path, suffix) {
-		response.WriteError(w, ErrNotFound, http.StatusNotFound)
-		h.logger.Info(ErrNotFound, slog.String(pkglogger.ErrorKey, path))
+	likeModel, err := converter.ToLikeModel(userID, r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
 		return
 	}
 
-	// Извлекаем id из пути
-	idPartStr := strings.TrimSuffix(strings.TrimPrefix(path, prefix), suffix)
-	idPartStr = strings.Trim(idPartStr, "/")
+	h.react(w, r, likeModel)
+}
+
+func (h *PostHandler) ReactToPost(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return
+	}
 
-	var req dto.LikeRequest
+	var req dto.ReactionRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
@@ -117,20 +184,288 @@ func (h *PostHandler) LikePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	likeModel, err := converter.ToLikeModelFromReq(&req, idPartStr)
+	reactionModel, err := converter.ToReactionModelFromReq(&req, userID, r.PathValue("id"))
 	if err != nil {
 		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
 		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
 		return
 	}
 
-	err = h.Service.LikePost(r.Context(), likeModel)
+	h.react(w, r, reactionModel)
+}
+
+func (h *PostHandler) RemoveReaction(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return
+	}
+
+	reactionModel, err := converter.ToRemoveReactionModel(userID, r.PathValue("id"))
 	if err != nil {
-		response.WriteError(w, err.Error(), http.StatusBadRequest)
-		h.logger.Info("error to like post", slog.String(pkglogger.ErrorKey, err.Error()))
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.react(w, r, reactionModel)
+}
+
+func (h *PostHandler) react(w http.ResponseWriter, r *http.Request, reaction *model.Reaction) {
+	err := h.Service.ReactToPost(r.Context(), reaction)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to react to post", slog.String(pkglogger.ErrorKey, err.Error()))
 		return
 	}
 
-	h.logger.InfoContext(r.Context(), "successful liked post")
+	h.logger.InfoContext(r.Context(), "successful reacted to post")
 	response.SuccessCode(w, http.StatusOK)
 }
+
+func (h *PostHandler) GetPostLikes(w http.ResponseWriter, r *http.Request) {
+	page, ok := h.getPostReactions(w, r, model.ReactionLike)
+	if !ok {
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful get post likes")
+	response.SuccessJSON(w, converter.ToLikesPageRespFromModel(page), http.StatusOK)
+}
+
+func (h *PostHandler) GetPostReactions(w http.ResponseWriter, r *http.Request) {
+	reactionType := model.ReactionType(r.URL.Query().Get("type"))
+	if reactionType != model.ReactionNone && !reactionType.Valid() {
+		response.WriteError(w, model.ErrInvalidReaction.Error(), http.StatusBadRequest)
+		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, model.ErrInvalidReaction.Error()))
+		return
+	}
+
+	page, ok := h.getPostReactions(w, r, reactionType)
+	if !ok {
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful get post reactions")
+	response.SuccessJSON(w, converter.ToReactionsPageRespFromModel(page), http.StatusOK)
+}
+
+// getPostReactions разбирает id поста и пагинацию и загружает страницу реакций.
+// При ошибке сам пишет ответ и возвращает false.
+func (h *PostHandler) getPostReactions(
+	w http.ResponseWriter,
+	r *http.Request,
+	reactionType model.ReactionType,
+) (*model.ReactionPage, bool) {
+	postID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return nil, false
+	}
+
+	cursor, limit, err := parsePage(r)
+	if err != nil {
+		response.WriteError(w, ErrPageParams, http.StatusBadRequest)
+		h.logger.Info(ErrPageParams, slog.String(pkglogger.ErrorKey, err.Error()))
+		return nil, false
+	}
+
+	viewerID := middleware.UserIDFromContext(r.Context())
+	page, err := h.Service.GetPostReactions(r.Context(), viewerID, postID, reactionType, cursor, limit)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to get post reactions", slog.String(pkglogger.ErrorKey, err.Error()))
+		return nil, false
+	}
+
+	return page, true
+}
+
+func (h *PostHandler) EditPost(w http.ResponseWriter, r *http.Request) {
+	editorID := middleware.UserIDFromContext(r.Context())
+	if editorID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return
+	}
+
+	postID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	var req dto.EditPostReq
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	post, err := h.Service.EditPost(r.Context(), editorID, postID, req.Text)
+	if writePolicyError(w, err) {
+		h.logger.Info("edit rejected by content policy", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to edit post", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "post successful edited")
+	response.SuccessJSON(w, converter.ToPostRespFromModel(post), http.StatusOK)
+}
+
+func (h *PostHandler) GetPostRevisions(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	viewerID := middleware.UserIDFromContext(r.Context())
+	revisions, err := h.Service.GetPostRevisions(r.Context(), viewerID, postID)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to get post revisions", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	revisionsResp := make([]*dto.RevisionResp, len(revisions))
+	for i, revision := range revisions {
+		revisionsResp[i] = converter.ToRevisionRespFromModel(revision)
+	}
+
+	h.logger.InfoContext(r.Context(), "successful get post revisions")
+	response.SuccessJSON(w, revisionsResp, http.StatusOK)
+}
+
+func (h *PostHandler) GetAuthorPosts(w http.ResponseWriter, r *http.Request) {
+	authorID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	filter, err := parseAuthorFeedFilter(r)
+	if err != nil {
+		response.WriteError(w, ErrFilterParams, http.StatusBadRequest)
+		h.logger.Info(ErrFilterParams, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	cursor, limit, err := parsePage(r)
+	if err != nil {
+		response.WriteError(w, ErrPageParams, http.StatusBadRequest)
+		h.logger.Info(ErrPageParams, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	viewerID := middleware.UserIDFromContext(r.Context())
+	page, err := h.Service.GetAuthorPosts(r.Context(), viewerID, authorID, filter, cursor, limit)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to get author posts", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful get author posts")
+	response.SuccessJSON(w, converter.ToPostsPageRespFromModel(page), http.StatusOK)
+}
+
+func (h *PostHandler) PinPost(w http.ResponseWriter, r *http.Request) {
+	h.pin(w, r, h.Service.PinPost, "post successful pinned")
+}
+
+func (h *PostHandler) UnpinPost(w http.ResponseWriter, r *http.Request) {
+	h.pin(w, r, h.Service.UnpinPost, "post successful unpinned")
+}
+
+// pin разбирает пользователя и id поста и выполняет закрепление или открепление.
+func (h *PostHandler) pin(
+	w http.ResponseWriter,
+	r *http.Request,
+	action func(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error,
+	successMsg string,
+) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return
+	}
+
+	postID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	if err = action(r.Context(), userID, postID); err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to change post pin", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), successMsg)
+	response.SuccessCode(w, http.StatusOK)
+}
+
+func (h *PostHandler) VotePoll(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return
+	}
+
+	var req dto.VoteReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	v := getValidator(r)
+	if err := v.Struct(req); err != nil {
+		response.WriteError(w, ErrRequestFields, http.StatusBadRequest)
+		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	vote, err := converter.ToVoteModelFromReq(&req, userID, r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	if err = h.Service.VotePoll(r.Context(), vote); err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to vote in poll", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "poll vote accepted")
+	response.SuccessCode(w, http.StatusAccepted)
+}
+
+// writePolicyError отвечает на отказ контент-политики списком сработавших
+// правил и сообщает, была ли err таким отказом.
+func writePolicyError(w http.ResponseWriter, err error) bool {
+	var policyErr *model.PolicyError
+	if !errors.As(err, &policyErr) {
+		return false
+	}
+	response.WriteErrorResp(w, converter.ToPolicyErrorRespFromModel(policyErr), http.StatusUnprocessableEntity)
+	return true
+}
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/converter"
+	"micro-blog/internal/handler/dto"
+	"micro-blog/internal/handler/pkg/response"
+	"micro-blog/internal/logger"
+	"micro-blog/internal/middleware"
+	"micro-blog/internal/model"
+	"micro-blog/pkg/pkglogger"
+)
+
+type ProfileService interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (*model.Profile, error)
+	GetProfileByName(ctx context.Context, name string) (*model.Profile, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, update *model.ProfileUpdate) (*model.Profile, error)
+	Follow(ctx context.Context, followerID, followeeID uuid.UUID) (model.FollowStatus, error)
+	Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) error
+	ListFollowRequests(ctx context.Context, ownerID uuid.UUID, cursor int64, limit int) (*model.RelationPage, error)
+	ApproveFollowRequest(ctx context.Context, ownerID, followerID uuid.UUID) error
+	RejectFollowRequest(ctx context.Context, ownerID, followerID uuid.UUID) error
+}
+
+type ProfileHandler struct {
+	Service ProfileService
+	logger  logger.Logger
+}
+
+func NewProfileHandler(service ProfileService, logger logger.Logger) *ProfileHandler {
+	return &ProfileHandler{
+		Service: service,
+		logger:  logger,
+	}
+}
+
+func (h *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	profile, err := h.Service.GetProfile(r.Context(), userID)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to get profile", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful get profile")
+	response.SuccessJSON(w, converter.ToProfileRespFromModel(profile), http.StatusOK)
+}
+
+func (h *ProfileHandler) GetProfileByName(w http.ResponseWriter, r *http.Request) {
+	profile, err := h.Service.GetProfileByName(r.Context(), r.PathValue("name"))
+	var moved *model.UsernameMovedError
+	if errors.As(err, &moved) {
+		h.logger.InfoContext(r.Context(), "profile lookup redirected", slog.String("to", moved.NewName))
+		http.Redirect(w, r, "/users/by-name/"+url.PathEscape(moved.NewName), http.StatusTemporaryRedirect)
+		return
+	}
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to get profile", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful get profile")
+	response.SuccessJSON(w, converter.ToProfileRespFromModel(profile), http.StatusOK)
+}
+
+func (h *ProfileHandler) UpdateMyProfile(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return
+	}
+
+	var req dto.UpdateProfileReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	v := getValidator(r)
+	if err := v.Struct(req); err != nil {
+		response.WriteError(w, ErrRequestFields, http.StatusBadRequest)
+		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	profile, err := h.Service.UpdateProfile(r.Context(), userID, converter.ToProfileUpdateFromReq(&req))
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to update profile", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "profile successful updated")
+	response.SuccessJSON(w, converter.ToOwnProfileRespFromModel(profile), http.StatusOK)
+}
+
+func (h *ProfileHandler) Follow(w http.ResponseWriter, r *http.Request) {
+	followerID, followeeID, ok := h.followPair(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.Service.Follow(r.Context(), followerID, followeeID)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to follow user", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	// Подписка на закрытый аккаунт ждет одобрения владельца.
+	code := http.StatusOK
+	if status == model.FollowStatusRequested {
+		code = http.StatusAccepted
+	}
+
+	h.logger.InfoContext(r.Context(), "successful followed user", slog.String("status", string(status)))
+	response.SuccessJSON(w, dto.FollowResp{Status: string(status)}, code)
+}
+
+func (h *ProfileHandler) Unfollow(w http.ResponseWriter, r *http.Request) {
+	followerID, followeeID, ok := h.followPair(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.Unfollow(r.Context(), followerID, followeeID); err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to unfollow user", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful unfollowed user")
+	response.SuccessCode(w, http.StatusOK)
+}
+
+func (h *ProfileHandler) ListFollowRequests(w http.ResponseWriter, r *http.Request) {
+	ownerID := middleware.UserIDFromContext(r.Context())
+	if ownerID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return
+	}
+
+	cursor, limit, err := parsePage(r)
+	if err != nil {
+		response.WriteError(w, ErrPageParams, http.StatusBadRequest)
+		h.logger.Info(ErrPageParams, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	page, err := h.Service.ListFollowRequests(r.Context(), ownerID, cursor, limit)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to list follow requests", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful list follow requests")
+	response.SuccessJSON(w, converter.ToRelationsPageRespFromModel(page), http.StatusOK)
+}
+
+// ApproveFollowRequest одобряет запрос пользователя из пути на подписку
+// на текущего пользователя.
+func (h *ProfileHandler) ApproveFollowRequest(w http.ResponseWriter, r *http.Request) {
+	ownerID, followerID, ok := h.followPair(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.ApproveFollowRequest(r.Context(), ownerID, followerID); err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to approve follow request", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "follow request successful approved")
+	response.SuccessCode(w, http.StatusOK)
+}
+
+func (h *ProfileHandler) RejectFollowRequest(w http.ResponseWriter, r *http.Request) {
+	ownerID, followerID, ok := h.followPair(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.RejectFollowRequest(r.Context(), ownerID, followerID); err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to reject follow request", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "follow request successful rejected")
+	response.SuccessCode(w, http.StatusOK)
+}
+
+// followPair возвращает текущего пользователя и пользователя из пути.
+// При ошибке сам пишет ответ и возвращает false.
+func (h *ProfileHandler) followPair(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	followerID := middleware.UserIDFromContext(r.Context())
+	if followerID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	followeeID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return followerID, followeeID, true
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/converter"
+	"micro-blog/internal/handler/pkg/response"
+	"micro-blog/internal/logger"
+	"micro-blog/internal/middleware"
+	"micro-blog/internal/model"
+	"micro-blog/pkg/pkglogger"
+)
+
+type RelationService interface {
+	Block(ctx context.Context, ownerID, targetID uuid.UUID) error
+	Unblock(ctx context.Context, ownerID, targetID uuid.UUID) error
+	ListBlocked(ctx context.Context, ownerID uuid.UUID, cursor int64, limit int) (*model.RelationPage, error)
+	Mute(ctx context.Context, ownerID, targetID uuid.UUID) error
+	Unmute(ctx context.Context, ownerID, targetID uuid.UUID) error
+	ListMuted(ctx context.Context, ownerID uuid.UUID, cursor int64, limit int) (*model.RelationPage, error)
+}
+
+// RelationHandler обслуживает блокировки и заглушения текущего
+// пользователя; все ручки требуют идентификации.
+type RelationHandler struct {
+	Service RelationService
+	logger  logger.Logger
+}
+
+func NewRelationHandler(service RelationService, logger logger.Logger) *RelationHandler {
+	return &RelationHandler{
+		Service: service,
+		logger:  logger,
+	}
+}
+
+func (h *RelationHandler) Block(w http.ResponseWriter, r *http.Request) {
+	h.change(w, r, h.Service.Block, "block user")
+}
+
+func (h *RelationHandler) Unblock(w http.ResponseWriter, r *http.Request) {
+	h.change(w, r, h.Service.Unblock, "unblock user")
+}
+
+func (h *RelationHandler) Mute(w http.ResponseWriter, r *http.Request) {
+	h.change(w, r, h.Service.Mute, "mute user")
+}
+
+func (h *RelationHandler) Unmute(w http.ResponseWriter, r *http.Request) {
+	h.change(w, r, h.Service.Unmute, "unmute user")
+}
+
+func (h *RelationHandler) ListBlocked(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, h.Service.ListBlocked, "blocked users")
+}
+
+func (h *RelationHandler) ListMuted(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, h.Service.ListMuted, "muted users")
+}
+
+// change применяет action к паре из текущего пользователя и пользователя из пути.
+func (h *RelationHandler) change(
+	w http.ResponseWriter,
+	r *http.Request,
+	action func(ctx context.Context, ownerID, targetID uuid.UUID) error,
+	name string,
+) {
+	ownerID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	targetID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	if err = action(r.Context(), ownerID, targetID); err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to "+name, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful "+name)
+	response.SuccessCode(w, http.StatusOK)
+}
+
+func (h *RelationHandler) list(
+	w http.ResponseWriter,
+	r *http.Request,
+	list func(ctx context.Context, ownerID uuid.UUID, cursor int64, limit int) (*model.RelationPage, error),
+	name string,
+) {
+	ownerID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	cursor, limit, err := parsePage(r)
+	if err != nil {
+		response.WriteError(w, ErrPageParams, http.StatusBadRequest)
+		h.logger.Info(ErrPageParams, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	page, err := list(r.Context(), ownerID, cursor, limit)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to list "+name, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful list "+name)
+	response.SuccessJSON(w, converter.ToRelationsPageRespFromModel(page), http.StatusOK)
+}
+
+func (h *RelationHandler) user(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/go-playground/validator/v10"
 	"micro-blog/internal/logger"
 	"micro-blog/internal/middleware"
+	"micro-blog/internal/model"
 )
 
 const (
@@ -13,38 +15,133 @@ const (
 	ErrRequestFields = "Invalid Request Fields"
 	ErrUUIDParsing   = "Invalid UUID"
 	ErrNotFound      = "Not Found"
+	ErrPageParams    = "Invalid Pagination Parameters"
+	ErrFilterParams  = "Invalid Filter Parameters"
+	ErrUnauthorized  = "Unauthorized"
 )
 
 type Service interface {
 	UserService
 	PostService
+	ProfileService
+	MediaService
+	DraftService
+	BookmarkService
+	ConversationService
+	RelationService
+	ModerationService
+	SpamService
+	AuditService
+	AccessTokenService
 }
 
 type Router struct {
 	service Service
 	logger  logger.Logger
+	// maxUploadSize - предел тела запроса загрузки медиа; точные лимиты
+	// по типам файлов проверяет сервис.
+	maxUploadSize int64
 }
 
-func NewRouter(service Service, logger logger.Logger) http.Handler {
+func NewRouter(service Service, logger logger.Logger, maxUploadSize int64) http.Handler {
 	r := http.NewServeMux()
 	router := &Router{
-		service: service,
-		logger:  logger,
+		service:       service,
+		logger:        logger,
+		maxUploadSize: maxUploadSize,
 	}
 
 	validate := middleware.NewValidator().Middleware
 	recovery := middleware.Recovery(logger)
+	identity := middleware.Identity(service)
 
 	// Утилита для оборачивания хендлера
 	wrap := func(h http.Handler) http.Handler {
-		return recovery(validate(h))
+		return middleware.RequestID(recovery(middleware.ClientIP(identity(validate(h)))))
 	}
 
+	// Ручки, доступные только пользователям с ролью не ниже min.
+	guard := func(min model.Role) func(http.Handler) http.Handler {
+		requireRole := middleware.RequireRole(service, min)
+		return func(h http.Handler) http.Handler {
+			return wrap(requireRole(h))
+		}
+	}
+	moderator := guard(model.RoleModerator)
+	admin := guard(model.RoleAdmin)
+
 	r.Handle("/register", methodOnly(http.MethodPost, wrap(http.HandlerFunc(router.authHandler))))
 	r.Handle("/posts", wrap(http.HandlerFunc(router.postsHandler)))
-	r.Handle("/posts/", methodOnly(http.MethodPost, wrap(http.HandlerFunc(router.LikePostHandler))))
+	r.Handle("GET /posts/{id}", wrap(http.HandlerFunc(router.getPostHandler)))
+	r.Handle("PATCH /posts/{id}", wrap(http.HandlerFunc(router.editPostHandler)))
+	r.Handle("GET /posts/{id}/revisions", wrap(http.HandlerFunc(router.postRevisionsHandler)))
+	r.Handle("POST /posts/{id}/like", wrap(http.HandlerFunc(router.LikePostHandler)))
+	r.Handle("GET /posts/{id}/likes", wrap(http.HandlerFunc(router.postLikesHandler)))
+	r.Handle("/posts/{id}/reactions", wrap(http.HandlerFunc(router.postReactionsHandler)))
+	r.Handle("POST /posts/{id}/votes", wrap(http.HandlerFunc(router.votePollHandler)))
+	r.Handle("POST /posts/{id}/pin", wrap(http.HandlerFunc(router.pinPostHandler)))
+	r.Handle("DELETE /posts/{id}/pin", wrap(http.HandlerFunc(router.unpinPostHandler)))
+	// Разделы пользователя регистрируются одним шаблоном: отдельный
+	// "GET /users/{id}/posts" конфликтует с "GET /users/by-name/{name}".
+	r.Handle("GET /users/{id}/{section}", wrap(http.HandlerFunc(router.userSectionHandler)))
+	r.Handle("GET /users/{id}", wrap(http.HandlerFunc(router.profileHandler)))
+	r.Handle("GET /users/by-name/{name}", wrap(http.HandlerFunc(router.profileByNameHandler)))
+	r.Handle("PATCH /users/me", wrap(http.HandlerFunc(router.updateProfileHandler)))
+	r.Handle("PUT /users/me/username", wrap(http.HandlerFunc(router.renameHandler)))
+	r.Handle("POST /media", wrap(http.HandlerFunc(router.uploadMediaHandler)))
+	r.Handle("GET /media/{id}", wrap(http.HandlerFunc(router.getMediaHandler)))
+	r.Handle("POST /users/{id}/follow", wrap(http.HandlerFunc(router.followHandler)))
+	r.Handle("DELETE /users/{id}/follow", wrap(http.HandlerFunc(router.unfollowHandler)))
+	r.Handle("GET /follow-requests", wrap(http.HandlerFunc(router.listFollowRequestsHandler)))
+	r.Handle("POST /follow-requests/{id}/approve", wrap(http.HandlerFunc(router.approveFollowRequestHandler)))
+	r.Handle("POST /follow-requests/{id}/reject", wrap(http.HandlerFunc(router.rejectFollowRequestHandler)))
+	r.Handle("POST /users/{id}/block", wrap(http.HandlerFunc(router.blockHandler)))
+	r.Handle("DELETE /users/{id}/block", wrap(http.HandlerFunc(router.unblockHandler)))
+	r.Handle("POST /users/{id}/mute", wrap(http.HandlerFunc(router.muteHandler)))
+	r.Handle("DELETE /users/{id}/mute", wrap(http.HandlerFunc(router.unmuteHandler)))
+	r.Handle("GET /blocks", wrap(http.HandlerFunc(router.listBlockedHandler)))
+	r.Handle("GET /mutes", wrap(http.HandlerFunc(router.listMutedHandler)))
+
+	r.Handle("POST /drafts", wrap(http.HandlerFunc(router.createDraftHandler)))
+	r.Handle("GET /drafts", wrap(http.HandlerFunc(router.listDraftsHandler)))
+	r.Handle("GET /drafts/{id}", wrap(http.HandlerFunc(router.getDraftHandler)))
+	r.Handle("PUT /drafts/{id}", wrap(http.HandlerFunc(router.updateDraftHandler)))
+	r.Handle("DELETE /drafts/{id}", wrap(http.HandlerFunc(router.deleteDraftHandler)))
+	r.Handle("POST /drafts/{id}/publish", wrap(http.HandlerFunc(router.publishDraftHandler)))
+
+	r.Handle("POST /posts/{id}/bookmark", wrap(http.HandlerFunc(router.addBookmarkHandler)))
+	r.Handle("DELETE /posts/{id}/bookmark", wrap(http.HandlerFunc(router.removeBookmarkHandler)))
+	r.Handle("GET /bookmarks", wrap(http.HandlerFunc(router.listBookmarksHandler)))
+	r.Handle("POST /bookmarks/collections", wrap(http.HandlerFunc(router.createCollectionHandler)))
+	r.Handle("GET /bookmarks/collections", wrap(http.HandlerFunc(router.listCollectionsHandler)))
+	r.Handle("DELETE /bookmarks/collections/{id}", wrap(http.HandlerFunc(router.deleteCollectionHandler)))
+	r.Handle("POST /conversations", wrap(http.HandlerFunc(router.createConversationHandler)))
+	r.Handle("GET /conversations", wrap(http.HandlerFunc(router.listConversationsHandler)))
+	r.Handle("GET /conversations/unread", wrap(http.HandlerFunc(router.unreadMessagesHandler)))
+	r.Handle("GET /conversations/{id}", wrap(http.HandlerFunc(router.getConversationHandler)))
+	r.Handle("POST /conversations/{id}/messages", wrap(http.HandlerFunc(router.sendMessageHandler)))
+	r.Handle("GET /conversations/{id}/messages", wrap(http.HandlerFunc(router.listMessagesHandler)))
+	r.Handle("POST /conversations/{id}/read", wrap(http.HandlerFunc(router.markConversationReadHandler)))
 
-	RegisterPprofRoutes(r)
+	r.Handle("POST /posts/{id}/report", wrap(http.HandlerFunc(router.reportPostHandler)))
+	r.Handle("POST /users/{id}/report", wrap(http.HandlerFunc(router.reportUserHandler)))
+	r.Handle("GET /admin/reports", moderator(http.HandlerFunc(router.listReportsHandler)))
+	r.Handle("GET /admin/reports/{id}", moderator(http.HandlerFunc(router.getReportHandler)))
+	r.Handle("POST /admin/reports/{id}/actions", moderator(http.HandlerFunc(router.moderateReportHandler)))
+	r.Handle("PUT /admin/posts/{id}/sensitive", moderator(http.HandlerFunc(router.markSensitiveHandler)))
+	r.Handle("PUT /admin/users/{id}/role", admin(http.HandlerFunc(router.changeRoleHandler)))
+	r.Handle("PUT /admin/users/{id}/suspension", moderator(http.HandlerFunc(router.suspendHandler)))
+	r.Handle("DELETE /admin/users/{id}/suspension", moderator(http.HandlerFunc(router.liftSuspensionHandler)))
+	r.Handle("PUT /admin/users/{id}/shadow-ban", moderator(http.HandlerFunc(router.shadowBanHandler)))
+	r.Handle("DELETE /admin/users/{id}/shadow-ban", moderator(http.HandlerFunc(router.liftShadowBanHandler)))
+	r.Handle("POST /admin/users/{id}/tokens", admin(http.HandlerFunc(router.issueTokenHandler)))
+	r.Handle("DELETE /admin/users/{id}/tokens", admin(http.HandlerFunc(router.revokeTokensHandler)))
+	r.Handle("GET /admin/spam/accounts", admin(http.HandlerFunc(router.listSpamAccountsHandler)))
+	r.Handle("DELETE /admin/spam/accounts/{id}", admin(http.HandlerFunc(router.clearSpamAccountHandler)))
+	r.Handle("GET /admin/audit", admin(http.HandlerFunc(router.listAuditHandler)))
+	r.Handle("GET /admin/audit/export", admin(http.HandlerFunc(router.exportAuditHandler)))
+
+	RegisterPprofRoutes(r, admin)
 
 	return r
 }
@@ -60,6 +157,41 @@ func methodOnly(method string, h http.Handler) http.Handler {
 	})
 }
 
+// statusFromError подбирает HTTP-статус для ошибки сервиса.
+func statusFromError(err error) int {
+	switch {
+	case errors.Is(err, model.ErrUserNotFound), errors.Is(err, model.ErrPostNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrInvalidToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, model.ErrMediaNotFound), errors.Is(err, model.ErrDraftNotFound),
+		errors.Is(err, model.ErrCollectionNotFound), errors.Is(err, model.ErrPollNotFound),
+		errors.Is(err, model.ErrConversationNotFound), errors.Is(err, model.ErrMessageNotFound),
+		errors.Is(err, model.ErrFollowRequestNotFound), errors.Is(err, model.ErrReportNotFound),
+		errors.Is(err, model.ErrSpamFlagNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrForbidden), errors.Is(err, model.ErrEditWindowClosed),
+		errors.Is(err, model.ErrBlocked), errors.Is(err, model.ErrSuspended),
+		errors.Is(err, model.ErrQuarantined):
+		return http.StatusForbidden
+	case errors.Is(err, model.ErrUsernameTaken), errors.Is(err, model.ErrPinLimitReached),
+		errors.Is(err, model.ErrAlreadyVoted), errors.Is(err, model.ErrPollClosed),
+		errors.Is(err, model.ErrAlreadyReported), errors.Is(err, model.ErrReportClosed),
+		errors.Is(err, model.ErrLastAdmin):
+		return http.StatusConflict
+	case errors.Is(err, model.ErrContentPolicy):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, model.ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, model.ErrMediaTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, model.ErrUnsupportedMedia):
+		return http.StatusUnsupportedMediaType
+	default:
+		return http.StatusBadRequest
+	}
+}
+
 func getValidator(r *http.Request) *validator.Validate {
 	if v, ok := r.Context().Value("validator").(*validator.Validate); ok {
 		return v
@@ -72,6 +204,16 @@ func (r *Router) authHandler(w http.ResponseWriter, req *http.Request) {
 	h.Authenticate(w, req)
 }
 
+func (r *Router) renameHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewUserHandler(r.service, r.logger)
+	h.RenameMe(w, req)
+}
+
+func (r *Router) changeRoleHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewUserHandler(r.service, r.logger)
+	h.ChangeRole(w, req)
+}
+
 func (r *Router) postsHandler(w http.ResponseWriter, req *http.Request) {
 	h := NewPostHandler(r.service, r.logger)
 	switch req.Method {
@@ -84,7 +226,321 @@ func (r *Router) postsHandler(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+func (r *Router) getPostHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPostHandler(r.service, r.logger)
+	h.GetPost(w, req)
+}
+
+func (r *Router) editPostHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPostHandler(r.service, r.logger)
+	h.EditPost(w, req)
+}
+
+func (r *Router) postRevisionsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPostHandler(r.service, r.logger)
+	h.GetPostRevisions(w, req)
+}
+
+func (r *Router) votePollHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPostHandler(r.service, r.logger)
+	h.VotePoll(w, req)
+}
+
+func (r *Router) pinPostHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPostHandler(r.service, r.logger)
+	h.PinPost(w, req)
+}
+
+func (r *Router) unpinPostHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPostHandler(r.service, r.logger)
+	h.UnpinPost(w, req)
+}
+
+func (r *Router) userSectionHandler(w http.ResponseWriter, req *http.Request) {
+	switch req.PathValue("section") {
+	case "posts":
+		h := NewPostHandler(r.service, r.logger)
+		h.GetAuthorPosts(w, req)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
 func (r *Router) LikePostHandler(w http.ResponseWriter, req *http.Request) {
 	h := NewPostHandler(r.service, r.logger)
 	h.LikePost(w, req)
 }
+
+func (r *Router) postLikesHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPostHandler(r.service, r.logger)
+	h.GetPostLikes(w, req)
+}
+
+func (r *Router) postReactionsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewPostHandler(r.service, r.logger)
+	switch req.Method {
+	case http.MethodPost:
+		h.ReactToPost(w, req)
+	case http.MethodDelete:
+		h.RemoveReaction(w, req)
+	case http.MethodGet:
+		h.GetPostReactions(w, req)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *Router) profileHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewProfileHandler(r.service, r.logger)
+	h.GetProfile(w, req)
+}
+
+func (r *Router) profileByNameHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewProfileHandler(r.service, r.logger)
+	h.GetProfileByName(w, req)
+}
+
+func (r *Router) updateProfileHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewProfileHandler(r.service, r.logger)
+	h.UpdateMyProfile(w, req)
+}
+
+func (r *Router) followHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewProfileHandler(r.service, r.logger)
+	h.Follow(w, req)
+}
+
+func (r *Router) unfollowHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewProfileHandler(r.service, r.logger)
+	h.Unfollow(w, req)
+}
+
+func (r *Router) uploadMediaHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMediaHandler(r.service, r.logger, r.maxUploadSize)
+	h.Upload(w, req)
+}
+
+func (r *Router) getMediaHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewMediaHandler(r.service, r.logger, r.maxUploadSize)
+	h.Get(w, req)
+}
+
+func (r *Router) createDraftHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewDraftHandler(r.service, r.logger)
+	h.Create(w, req)
+}
+
+func (r *Router) listDraftsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewDraftHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) getDraftHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewDraftHandler(r.service, r.logger)
+	h.Get(w, req)
+}
+
+func (r *Router) updateDraftHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewDraftHandler(r.service, r.logger)
+	h.Update(w, req)
+}
+
+func (r *Router) deleteDraftHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewDraftHandler(r.service, r.logger)
+	h.Delete(w, req)
+}
+
+func (r *Router) publishDraftHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewDraftHandler(r.service, r.logger)
+	h.Publish(w, req)
+}
+
+func (r *Router) addBookmarkHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewBookmarkHandler(r.service, r.logger)
+	h.Add(w, req)
+}
+
+func (r *Router) removeBookmarkHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewBookmarkHandler(r.service, r.logger)
+	h.Remove(w, req)
+}
+
+func (r *Router) listBookmarksHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewBookmarkHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) createCollectionHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewBookmarkHandler(r.service, r.logger)
+	h.CreateCollection(w, req)
+}
+
+func (r *Router) listCollectionsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewBookmarkHandler(r.service, r.logger)
+	h.ListCollections(w, req)
+}
+
+func (r *Router) deleteCollectionHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewBookmarkHandler(r.service, r.logger)
+	h.DeleteCollection(w, req)
+}
+
+func (r *Router) createConversationHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewConversationHandler(r.service, r.logger)
+	h.Create(w, req)
+}
+
+func (r *Router) listConversationsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewConversationHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) unreadMessagesHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewConversationHandler(r.service, r.logger)
+	h.Unread(w, req)
+}
+
+func (r *Router) getConversationHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewConversationHandler(r.service, r.logger)
+	h.Get(w, req)
+}
+
+func (r *Router) sendMessageHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewConversationHandler(r.service, r.logger)
+	h.SendMessage(w, req)
+}
+
+func (r *Router) listMessagesHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewConversationHandler(r.service, r.logger)
+	h.ListMessages(w, req)
+}
+
+func (r *Router) markConversationReadHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewConversationHandler(r.service, r.logger)
+	h.MarkRead(w, req)
+}
+
+func (r *Router) blockHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewRelationHandler(r.service, r.logger)
+	h.Block(w, req)
+}
+
+func (r *Router) unblockHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewRelationHandler(r.service, r.logger)
+	h.Unblock(w, req)
+}
+
+func (r *Router) muteHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewRelationHandler(r.service, r.logger)
+	h.Mute(w, req)
+}
+
+func (r *Router) unmuteHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewRelationHandler(r.service, r.logger)
+	h.Unmute(w, req)
+}
+
+func (r *Router) listBlockedHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewRelationHandler(r.service, r.logger)
+	h.ListBlocked(w, req)
+}
+
+func (r *Router) listMutedHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewRelationHandler(r.service, r.logger)
+	h.ListMuted(w, req)
+}
+
+func (r *Router) listFollowRequestsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewProfileHandler(r.service, r.logger)
+	h.ListFollowRequests(w, req)
+}
+
+func (r *Router) approveFollowRequestHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewProfileHandler(r.service, r.logger)
+	h.ApproveFollowRequest(w, req)
+}
+
+func (r *Router) rejectFollowRequestHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewProfileHandler(r.service, r.logger)
+	h.RejectFollowRequest(w, req)
+}
+
+func (r *Router) reportPostHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewModerationHandler(r.service, r.logger)
+	h.ReportPost(w, req)
+}
+
+func (r *Router) reportUserHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewModerationHandler(r.service, r.logger)
+	h.ReportUser(w, req)
+}
+
+func (r *Router) listReportsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewModerationHandler(r.service, r.logger)
+	h.ListReports(w, req)
+}
+
+func (r *Router) getReportHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewModerationHandler(r.service, r.logger)
+	h.GetReport(w, req)
+}
+
+func (r *Router) moderateReportHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewModerationHandler(r.service, r.logger)
+	h.Moderate(w, req)
+}
+
+func (r *Router) markSensitiveHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewModerationHandler(r.service, r.logger)
+	h.MarkSensitive(w, req)
+}
+
+func (r *Router) issueTokenHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewAccessTokenHandler(r.service, r.logger)
+	h.Issue(w, req)
+}
+
+func (r *Router) revokeTokensHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewAccessTokenHandler(r.service, r.logger)
+	h.Revoke(w, req)
+}
+
+func (r *Router) suspendHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewModerationHandler(r.service, r.logger)
+	h.Suspend(w, req)
+}
+
+func (r *Router) liftSuspensionHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewModerationHandler(r.service, r.logger)
+	h.LiftSuspension(w, req)
+}
+
+func (r *Router) shadowBanHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewModerationHandler(r.service, r.logger)
+	h.ShadowBan(w, req)
+}
+
+func (r *Router) liftShadowBanHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewModerationHandler(r.service, r.logger)
+	h.LiftShadowBan(w, req)
+}
+
+func (r *Router) listSpamAccountsHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewSpamHandler(r.service, r.logger)
+	h.ListAccounts(w, req)
+}
+
+func (r *Router) clearSpamAccountHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewSpamHandler(r.service, r.logger)
+	h.ClearAccount(w, req)
+}
+
+func (r *Router) listAuditHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewAuditHandler(r.service, r.logger)
+	h.List(w, req)
+}
+
+func (r *Router) exportAuditHandler(w http.ResponseWriter, req *http.Request) {
+	h := NewAuditHandler(r.service, r.logger)
+	h.Export(w, req)
+}
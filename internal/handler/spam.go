@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/converter"
+	"micro-blog/internal/handler/pkg/response"
+	"micro-blog/internal/logger"
+	"micro-blog/internal/middleware"
+	"micro-blog/internal/model"
+	"micro-blog/pkg/pkglogger"
+)
+
+type SpamService interface {
+	ListFlaggedAccounts(
+		ctx context.Context,
+		actorID uuid.UUID,
+		quarantinedOnly bool,
+		cursor int64,
+		limit int,
+	) (*model.FlaggedAccountPage, error)
+	ClearSpamFlag(ctx context.Context, actorID, userID uuid.UUID) error
+}
+
+// SpamHandler обслуживает просмотр аккаунтов с подозрениями на спам
+// /admin/spam/accounts.
+type SpamHandler struct {
+	Service SpamService
+	logger  logger.Logger
+}
+
+func NewSpamHandler(service SpamService, logger logger.Logger) *SpamHandler {
+	return &SpamHandler{
+		Service: service,
+		logger:  logger,
+	}
+}
+
+// ListAccounts возвращает аккаунты с подозрениями; quarantined=true
+// оставляет только аккаунты в карантине.
+func (h *SpamHandler) ListAccounts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	cursor, limit, err := parsePage(r)
+	if err != nil {
+		response.WriteError(w, ErrPageParams, http.StatusBadRequest)
+		h.logger.Info(ErrPageParams, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	var quarantinedOnly bool
+	if raw := r.URL.Query().Get("quarantined"); raw != "" {
+		if quarantinedOnly, err = strconv.ParseBool(raw); err != nil {
+			response.WriteError(w, ErrFilterParams, http.StatusBadRequest)
+			h.logger.Info(ErrFilterParams, slog.String(pkglogger.ErrorKey, err.Error()))
+			return
+		}
+	}
+
+	page, err := h.Service.ListFlaggedAccounts(r.Context(), userID, quarantinedOnly, cursor, limit)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to list flagged accounts", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "successful list flagged accounts")
+	response.SuccessJSON(w, converter.ToSpamAccountsPageRespFromModel(page), http.StatusOK)
+}
+
+// ClearAccount снимает с аккаунта подозрения и карантин.
+func (h *SpamHandler) ClearAccount(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	if err = h.Service.ClearSpamFlag(r.Context(), actorID, userID); err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to clear spam flag", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "spam flag successful cleared")
+	response.SuccessCode(w, http.StatusOK)
+}
+
+func (h *SpamHandler) user(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/converter"
+	"micro-blog/internal/handler/pkg/response"
+	"micro-blog/internal/logger"
+	"micro-blog/internal/middleware"
+	"micro-blog/pkg/pkglogger"
+)
+
+type AccessTokenService interface {
+	IssueAccessToken(ctx context.Context, actorID, userID uuid.UUID) (string, error)
+	RevokeAccessTokens(ctx context.Context, actorID, userID uuid.UUID) error
+	ResolveAccessToken(ctx context.Context, token string) (uuid.UUID, error)
+}
+
+// AccessTokenHandler выдает и отзывает ключи доступа сотрудников
+// /admin/users/{id}/tokens.
+type AccessTokenHandler struct {
+	Service AccessTokenService
+	logger  logger.Logger
+}
+
+func NewAccessTokenHandler(service AccessTokenService, logger logger.Logger) *AccessTokenHandler {
+	return &AccessTokenHandler{
+		Service: service,
+		logger:  logger,
+	}
+}
+
+// Issue выдает новый ключ; ключ показывается только в этом ответе.
+func (h *AccessTokenHandler) Issue(w http.ResponseWriter, r *http.Request) {
+	actorID, userID, ok := h.pair(w, r)
+	if !ok {
+		return
+	}
+
+	token, err := h.Service.IssueAccessToken(r.Context(), actorID, userID)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to issue access token", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "access token successful issued")
+	response.SuccessJSON(w, converter.ToAccessTokenResp(token), http.StatusCreated)
+}
+
+// Revoke отзывает все ключи пользователя.
+func (h *AccessTokenHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	actorID, userID, ok := h.pair(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.RevokeAccessTokens(r.Context(), actorID, userID); err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to revoke access tokens", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "access tokens successful revoked")
+	response.SuccessCode(w, http.StatusOK)
+}
+
+func (h *AccessTokenHandler) pair(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	actorID := middleware.UserIDFromContext(r.Context())
+	if actorID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return actorID, userID, true
+}
@@ -6,16 +6,22 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/google/uuid"
 	"micro-blog/internal/converter"
 	"micro-blog/internal/handler/dto"
 	"micro-blog/internal/handler/pkg/response"
 	"micro-blog/internal/logger"
+	"micro-blog/internal/middleware"
 	"micro-blog/internal/model"
 	"micro-blog/pkg/pkglogger"
 )
 
 type UserService interface {
-	Authenticate(ctx context.Context, user *model.User) (*model.User, error)
+	Authenticate(ctx context.Context, user *model.User, callerID uuid.UUID) (*model.User, error)
+	IssueUserToken(ctx context.Context, userID uuid.UUID) (string, error)
+	RenameUser(ctx context.Context, userID uuid.UUID, newName string) (*model.User, error)
+	UserRole(ctx context.Context, userID uuid.UUID) (model.Role, error)
+	ChangeRole(ctx context.Context, actorID, userID uuid.UUID, role model.Role) (*model.User, error)
 }
 
 type UserHandler struct {
@@ -48,15 +54,98 @@ func (h *UserHandler) Authenticate(w http.ResponseWriter, r *http.Request) {
 
 	userModel := converter.ToUserModelFromReq(&req)
 
-	user, err := h.Service.Authenticate(r.Context(), userModel)
+	callerID := middleware.UserIDFromContext(r.Context())
+	user, err := h.Service.Authenticate(r.Context(), userModel, callerID)
 	if err != nil {
-		response.WriteError(w, err.Error(), http.StatusBadRequest)
+		response.WriteError(w, err.Error(), statusFromError(err))
 		h.logger.Info("error to register user", slog.String(pkglogger.ErrorKey, err.Error()))
 		return
 	}
 
-	resp := converter.ToUserRespFromModel(user)
+	token, err := h.Service.IssueUserToken(r.Context(), user.ID)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to issue access token", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	resp := converter.ToUserRespFromModel(user, token)
 	h.logger.InfoContext(r.Context(), "user successful register")
 
 	response.SuccessJSON(w, resp, http.StatusCreated)
 }
+
+func (h *UserHandler) RenameMe(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return
+	}
+
+	var req dto.RenameUserReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	v := getValidator(r)
+	if err := v.Struct(req); err != nil {
+		response.WriteError(w, ErrRequestFields, http.StatusBadRequest)
+		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	user, err := h.Service.RenameUser(r.Context(), userID, req.Name)
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to rename user", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "user successful renamed")
+	response.SuccessJSON(w, converter.ToRenameUserRespFromModel(user), http.StatusOK)
+}
+
+func (h *UserHandler) ChangeRole(w http.ResponseWriter, r *http.Request) {
+	actorID := middleware.UserIDFromContext(r.Context())
+	if actorID == uuid.Nil {
+		response.WriteError(w, ErrUnauthorized, http.StatusUnauthorized)
+		h.logger.Info(ErrUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.WriteError(w, ErrUUIDParsing, http.StatusBadRequest)
+		h.logger.Info(ErrUUIDParsing, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	var req dto.ChangeRoleReq
+
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, ErrBodyRequest, http.StatusBadRequest)
+		h.logger.Info(ErrBodyRequest, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	v := getValidator(r)
+	if err = v.Struct(req); err != nil {
+		response.WriteError(w, ErrRequestFields, http.StatusBadRequest)
+		h.logger.Info(ErrRequestFields, slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	user, err := h.Service.ChangeRole(r.Context(), actorID, userID, model.Role(req.Role))
+	if err != nil {
+		response.WriteError(w, err.Error(), statusFromError(err))
+		h.logger.Info("error to change role", slog.String(pkglogger.ErrorKey, err.Error()))
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "role successful changed")
+	response.SuccessJSON(w, converter.ToUserRoleRespFromModel(user), http.StatusOK)
+}
@@ -0,0 +1,295 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+const (
+	// MaxPixels защищает от "бомб" - маленьких файлов с огромным разрешением.
+	// Для GIF ограничивается сумма по всем кадрам.
+	MaxPixels = 40_000_000
+	// MaxFrames ограничивает число кадров GIF независимо от их размера.
+	MaxFrames = 500
+
+	jpegQuality = 90
+)
+
+var ErrTooManyPixels = errors.New("image resolution is too large")
+
+// Sanitize декодирует изображение и кодирует его заново. Метаданные
+// (EXIF, текстовые чанки PNG, комментарии GIF) при этом не переносятся;
+// ориентация из EXIF JPEG предварительно применяется к пикселям.
+// Возвращает новые байты и первый кадр для построения превью.
+func Sanitize(data []byte, contentType string) ([]byte, image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	frames := 1
+	if contentType == "image/gif" {
+		frames = gifFrames(data)
+	}
+	if frames > MaxFrames || frames*cfg.Width*cfg.Height > MaxPixels {
+		return nil, nil, ErrTooManyPixels
+	}
+
+	var buf bytes.Buffer
+	switch contentType {
+	case "image/jpeg":
+		img, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, nil, err
+		}
+		img = orient(img, jpegOrientation(data))
+		if err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, nil, err
+		}
+		return buf.Bytes(), img, nil
+
+	case "image/png":
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = png.Encode(&buf, img); err != nil {
+			return nil, nil, err
+		}
+		return buf.Bytes(), img, nil
+
+	case "image/gif":
+		anim, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = gif.EncodeAll(&buf, anim); err != nil {
+			return nil, nil, err
+		}
+		return buf.Bytes(), anim.Image[0], nil
+	}
+
+	return nil, nil, fmt.Errorf("unsupported image type %q", contentType)
+}
+
+// Thumbnail уменьшает изображение так, чтобы большая сторона не превышала
+// maxSide, и кодирует его в JPEG (для JPEG) или PNG (для остальных форматов).
+// Возвращает байты превью и их content type.
+func Thumbnail(img image.Image, maxSide int, contentType string) ([]byte, string, error) {
+	small := resize(img, maxSide)
+
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, small, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+
+	if err := png.Encode(&buf, small); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/png", nil
+}
+
+// resize уменьшает изображение усреднением пикселей исходной области.
+// Увеличение не выполняется.
+func resize(src image.Image, maxSide int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	tw, th := w, h
+	if w > maxSide || h > maxSide {
+		if w >= h {
+			tw, th = maxSide, max(1, h*maxSide/w)
+		} else {
+			tw, th = max(1, w*maxSide/h), maxSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		sy0, sy1 := b.Min.Y+y*h/th, b.Min.Y+(y+1)*h/th
+		for x := 0; x < tw; x++ {
+			sx0, sx1 := b.Min.X+x*w/tw, b.Min.X+(x+1)*w/tw
+
+			var r, g, bl, a, n uint64
+			for sy := sy0; sy < max(sy1, sy0+1); sy++ {
+				for sx := sx0; sx < max(sx1, sx0+1); sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
+
+// gifFrames считает кадры GIF по структуре блоков, не декодируя их.
+// Поврежденный файл отвергнет декодер, поэтому при обрыве данных
+// возвращается число кадров, найденных до него.
+func gifFrames(data []byte) int {
+	const headerLen = 13
+	if len(data) < headerLen {
+		return 0
+	}
+
+	pos := headerLen
+	if flags := data[10]; flags&0x80 != 0 {
+		pos += 3 << (flags&0x07 + 1)
+	}
+
+	frames := 0
+	for pos < len(data) {
+		switch data[pos] {
+		case 0x21: // расширение: метка и подблоки
+			pos = skipSubBlocks(data, pos+2)
+		case 0x2C: // дескриптор кадра
+			if pos+10 > len(data) {
+				return frames
+			}
+			flags := data[pos+9]
+			pos += 10
+			if flags&0x80 != 0 {
+				pos += 3 << (flags&0x07 + 1)
+			}
+			pos = skipSubBlocks(data, pos+1)
+			frames++
+		default: // завершающий блок или мусор
+			return frames
+		}
+	}
+	return frames
+}
+
+func skipSubBlocks(data []byte, pos int) int {
+	for pos < len(data) {
+		n := int(data[pos])
+		pos++
+		if n == 0 {
+			break
+		}
+		pos += n
+	}
+	return pos
+}
+
+// jpegOrientation находит в сегменте APP1 тег EXIF Orientation (0x0112).
+// Без EXIF или при ошибке разбора возвращает 1 - исходную ориентацию.
+func jpegOrientation(data []byte) int {
+	const (
+		orientationTag = 0x0112
+		sos            = 0xDA
+		app1           = 0xE1
+	)
+
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	pos := 2
+	for pos+4 <= len(data) && data[pos] == 0xFF {
+		marker := data[pos+1]
+		if marker == sos {
+			return 1
+		}
+		size := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if size < 2 || pos+2+size > len(data) {
+			return 1
+		}
+		segment := data[pos+4 : pos+2+size]
+		pos += 2 + size
+
+		if marker != app1 || !bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			continue
+		}
+
+		tiff := segment[6:]
+		if len(tiff) < 8 {
+			return 1
+		}
+		var order binary.ByteOrder
+		switch string(tiff[:2]) {
+		case "II":
+			order = binary.LittleEndian
+		case "MM":
+			order = binary.BigEndian
+		default:
+			return 1
+		}
+
+		ifd := int(order.Uint32(tiff[4:]))
+		if ifd+2 > len(tiff) {
+			return 1
+		}
+		count := int(order.Uint16(tiff[ifd:]))
+		for i := 0; i < count; i++ {
+			entry := ifd + 2 + i*12
+			if entry+12 > len(tiff) {
+				return 1
+			}
+			if order.Uint16(tiff[entry:]) == orientationTag {
+				if o := int(order.Uint16(tiff[entry+8:])); o >= 1 && o <= 8 {
+					return o
+				}
+				return 1
+			}
+		}
+		return 1
+	}
+	return 1
+}
+
+// orient поворачивает и отражает изображение по значению EXIF Orientation,
+// чтобы после удаления метаданных оно отображалось так же, как исходное.
+func orient(src image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // отражение по горизонтали
+				sx, sy = w-1-x, y
+			case 3: // поворот на 180°
+				sx, sy = w-1-x, h-1-y
+			case 4: // отражение по вертикали
+				sx, sy = x, h-1-y
+			case 5: // транспонирование
+				sx, sy = y, x
+			case 6: // поворот на 90° по часовой
+				sx, sy = y, h-1-x
+			case 7: // транспонирование относительно побочной диагонали
+				sx, sy = w-1-y, h-1-x
+			case 8: // поворот на 90° против часовой
+				sx, sy = w-1-y, x
+			}
+			dst.Set(x, y, src.At(b.Min.X+sx, b.Min.Y+sy))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"micro-blog/pkg/pkglogger"
+)
+
+// ClientIP кладет адрес клиента в контекст запроса. Берется адрес
+// соединения: X-Forwarded-For клиент может подделать, а доверенного
+// прокси перед сервисом нет.
+func ClientIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+		if ip == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), pkglogger.ClientIPKey, ip)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/handler/pkg/response"
+	"micro-blog/pkg/pkglogger"
+)
+
+// UserIDHeader - заголовок, в котором клиент передает ID текущего пользователя.
+const UserIDHeader = "X-User-ID"
+
+// AuthorizationHeader - заголовок с ключом доступа пользователя в виде
+// "Bearer <ключ>".
+const AuthorizationHeader = "Authorization"
+
+// TokenResolver возвращает владельца ключа доступа.
+type TokenResolver interface {
+	ResolveAccessToken(ctx context.Context, token string) (uuid.UUID, error)
+}
+
+// Identity кладет в контекст запроса ID пользователя, предъявившего ключ
+// доступа. ID пользователей публичны, поэтому один заголовок X-User-ID
+// личность не подтверждает и отклоняется; вместе с ключом он должен
+// совпадать с владельцем ключа. Запросы без заголовков считаются
+// анонимными.
+func Identity(tokens TokenResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw := r.Header.Get(UserIDHeader)
+			authorization := r.Header.Get(AuthorizationHeader)
+			if raw == "" && authorization == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if authorization == "" {
+				response.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			var id uuid.UUID
+			if raw != "" {
+				var err error
+				if id, err = uuid.Parse(raw); err != nil {
+					response.WriteError(w, "Invalid UUID", http.StatusBadRequest)
+					return
+				}
+			}
+
+			token, ok := strings.CutPrefix(authorization, "Bearer ")
+			if !ok {
+				response.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			ctx := r.Context()
+			owner, err := tokens.ResolveAccessToken(ctx, token)
+			if err != nil || raw != "" && owner != id {
+				response.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			ctx = context.WithValue(ctx, pkglogger.UserIDKey, owner.String())
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// UserIDFromContext возвращает ID текущего пользователя или uuid.Nil для анонимного запроса.
+func UserIDFromContext(ctx context.Context) uuid.UUID {
+	raw, ok := ctx.Value(pkglogger.UserIDKey).(string)
+	if !ok {
+		return uuid.Nil
+	}
+
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil
+	}
+	return id
+}
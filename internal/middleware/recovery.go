@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"runtime/debug"
 
-	"github.com/google/uuid"
 	"micro-blog/internal/handler/pkg/response"
 	"micro-blog/internal/logger"
 )
@@ -14,7 +13,6 @@ func Recovery(baseLogger logger.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			reqLogger := baseLogger.With(
-				slog.String("request_id", uuid.NewString()),
 				slog.String("method", r.Method),
 				slog.String("path", r.URL.Path),
 			)
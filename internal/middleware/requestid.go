@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"micro-blog/pkg/pkglogger"
+)
+
+// RequestIDHeader - заголовок ответа с ID запроса, по которому запрос
+// можно найти в логах и журнале аудита.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID присваивает запросу новый ID и кладет его в контекст и в
+// заголовок ответа. ID от клиента не принимается, чтобы его нельзя
+// было подделать в журнале аудита.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := uuid.NewString()
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx := context.WithValue(r.Context(), pkglogger.RequestIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/handler/pkg/response"
+	"micro-blog/internal/model"
+)
+
+// RoleLookup возвращает роль пользователя по его ID.
+type RoleLookup interface {
+	UserRole(ctx context.Context, userID uuid.UUID) (model.Role, error)
+}
+
+// RequireRole пропускает запрос, только если роль текущего пользователя
+// не ниже min. Анонимный или неизвестный пользователь получает 401,
+// пользователь с недостаточной ролью - 403. Ставится после Identity.
+func RequireRole(roles RoleLookup, min model.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID := UserIDFromContext(r.Context())
+			if userID == uuid.Nil {
+				response.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			role, err := roles.UserRole(r.Context(), userID)
+			if err != nil {
+				response.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			if !role.AtLeast(min) {
+				response.WriteError(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
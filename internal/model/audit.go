@@ -0,0 +1,96 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditAction - тип события журнала аудита.
+type AuditAction string
+
+const (
+	AuditLogin           AuditAction = "login"
+	AuditLoginFailed     AuditAction = "login_failed"
+	AuditUserRegistered  AuditAction = "user_registered"
+	AuditTokenIssued     AuditAction = "token_issued"
+	AuditTokensRevoked   AuditAction = "tokens_revoked"
+	AuditRoleChanged     AuditAction = "role_changed"
+	AuditReportModerated AuditAction = "report_moderated"
+	// AuditModerationReverted - решение по жалобе не удалось применить,
+	// жалоба снова открыта.
+	AuditModerationReverted AuditAction = "moderation_reverted"
+	AuditUserSanctioned     AuditAction = "user_sanctioned"
+	AuditSanctionLifted     AuditAction = "sanction_lifted"
+	AuditSpamFlagCleared    AuditAction = "spam_flag_cleared"
+	AuditPostRemoved        AuditAction = "post_removed"
+	AuditPostFlagged        AuditAction = "post_flagged"
+	AuditDraftDeleted       AuditAction = "draft_deleted"
+	AuditCollectionDeleted  AuditAction = "collection_deleted"
+)
+
+func (a AuditAction) Valid() bool {
+	switch a {
+	case AuditLogin, AuditLoginFailed, AuditUserRegistered, AuditTokenIssued, AuditTokensRevoked,
+		AuditRoleChanged, AuditReportModerated, AuditModerationReverted,
+		AuditUserSanctioned, AuditSanctionLifted, AuditSpamFlagCleared, AuditPostRemoved,
+		AuditPostFlagged, AuditDraftDeleted, AuditCollectionDeleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// AuditTarget - тип объекта, над которым совершено действие.
+type AuditTarget string
+
+const (
+	AuditTargetUser       AuditTarget = "user"
+	AuditTargetPost       AuditTarget = "post"
+	AuditTargetReport     AuditTarget = "report"
+	AuditTargetDraft      AuditTarget = "draft"
+	AuditTargetCollection AuditTarget = "collection"
+)
+
+// AuditEntry - запись журнала аудита. ActorID нулевой у действий
+// системы и анонимных запросов, например неудачного входа. IP и
+// RequestID берутся из запроса, в котором совершено действие.
+type AuditEntry struct {
+	ID         uuid.UUID
+	Action     AuditAction
+	ActorID    uuid.UUID
+	TargetType AuditTarget
+	TargetID   uuid.UUID
+	IP         string
+	RequestID  string
+	Details    map[string]string
+	CreatedAt  time.Time
+}
+
+// AuditFilter отбирает записи журнала; нулевые поля не ограничивают
+// выборку. Since включительно, Until - нет.
+type AuditFilter struct {
+	Action   AuditAction
+	ActorID  uuid.UUID
+	TargetID uuid.UUID
+	Since    time.Time
+	Until    time.Time
+}
+
+func (f AuditFilter) Match(entry *AuditEntry) bool {
+	switch {
+	case f.Action != "" && entry.Action != f.Action,
+		f.ActorID != uuid.Nil && entry.ActorID != f.ActorID,
+		f.TargetID != uuid.Nil && entry.TargetID != f.TargetID,
+		!f.Since.IsZero() && entry.CreatedAt.Before(f.Since),
+		!f.Until.IsZero() && !entry.CreatedAt.Before(f.Until):
+		return false
+	default:
+		return true
+	}
+}
+
+type AuditPage struct {
+	Entries    []*AuditEntry
+	NextCursor int64
+}
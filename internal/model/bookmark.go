@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bookmark - пост, сохраненный пользователем. Закладки видны только
+// их владельцу. CollectionID равен uuid.Nil у закладок без коллекции.
+type Bookmark struct {
+	UserID       uuid.UUID
+	PostID       uuid.UUID
+	CollectionID uuid.UUID
+	CreatedAt    time.Time
+}
+
+// BookmarkCollection - именованная коллекция закладок пользователя.
+type BookmarkCollection struct {
+	ID        uuid.UUID
+	OwnerID   uuid.UUID
+	Name      string
+	CreatedAt time.Time
+}
+
+// BookmarkedPost - закладка вместе с постом. Post равен nil, если пост
+// удален или больше недоступен владельцу закладки.
+type BookmarkedPost struct {
+	Bookmark *Bookmark
+	Post     *Post
+}
+
+type BookmarkPage struct {
+	Bookmarks  []*BookmarkedPost
+	NextCursor int64
+}
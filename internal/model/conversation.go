@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxConversationMembers ограничивает размер групповой переписки вместе
+// с ее создателем.
+const MaxConversationMembers = 8
+
+// Conversation - личная переписка двух или нескольких пользователей.
+// LastMessage, UnreadCount и LastReadMessageID заполняются для
+// конкретного участника, который запрашивает переписку.
+type Conversation struct {
+	ID                uuid.UUID
+	MemberIDs         []uuid.UUID
+	CreatedAt         time.Time
+	LastMessage       *Message
+	UnreadCount       int
+	LastReadMessageID uuid.UUID
+}
+
+// IsDirect сообщает, что переписка ведется один на один.
+func (c *Conversation) IsDirect() bool {
+	return len(c.MemberIDs) == 2
+}
+
+type Message struct {
+	ID             uuid.UUID
+	ConversationID uuid.UUID
+	SenderID       uuid.UUID
+	Text           string
+	CreatedAt      time.Time
+}
+
+// ConversationCursor - позиция в списке переписок. Порядок переписок
+// меняется с каждым сообщением, поэтому курсор фиксирует снимок Snapshot:
+// переписки упорядочиваются по последнему событию не позже снимка, и
+// страница начинается с переписок, чье событие старше Before. Нулевой
+// курсор - первая страница на текущий момент.
+type ConversationCursor struct {
+	Snapshot int64
+	Before   int64
+}
+
+func (c ConversationCursor) IsZero() bool {
+	return c == ConversationCursor{}
+}
+
+type ConversationPage struct {
+	Conversations []*Conversation
+	NextCursor    ConversationCursor
+}
+
+type MessagePage struct {
+	Messages   []*Message
+	NextCursor int64
+}
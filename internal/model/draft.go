@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Draft - неопубликованный пост. Черновик виден только автору.
+// Если задан PublishAt, планировщик опубликует его в это время.
+// Остальные поля переносятся в пост при публикации как есть.
+type Draft struct {
+	ID                     uuid.UUID
+	AuthorID               uuid.UUID
+	Text                   string
+	AttachmentIDs          []uuid.UUID
+	Visibility             Visibility
+	TTL                    time.Duration
+	Poll                   *Poll
+	ContentWarning         string
+	Sensitive              bool
+	SensitiveAttachmentIDs []uuid.UUID
+	PublishAt              time.Time
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+func (d *Draft) Scheduled() bool {
+	return !d.PublishAt.IsZero()
+}
@@ -0,0 +1,23 @@
+package model
+
+import "github.com/google/uuid"
+
+type EntityType string
+
+const (
+	EntityURL     EntityType = "url"
+	EntityMention EntityType = "mention"
+	EntityHashtag EntityType = "hashtag"
+)
+
+// Entity - размеченный фрагмент текста поста. Start и End - смещения
+// в кодовых точках Unicode, End не включается.
+type Entity struct {
+	Type  EntityType
+	Start int
+	End   int
+	// Text - ссылка, имя пользователя без @ или тег без #.
+	Text string
+	// UserID - упомянутый пользователь, uuid.Nil если такого нет.
+	UserID uuid.UUID
+}
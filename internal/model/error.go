@@ -5,3 +5,54 @@ import "errors"
 var ErrUserNotFound = errors.New("user not found")
 var ErrPostNotFound = errors.New("post not found")
 var ErrLikeQueue = errors.New("likeQueue not attached")
+var ErrInvalidReaction = errors.New("invalid reaction type")
+var ErrSelfFollow = errors.New("cannot follow yourself")
+var ErrInvalidUsername = errors.New("invalid username")
+var ErrReservedUsername = errors.New("username is reserved")
+var ErrUsernameTaken = errors.New("username is already taken")
+var ErrMediaNotFound = errors.New("media not found")
+var ErrUnsupportedMedia = errors.New("unsupported media type")
+var ErrMediaTooLarge = errors.New("media is too large")
+var ErrInvalidAttachment = errors.New("invalid attachment")
+var ErrEmptyPost = errors.New("post text is empty")
+var ErrPostTooLong = errors.New("post text is too long")
+var ErrContentWarningTooLong = errors.New("content warning is too long")
+var ErrForbidden = errors.New("forbidden")
+var ErrEditWindowClosed = errors.New("post can no longer be edited")
+var ErrInvalidVisibility = errors.New("invalid post visibility")
+var ErrDraftNotFound = errors.New("draft not found")
+var ErrScheduleInPast = errors.New("publication time must be in the future")
+var ErrInvalidTTL = errors.New("invalid post ttl")
+var ErrPinLimitReached = errors.New("pinned posts limit reached")
+var ErrInvalidRepost = errors.New("post cannot be reposted")
+var ErrCollectionNotFound = errors.New("bookmark collection not found")
+var ErrInvalidCollectionName = errors.New("invalid bookmark collection name")
+var ErrInvalidPoll = errors.New("invalid poll")
+var ErrPollNotFound = errors.New("poll not found")
+var ErrPollClosed = errors.New("poll is closed")
+var ErrAlreadyVoted = errors.New("already voted in this poll")
+var ErrInvalidVote = errors.New("invalid poll choices")
+var ErrVoteQueue = errors.New("voteQueue not attached")
+var ErrConversationNotFound = errors.New("conversation not found")
+var ErrInvalidConversation = errors.New("invalid conversation members")
+var ErrMessageNotFound = errors.New("message not found")
+var ErrEmptyMessage = errors.New("message text is empty")
+var ErrMessageTooLong = errors.New("message text is too long")
+var ErrSelfRelation = errors.New("cannot block or mute yourself")
+var ErrBlocked = errors.New("user is blocked")
+var ErrFollowRequestNotFound = errors.New("follow request not found")
+var ErrInvalidReport = errors.New("invalid report")
+var ErrReportNotFound = errors.New("report not found")
+var ErrAlreadyReported = errors.New("already reported")
+var ErrReportClosed = errors.New("report is already closed")
+var ErrInvalidModerationAction = errors.New("invalid moderation action")
+var ErrInvalidRole = errors.New("invalid role")
+var ErrLastAdmin = errors.New("cannot demote the last admin")
+var ErrInvalidToken = errors.New("invalid access token")
+var ErrSuspended = errors.New("account is suspended")
+var ErrInvalidSanction = errors.New("invalid sanction")
+var ErrContentPolicy = errors.New("post violates content policy")
+var ErrRateLimited = errors.New("too many requests, slow down")
+var ErrQuarantined = errors.New("account is quarantined")
+var ErrSpamFlagNotFound = errors.New("spam flag not found")
+var ErrInvalidWebsite = errors.New("website must be an http or https url")
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FollowStatus - результат подписки: на закрытый аккаунт подписка
+// становится запросом до одобрения владельцем.
+type FollowStatus string
+
+const (
+	FollowStatusFollowing FollowStatus = "following"
+	FollowStatusRequested FollowStatus = "requested"
+)
+
+// FollowRequest - ожидающий одобрения запрос FollowerID на подписку
+// на закрытый аккаунт FolloweeID.
+type FollowRequest struct {
+	FollowerID uuid.UUID
+	FolloweeID uuid.UUID
+	CreatedAt  time.Time
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MediaKind string
+
+const (
+	MediaImage MediaKind = "image"
+	MediaVideo MediaKind = "video"
+)
+
+type Media struct {
+	ID          uuid.UUID
+	OwnerID     uuid.UUID
+	Kind        MediaKind
+	ContentType string
+	Size        int64
+	// ThumbnailID - превью изображения, uuid.Nil для видео.
+	ThumbnailID uuid.UUID
+	// OriginalID - изображение, для которого построено превью; uuid.Nil
+	// у самого изображения и у видео.
+	OriginalID uuid.UUID
+	CreatedAt  time.Time
+	// Public - файл виден любому зрителю без входа, вычисляется при выдаче.
+	Public bool
+}
+
+type MediaLimits struct {
+	MaxImageSize  int64
+	MaxVideoSize  int64
+	ThumbnailSize int
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PolicyAction - что делать с постом, нарушившим правило контент-политики.
+type PolicyAction string
+
+const (
+	// PolicySensitive публикует пост с пометкой деликатного содержимого.
+	PolicySensitive PolicyAction = "sensitive"
+	// PolicyHold публикует пост только для автора до проверки модератором.
+	PolicyHold PolicyAction = "hold"
+	// PolicyReject отклоняет пост.
+	PolicyReject PolicyAction = "reject"
+)
+
+func (a PolicyAction) Valid() bool {
+	return a == PolicySensitive || a == PolicyHold || a == PolicyReject
+}
+
+func (a PolicyAction) severity() int {
+	switch a {
+	case PolicySensitive:
+		return 1
+	case PolicyHold:
+		return 2
+	case PolicyReject:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// PolicyViolation - срабатывание правила контент-политики. Detail
+// поясняет, что именно нарушено, например найденное слово.
+type PolicyViolation struct {
+	Rule   string
+	Action PolicyAction
+	Detail string
+}
+
+// StrictestAction возвращает самое строгое действие среди нарушений,
+// пустое если нарушений нет.
+func StrictestAction(violations []PolicyViolation) PolicyAction {
+	var strictest PolicyAction
+	for _, violation := range violations {
+		if violation.Action.severity() > strictest.severity() {
+			strictest = violation.Action
+		}
+	}
+	return strictest
+}
+
+// PolicyError возвращается, когда контент-политика отклонила пост.
+// Violations - все сработавшие правила, включая более мягкие.
+type PolicyError struct {
+	Violations []PolicyViolation
+}
+
+func (e *PolicyError) Error() string {
+	rules := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		rules[i] = violation.Rule
+	}
+	return fmt.Sprintf("%s: %s", ErrContentPolicy, strings.Join(rules, ", "))
+}
+
+func (e *PolicyError) Unwrap() error {
+	return ErrContentPolicy
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MinPollOptions = 2
+	MaxPollOptions = 4
+)
+
+// Poll - опрос, прикрепленный к посту. Votes по вариантам и VotersCount
+// скрыты (ResultsHidden), пока зритель не проголосовал или опрос не закрылся.
+type Poll struct {
+	Options     []PollOption
+	Multiple    bool
+	ClosesAt    time.Time
+	VotersCount int
+	// MyChoices - варианты, выбранные зрителем, пусто если он не голосовал.
+	MyChoices     []int
+	ResultsHidden bool
+}
+
+type PollOption struct {
+	Text  string
+	Votes int
+}
+
+func (p *Poll) Closed(now time.Time) bool {
+	return !now.Before(p.ClosesAt)
+}
+
+// PollVote - голос пользователя. Choices - индексы вариантов.
+type PollVote struct {
+	PostID  uuid.UUID
+	UserID  uuid.UUID
+	Choices []int
+	VotedAt time.Time
+}
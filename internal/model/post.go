@@ -1,10 +1,123 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Post struct {
 	ID       uuid.UUID
 	AuthorID uuid.UUID
 	Text     string
-	Likes    []uuid.UUID
+	// ReplyToID - пост, на который это ответ.
+	ReplyToID uuid.UUID
+	// RepostOfID - пост, которым автор поделился. Текст репоста может быть пустым.
+	RepostOfID uuid.UUID
+	// AttachmentIDs - ID медиафайлов в порядке отображения.
+	AttachmentIDs []uuid.UUID
+	Entities      []Entity
+	Poll          *Poll
+	Reactions     map[ReactionType]int
+	MyReaction    ReactionType
+	Visibility    Visibility
+	CreatedAt     time.Time
+	// EditedAt - время последней правки, нулевое у неизмененного поста.
+	EditedAt time.Time
+	// TTL - время жизни, которое автор задает при создании поста.
+	TTL time.Duration
+	// ExpiresAt - когда пост будет удален, нулевое у постоянного поста.
+	ExpiresAt time.Time
+	// PinnedAt - когда автор закрепил пост в профиле, нулевое у незакрепленного.
+	PinnedAt time.Time
+	// Sensitive - пост помечен как деликатный автором, контент-политикой
+	// или модератором.
+	Sensitive bool
+	// ContentWarning - предупреждение, за которым скрыт текст поста,
+	// например о спойлерах.
+	ContentWarning string
+	// SensitiveAttachmentIDs - вложения, которые скрываются до явного
+	// раскрытия; подмножество AttachmentIDs.
+	SensitiveAttachmentIDs []uuid.UUID
+	// Collapsed - пост с деликатным содержимым свернут для зрителя, который
+	// не включил автоматическое раскрытие. Вычисляется для каждого зрителя.
+	Collapsed bool
+	// Held - пост задержан контент-политикой и до проверки модератором
+	// виден только автору.
+	Held bool
+}
+
+func (p *Post) Expired(now time.Time) bool {
+	return !p.ExpiresAt.IsZero() && !p.ExpiresAt.After(now)
+}
+
+// HasSensitiveContent сообщает, скрывает ли пост что-либо за предупреждением.
+func (p *Post) HasSensitiveContent() bool {
+	return p.Sensitive || p.ContentWarning != "" || len(p.SensitiveAttachmentIDs) > 0
+}
+
+func (p *Post) IsReply() bool {
+	return p.ReplyToID != uuid.Nil
+}
+
+func (p *Post) IsRepost() bool {
+	return p.RepostOfID != uuid.Nil
+}
+
+// AuthorFeedFilter отбирает посты в ленте автора.
+type AuthorFeedFilter struct {
+	ExcludeReplies bool
+	ExcludeReposts bool
+	OnlyMedia      bool
+}
+
+func (f AuthorFeedFilter) Match(post *Post) bool {
+	switch {
+	case f.ExcludeReplies && post.IsReply():
+		return false
+	case f.ExcludeReposts && post.IsRepost():
+		return false
+	case f.OnlyMedia && len(post.AttachmentIDs) == 0:
+		return false
+	default:
+		return true
+	}
+}
+
+// PostPage - страница постов. NextCursor равен 0 на последней странице.
+type PostPage struct {
+	Posts      []*Post
+	NextCursor int64
+}
+
+// PostEdit - новая версия текста поста. Sensitive и Held только
+// выставляют пометки: правка не снимает их с поста.
+type PostEdit struct {
+	PostID    uuid.UUID
+	Text      string
+	Entities  []Entity
+	EditedAt  time.Time
+	Sensitive bool
+	Held      bool
+}
+
+// PostRevision - неизменяемая версия текста поста. Первая ревизия -
+// текст при создании, каждая правка добавляет новую.
+type PostRevision struct {
+	PostID    uuid.UUID
+	Number    int
+	Text      string
+	Entities  []Entity
+	CreatedAt time.Time
+}
+
+type PostLimits struct {
+	// MaxTextLength - максимальная длина текста в графемах.
+	MaxTextLength int
+	// EditWindow - сколько времени после публикации пост можно править.
+	EditWindow time.Duration
+	// MaxTTL - наибольшее время жизни временного поста.
+	MaxTTL time.Duration
+	// MaxPinned - сколько постов автор может закрепить в профиле.
+	MaxPinned int
 }
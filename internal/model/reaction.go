@@ -0,0 +1,50 @@
+package model
+
+import (
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+type ReactionType string
+
+const (
+	// ReactionNone в реакции означает снятие реакции пользователя с поста.
+	ReactionNone  ReactionType = ""
+	ReactionLike  ReactionType = "like"
+	ReactionLove  ReactionType = "love"
+	ReactionLaugh ReactionType = "laugh"
+	ReactionSad   ReactionType = "sad"
+	ReactionAngry ReactionType = "angry"
+)
+
+// customEmojiRe - пользовательские эмодзи задаются шорткодом вида :party_parrot:.
+var customEmojiRe = regexp.MustCompile(`^:[a-z0-9_]{1,32}:$`)
+
+func (t ReactionType) Valid() bool {
+	switch t {
+	case ReactionLike, ReactionLove, ReactionLaugh, ReactionSad, ReactionAngry:
+		return true
+	}
+	return customEmojiRe.MatchString(string(t))
+}
+
+// Reaction - реакция пользователя на пост. На один пост у пользователя
+// может быть только одна реакция, новая заменяет старую.
+type Reaction struct {
+	UserID uuid.UUID
+	PostID uuid.UUID
+	Type   ReactionType
+}
+
+type UserReaction struct {
+	User *User
+	Type ReactionType
+}
+
+// ReactionPage - страница реакций на пост.
+// NextCursor равен нулю, если страниц больше нет.
+type ReactionPage struct {
+	Reactions  []*UserReaction
+	NextCursor int64
+}
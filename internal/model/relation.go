@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Relation - блокировка или заглушение пользователя TargetID
+// пользователем OwnerID.
+//
+// Блокировка взаимна по действию: ни один из двоих не видит постов
+// другого, не может подписаться, ответить, упомянуть или написать ему.
+// Заглушение одностороннее и молча убирает посты TargetID из лент
+// OwnerID.
+type Relation struct {
+	OwnerID   uuid.UUID
+	TargetID  uuid.UUID
+	CreatedAt time.Time
+}
+
+type RelatedUser struct {
+	User      *User
+	CreatedAt time.Time
+}
+
+// RelationPage - страница списка заблокированных или заглушенных.
+// NextCursor равен нулю, если страниц больше нет.
+type RelationPage struct {
+	Users      []*RelatedUser
+	NextCursor int64
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReportTarget string
+
+const (
+	ReportTargetPost ReportTarget = "post"
+	ReportTargetUser ReportTarget = "user"
+)
+
+// ReportReason - категория жалобы.
+type ReportReason string
+
+const (
+	ReportReasonSpam           ReportReason = "spam"
+	ReportReasonHarassment     ReportReason = "harassment"
+	ReportReasonHate           ReportReason = "hate"
+	ReportReasonViolence       ReportReason = "violence"
+	ReportReasonSexual         ReportReason = "sexual"
+	ReportReasonMisinformation ReportReason = "misinformation"
+	ReportReasonOther          ReportReason = "other"
+	// ReportReasonPolicy ставится системой на пост, задержанный
+	// контент-политикой; пользователи эту причину выбрать не могут.
+	ReportReasonPolicy ReportReason = "content_policy"
+)
+
+func (r ReportReason) Valid() bool {
+	switch r {
+	case ReportReasonSpam, ReportReasonHarassment, ReportReasonHate, ReportReasonViolence,
+		ReportReasonSexual, ReportReasonMisinformation, ReportReasonOther:
+		return true
+	default:
+		return false
+	}
+}
+
+type ReportStatus string
+
+const (
+	ReportStatusOpen      ReportStatus = "open"
+	ReportStatusResolved  ReportStatus = "resolved"
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+func (s ReportStatus) Valid() bool {
+	return s == ReportStatusOpen || s == ReportStatusResolved || s == ReportStatusDismissed
+}
+
+// ModerationActionType - решение модератора по жалобе. Любое действие,
+// кроме отклонения, закрывает жалобу как решенную.
+type ModerationActionType string
+
+const (
+	ModerationResolve       ModerationActionType = "resolve"
+	ModerationDismiss       ModerationActionType = "dismiss"
+	ModerationRemoveContent ModerationActionType = "remove_content"
+	ModerationSuspend       ModerationActionType = "suspend"
+	ModerationShadowBan     ModerationActionType = "shadow_ban"
+	// ModerationMarkSensitive помечает пост из жалобы как деликатный,
+	// не удаляя его.
+	ModerationMarkSensitive ModerationActionType = "mark_sensitive"
+)
+
+func (a ModerationActionType) Valid() bool {
+	switch a {
+	case ModerationResolve, ModerationDismiss, ModerationRemoveContent, ModerationSuspend, ModerationShadowBan,
+		ModerationMarkSensitive:
+		return true
+	default:
+		return false
+	}
+}
+
+// Report - жалоба пользователя на пост или другого пользователя.
+// Actions - история действий модераторов в порядке их совершения.
+type Report struct {
+	ID         uuid.UUID
+	ReporterID uuid.UUID
+	TargetType ReportTarget
+	TargetID   uuid.UUID
+	Reason     ReportReason
+	Comment    string
+	Status     ReportStatus
+	CreatedAt  time.Time
+	ClosedAt   time.Time
+	Actions    []ModerationAction
+}
+
+// ModerationAction - действие модератора по жалобе. SuspendUntil
+// заполняется только для блокировки аккаунта и теневого бана.
+type ModerationAction struct {
+	ReportID     uuid.UUID
+	ActorID      uuid.UUID
+	Type         ModerationActionType
+	Note         string
+	SuspendUntil time.Time
+	CreatedAt    time.Time
+}
+
+type ReportPage struct {
+	Reports    []*Report
+	NextCursor int64
+}
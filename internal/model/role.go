@@ -0,0 +1,31 @@
+package model
+
+// Role - уровень привилегий пользователя. Роли упорядочены: каждая
+// следующая включает права предыдущей.
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+var roleRank = map[Role]int{
+	RoleUser:      1,
+	RoleModerator: 2,
+	RoleAdmin:     3,
+}
+
+func (r Role) Valid() bool {
+	_, ok := roleRank[r]
+	return ok
+}
+
+// AtLeast сообщает, не ниже ли роль r роли min. Пустая роль считается
+// обычным пользователем.
+func (r Role) AtLeast(min Role) bool {
+	if r == "" {
+		r = RoleUser
+	}
+	return roleRank[r] >= roleRank[min]
+}
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// SanctionType - мера, которую модератор применяет к пользователю.
+type SanctionType string
+
+const (
+	// SanctionSuspension запрещает вход и любые действия, посты скрыты ото всех.
+	SanctionSuspension SanctionType = "suspension"
+	// SanctionShadowBan незаметно для пользователя скрывает его посты и
+	// реакции ото всех, кроме него самого.
+	SanctionShadowBan SanctionType = "shadow_ban"
+)
+
+func (t SanctionType) Valid() bool {
+	return t == SanctionSuspension || t == SanctionShadowBan
+}
+
+// Sanction действует до Until и снимается фоновой задачей после него.
+type Sanction struct {
+	Type   SanctionType
+	Reason string
+	Until  time.Time
+}
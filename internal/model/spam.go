@@ -0,0 +1,87 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SpamSignal - признак автоматизированной или вредной активности.
+type SpamSignal string
+
+const (
+	// SpamPostRate - слишком много постов за окно.
+	SpamPostRate SpamSignal = "post_rate"
+	// SpamLikeVelocity - слишком много реакций за окно.
+	SpamLikeVelocity SpamSignal = "like_velocity"
+	// SpamDuplicateText - текст почти повторяет недавний пост того же
+	// пользователя.
+	SpamDuplicateText SpamSignal = "duplicate_text"
+	// SpamRegistrationRate - слишком много регистраций с одного IP.
+	SpamRegistrationRate SpamSignal = "registration_rate"
+)
+
+// SpamVerdict - оценка одного действия. Throttled означает, что действие
+// нужно отклонить из-за превышения лимита частоты.
+type SpamVerdict struct {
+	Throttled bool
+	Signals   []SpamSignal
+}
+
+// SpamFlag - накопленные подозрения на спам у аккаунта. Signals считает
+// срабатывания каждого признака; QuarantinedAt нулевое, пока аккаунт
+// не помещен в карантин.
+type SpamFlag struct {
+	UserID        uuid.UUID
+	Score         int
+	Signals       map[SpamSignal]int
+	LastIP        string
+	FlaggedAt     time.Time
+	UpdatedAt     time.Time
+	QuarantinedAt time.Time
+}
+
+func (f *SpamFlag) Quarantined() bool {
+	return !f.QuarantinedAt.IsZero()
+}
+
+// FlaggedAccount - пользователь с подозрениями на спам.
+type FlaggedAccount struct {
+	User *User
+	Flag *SpamFlag
+}
+
+type FlaggedAccountPage struct {
+	Accounts   []*FlaggedAccount
+	NextCursor int64
+}
+
+type SpamLimits struct {
+	// Window - окно, в котором считаются частоты действий.
+	Window time.Duration
+	// MaxPosts и MaxLikes - лимиты одного пользователя за окно,
+	// MaxPostsPerIP и MaxLikesPerIP - всех пользователей одного IP.
+	MaxPosts      int
+	MaxPostsPerIP int
+	MaxLikes      int
+	MaxLikesPerIP int
+	// MaxRegistrationsPerIP - сколько аккаунтов можно создать с одного IP за окно.
+	MaxRegistrationsPerIP int
+	// NewAccountAge - аккаунты моложе этого возраста получают вдвое
+	// меньшие лимиты и вдвое больше штрафных очков.
+	NewAccountAge time.Duration
+	// DuplicateSimilarity - доля общих шинглов, начиная с которой текст
+	// считается повтором.
+	DuplicateSimilarity float64
+	// DuplicateHistory - сколько последних текстов помнить для сравнения.
+	DuplicateHistory int
+	// MaxDuplicatesPerIP - сколько повторов чужих текстов с одного IP
+	// допускается за окно. Повторы учитываются за адресом, а не за
+	// аккаунтом, и сверх лимита посты с адреса отклоняются.
+	MaxDuplicatesPerIP int
+	// QuarantineScore - сколько очков нужно набрать для карантина.
+	QuarantineScore int
+	// ScoreDecay - за каждый такой период без новых признаков счет
+	// аккаунта уменьшается на одно очко; 0 отключает забывание.
+	ScoreDecay time.Duration
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccessToken - ключ доступа сотрудника к ручкам модерации и
+// администрирования. Хранится только хеш ключа: сам ключ выдается один
+// раз при создании.
+type AccessToken struct {
+	Hash      string
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
@@ -1,8 +1,70 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type User struct {
-	ID   uuid.UUID
-	Name string
+	ID          uuid.UUID
+	Name        string
+	DisplayName string
+	Bio         string
+	Location    string
+	Website     string
+	AvatarID    string
+	// Role - роль пользователя; пустая роль равна RoleUser.
+	Role Role
+	// Private закрывает аккаунт: подписка становится запросом, который
+	// владелец одобряет, а посты видны только одобренным подписчикам.
+	Private bool
+	// ExpandSensitive раскрывает посты с деликатным содержимым и
+	// предупреждениями без дополнительного действия.
+	ExpandSensitive bool
+	// SuspendedUntil - до какого момента аккаунт заблокирован модератором
+	// с причиной SuspensionReason; нулевое значение - не заблокирован.
+	SuspendedUntil   time.Time
+	SuspensionReason string
+	// ShadowBannedUntil - до какого момента действует теневой бан с причиной
+	// ShadowBanReason: посты и реакции пользователя видны только ему самому.
+	ShadowBannedUntil time.Time
+	ShadowBanReason   string
+	// CreatedAt - время регистрации; нулевое у аккаунтов, созданных до
+	// того, как его начали сохранять.
+	CreatedAt time.Time
+}
+
+// Suspended сообщает, действует ли блокировка аккаунта в момент now.
+func (u *User) Suspended(now time.Time) bool {
+	return now.Before(u.SuspendedUntil)
+}
+
+// ShadowBanned сообщает, действует ли теневой бан в момент now.
+func (u *User) ShadowBanned(now time.Time) bool {
+	return now.Before(u.ShadowBannedUntil)
+}
+
+// Hidden сообщает, скрыты ли посты пользователя от остальных в момент now.
+func (u *User) Hidden(now time.Time) bool {
+	return u.Suspended(now) || u.ShadowBanned(now)
+}
+
+// ProfileUpdate - частичное обновление профиля: nil-поля не меняются,
+// пустая строка очищает поле.
+type ProfileUpdate struct {
+	DisplayName     *string
+	Bio             *string
+	Location        *string
+	Website         *string
+	AvatarID        *string
+	Private         *bool
+	ExpandSensitive *bool
+}
+
+type Profile struct {
+	User           *User
+	PostsCount     int
+	FollowersCount int
+	FollowingCount int
 }
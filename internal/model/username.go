@@ -0,0 +1,72 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	UsernameMinLen = 3
+	UsernameMaxLen = 30
+)
+
+// reservedUsernames нельзя занять: они пересекаются с путями API
+// или могут вводить пользователей в заблуждение.
+var reservedUsernames = map[string]struct{}{
+	"admin":         {},
+	"administrator": {},
+	"api":           {},
+	"help":          {},
+	"me":            {},
+	"moderator":     {},
+	"null":          {},
+	"posts":         {},
+	"register":      {},
+	"root":          {},
+	"support":       {},
+	"system":        {},
+	"undefined":     {},
+	"users":         {},
+}
+
+// NormalizeUsername приводит имя пользователя к каноническому виду:
+// NFKC, свертка регистра, обрезка пробелов. Допустимы только латинские
+// буквы, цифры и подчеркивание.
+func NormalizeUsername(raw string) (string, error) {
+	name := norm.NFKC.String(cases.Fold().String(norm.NFKC.String(raw)))
+	name = strings.TrimSpace(name)
+
+	if n := utf8.RuneCountInString(name); n < UsernameMinLen || n > UsernameMaxLen {
+		return "", fmt.Errorf("%w: length must be between %d and %d", ErrInvalidUsername, UsernameMinLen, UsernameMaxLen)
+	}
+
+	for _, r := range name {
+		if !isUsernameRune(r) {
+			return "", fmt.Errorf("%w: only letters a-z, digits and underscore are allowed", ErrInvalidUsername)
+		}
+	}
+
+	if _, ok := reservedUsernames[name]; ok {
+		return "", ErrReservedUsername
+	}
+
+	return name, nil
+}
+
+func isUsernameRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_'
+}
+
+// UsernameMovedError возвращается при поиске по старому имени,
+// пока оно удерживается за переименованным пользователем.
+type UsernameMovedError struct {
+	NewName string
+}
+
+func (e *UsernameMovedError) Error() string {
+	return fmt.Sprintf("username moved to %q", e.NewName)
+}
@@ -0,0 +1,24 @@
+package model
+
+// Visibility определяет, кому виден пост.
+type Visibility string
+
+const (
+	// VisibilityPublic - виден всем и попадает в общую ленту.
+	VisibilityPublic Visibility = "public"
+	// VisibilityUnlisted - доступен всем по ссылке, но не попадает в ленту.
+	VisibilityUnlisted Visibility = "unlisted"
+	// VisibilityFollowers - виден только подписчикам автора.
+	VisibilityFollowers Visibility = "followers"
+	// VisibilityMentioned - виден только упомянутым в тексте пользователям.
+	VisibilityMentioned Visibility = "mentioned"
+)
+
+func (v Visibility) Valid() bool {
+	switch v {
+	case VisibilityPublic, VisibilityUnlisted, VisibilityFollowers, VisibilityMentioned:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,62 @@
+package periodic
+
+import (
+	"sync"
+	"time"
+)
+
+// Runner выполняет задачу в фоне: сразу после запуска и затем с заданным
+// интервалом. Проходы не пересекаются, следующий начинается не раньше,
+// чем закончится предыдущий.
+type Runner struct {
+	task     func()
+	interval time.Duration
+	done     chan struct{}
+	wg       sync.WaitGroup
+
+	closeOnce sync.Once
+}
+
+// Start запускает задачу. Неположительный интервал заменяется секундой.
+func Start(interval time.Duration, task func()) *Runner {
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	r := &Runner{
+		task:     task,
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+
+	r.wg.Add(1)
+	go r.worker()
+
+	return r
+}
+
+func (r *Runner) worker() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.task()
+	for {
+		select {
+		case <-ticker.C:
+			r.task()
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// Close останавливает задачу и дожидается текущего прохода. Повторный
+// вызов ничего не делает.
+func (r *Runner) Close() {
+	r.closeOnce.Do(func() {
+		close(r.done)
+		r.wg.Wait()
+	})
+}
@@ -0,0 +1,32 @@
+package periodic_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"micro-blog/internal/periodic"
+)
+
+func TestRunner_RunsOnStartAndStops(t *testing.T) {
+	var calls atomic.Int32
+	r := periodic.Start(time.Hour, func() { calls.Add(1) })
+
+	assert.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	r.Close()
+	r.Close()
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRunner_RunsOnEveryTick(t *testing.T) {
+	var calls atomic.Int32
+	r := periodic.Start(5*time.Millisecond, func() { calls.Add(1) })
+
+	assert.Eventually(t, func() bool { return calls.Load() >= 3 }, time.Second, time.Millisecond)
+
+	r.Close()
+	stopped := calls.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, calls.Load())
+}
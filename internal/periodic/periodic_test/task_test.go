@@ -0,0 +1,128 @@
+package periodic_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/model"
+	"micro-blog/internal/periodic"
+	"micro-blog/internal/repository"
+	"micro-blog/internal/service"
+	"micro-blog/internal/testutil"
+)
+
+// recordingTask запоминает время, переданное задаче.
+type recordingTask struct {
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (r *recordingTask) run(_ context.Context, now time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.times = append(r.times, now)
+	return 0, nil
+}
+
+func (r *recordingTask) last() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.times) == 0 {
+		return time.Time{}
+	}
+	return r.times[len(r.times)-1]
+}
+
+func TestStartTask_UsesClock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(start)
+	task := &recordingTask{}
+
+	r := periodic.StartTask("record", task.run, clk, 5*time.Millisecond, testutil.NopLogger{})
+	defer r.Close()
+
+	assert.Eventually(t, func() bool { return task.last().Equal(start) }, time.Second, time.Millisecond)
+
+	clk.Advance(time.Hour)
+	assert.Eventually(t, func() bool { return task.last().Equal(start.Add(time.Hour)) }, time.Second, time.Millisecond)
+}
+
+func TestStartTask_PurgesExpiredPostsAndLikes(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	repo := repository.NewRepository()
+	serv := service.NewService(repo, nil, model.MediaLimits{}, model.PostLimits{MaxTextLength: 500, MaxTTL: time.Hour})
+	serv.PostService.SetClock(clk)
+
+	author, err := repo.CreateUser(&model.User{ID: uuid.New(), Name: "alice"})
+	require.NoError(t, err)
+
+	ephemeral, err := serv.CreatePost(context.Background(), &model.Post{AuthorID: author.ID, Text: "brief", TTL: time.Minute})
+	require.NoError(t, err)
+	permanent, err := serv.CreatePost(context.Background(), &model.Post{AuthorID: author.ID, Text: "forever"})
+	require.NoError(t, err)
+	require.NoError(t, serv.HandleLike(context.Background(), &model.Reaction{
+		UserID: author.ID,
+		PostID: ephemeral.ID,
+		Type:   model.ReactionLike,
+	}))
+
+	r := periodic.StartTask("purge expired posts", serv.PostService.PurgeExpired, clk, 5*time.Millisecond, testutil.NopLogger{})
+	defer r.Close()
+
+	time.Sleep(20 * time.Millisecond)
+	_, err = repo.GetPost(ephemeral.ID, author.ID)
+	require.NoError(t, err, "post must survive until its expiry")
+
+	clk.Advance(time.Minute)
+	assert.Eventually(t, func() bool {
+		_, err := repo.GetPost(ephemeral.ID, author.ID)
+		return err != nil
+	}, time.Second, time.Millisecond)
+
+	_, _, err = repo.GetPostReactions(ephemeral.ID, model.ReactionNone, 0, 10)
+	assert.ErrorIs(t, err, model.ErrPostNotFound)
+	_, err = repo.GetPost(permanent.ID, author.ID)
+	assert.NoError(t, err)
+}
+
+func TestStartTask_LiftsExpiredSanctions(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(start)
+	repo := repository.NewRepository()
+	serv := service.NewService(repo, nil, model.MediaLimits{}, model.PostLimits{MaxTextLength: 500})
+
+	suspended, err := repo.CreateUser(&model.User{Name: "suspended"})
+	require.NoError(t, err)
+	_, err = repo.SuspendUser(suspended.ID, "spam", start.Add(time.Hour))
+	require.NoError(t, err)
+
+	banned, err := repo.CreateUser(&model.User{Name: "banned"})
+	require.NoError(t, err)
+	_, err = repo.ShadowBanUser(banned.ID, "spam", start.Add(3*time.Hour))
+	require.NoError(t, err)
+
+	r := periodic.StartTask("lift expired sanctions", serv.ModerationService.LiftExpiredSanctions, clk, 5*time.Millisecond, testutil.NopLogger{})
+	defer r.Close()
+
+	clk.Advance(2 * time.Hour)
+	assert.Eventually(t, func() bool {
+		user, err := repo.GetUserById(suspended.ID)
+		return err == nil && user.SuspendedUntil.IsZero() && user.SuspensionReason == ""
+	}, time.Second, time.Millisecond)
+
+	user, err := repo.GetUserById(banned.ID)
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(3*time.Hour), user.ShadowBannedUntil, "shadow ban has not expired yet")
+
+	clk.Advance(2 * time.Hour)
+	assert.Eventually(t, func() bool {
+		user, err := repo.GetUserById(banned.ID)
+		return err == nil && user.ShadowBannedUntil.IsZero()
+	}, time.Second, time.Millisecond)
+}
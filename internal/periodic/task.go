@@ -0,0 +1,28 @@
+package periodic
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"micro-blog/internal/clock"
+	"micro-blog/internal/logger"
+)
+
+// Task - проход фоновой обработки: обрабатывает все, срок чего наступил
+// к now, и возвращает число обработанных записей.
+type Task func(ctx context.Context, now time.Time) (int, error)
+
+// StartTask запускает task, передавая ей время часов clk, и пишет в журнал
+// под именем name ошибки проходов и число обработанных записей.
+func StartTask(name string, task Task, clk clock.Clock, interval time.Duration, log logger.Logger) *Runner {
+	return Start(interval, func() {
+		count, err := task(context.Background(), clk.Now())
+		if err != nil {
+			log.Error("periodic task failed", slog.String("task", name), slog.String("error", err.Error()))
+		}
+		if count > 0 {
+			log.Info("periodic task done", slog.String("task", name), slog.Int("count", count))
+		}
+	})
+}
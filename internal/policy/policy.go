@@ -0,0 +1,49 @@
+package policy
+
+import "micro-blog/internal/model"
+
+// Названия правил в нарушениях.
+const (
+	RuleBlockedWord   = "blocked_word"
+	RuleBlockedDomain = "blocked_domain"
+	RuleMaxMentions   = "max_mentions"
+)
+
+// Rule - правило контент-политики. Check получает пост с очищенным
+// текстом и размеченными сущностями и возвращает нарушения.
+type Rule interface {
+	Check(post *model.Post) []model.PolicyViolation
+}
+
+// Pipeline проверяет пост всеми правилами по порядку и собирает
+// нарушения всех правил, чтобы автор увидел их сразу.
+type Pipeline struct {
+	rules []Rule
+}
+
+func NewPipeline(rules ...Rule) *Pipeline {
+	return &Pipeline{rules: rules}
+}
+
+func (p *Pipeline) Check(post *model.Post) []model.PolicyViolation {
+	var violations []model.PolicyViolation
+	for _, rule := range p.rules {
+		violations = append(violations, rule.Check(post)...)
+	}
+	return violations
+}
+
+// texts возвращает проверяемые тексты поста: сам текст, предупреждение
+// о содержимом и варианты опроса.
+func texts(post *model.Post) []string {
+	out := []string{post.Text}
+	if post.ContentWarning != "" {
+		out = append(out, post.ContentWarning)
+	}
+	if post.Poll != nil {
+		for _, option := range post.Poll.Options {
+			out = append(out, option.Text)
+		}
+	}
+	return out
+}
@@ -0,0 +1,188 @@
+package policy_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/model"
+	"micro-blog/internal/policy"
+	"micro-blog/internal/richtext"
+	"micro-blog/internal/testutil"
+)
+
+func writeList(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func newPost(text string) *model.Post {
+	return &model.Post{Text: text, Entities: richtext.Parse(text)}
+}
+
+func TestWordFilter_Check(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	writeList(t, path, "# comment\n\nspam\nбесплатно\nfree money\n/(?i)c[a@]sino/\n")
+
+	filter, err := policy.NewWordFilter(path, model.PolicyHold)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		post *model.Post
+		want []string
+	}{
+		{name: "clean", post: newPost("hello world")},
+		{name: "word inside another word", post: newPost("spammer antispam")},
+		{name: "whole word, any case", post: newPost("No SPAM, please"), want: []string{"SPAM"}},
+		{name: "cyrillic", post: newPost("Все бесплатно!"), want: []string{"бесплатно"}},
+		{name: "phrase across spaces", post: newPost("get free   money now"), want: []string{"free   money"}},
+		{name: "regex", post: newPost("best C@sino ever"), want: []string{"C@sino"}},
+		{
+			name: "poll option",
+			post: &model.Post{Text: "vote", Poll: &model.Poll{Options: []model.PollOption{{Text: "yes"}, {Text: "spam"}}}},
+			want: []string{"spam"},
+		},
+		{
+			name: "content warning",
+			post: &model.Post{Text: "hello", ContentWarning: "free money inside"},
+			want: []string{"free money"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := filter.Check(tt.post)
+
+			var details []string
+			for _, violation := range violations {
+				assert.Equal(t, policy.RuleBlockedWord, violation.Rule)
+				assert.Equal(t, model.PolicyHold, violation.Action)
+				details = append(details, violation.Detail)
+			}
+			assert.Equal(t, tt.want, details)
+		})
+	}
+}
+
+func TestWordFilter_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	writeList(t, path, "spam\n")
+
+	filter, err := policy.NewWordFilter(path, model.PolicyReject)
+	require.NoError(t, err)
+
+	reloaded, err := filter.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded, "unchanged file is not reread")
+
+	writeList(t, path, "scam\n")
+	reloaded, err = filter.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Empty(t, filter.Check(newPost("spam")))
+	assert.Len(t, filter.Check(newPost("scam")), 1)
+
+	// Список с ошибкой не применяется, остается прежний.
+	writeList(t, path, "/[/\n")
+	_, err = filter.Reload()
+	assert.Error(t, err)
+	assert.Len(t, filter.Check(newPost("scam")), 1)
+
+	_, err = policy.NewWordFilter(path, "ban")
+	assert.Error(t, err)
+	_, err = policy.NewWordFilter(filepath.Join(t.TempDir(), "missing.txt"), model.PolicyReject)
+	assert.Error(t, err)
+}
+
+func TestWatcher_ReloadsChangedLists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	writeList(t, path, "spam\n")
+
+	filter, err := policy.NewWordFilter(path, model.PolicyReject)
+	require.NoError(t, err)
+
+	watcher := policy.NewWatcher([]*policy.WordFilter{filter}, 10*time.Millisecond, testutil.NopLogger{})
+	defer watcher.Close()
+
+	writeList(t, path, "spam\nscam scam\n")
+	assert.Eventually(t, func() bool {
+		return len(filter.Check(newPost("a scam scam"))) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestDomainBlocklist_Check(t *testing.T) {
+	blocklist, err := policy.NewDomainBlocklist([]string{"Bad.example", " .tracker.io "}, model.PolicyReject)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "no links", text: "hello"},
+		{name: "allowed", text: "see https://good.example/bad.example"},
+		{name: "lookalike suffix", text: "https://notbad.example"},
+		{name: "exact domain", text: "https://BAD.example/page", want: []string{"bad.example"}},
+		{name: "subdomain with port", text: "http://a.b.tracker.io:8080/x", want: []string{"tracker.io"}},
+		{
+			name: "several links",
+			text: "https://bad.example and https://ok.example and https://x.tracker.io",
+			want: []string{"bad.example", "tracker.io"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var domains []string
+			for _, violation := range blocklist.Check(newPost(tt.text)) {
+				assert.Equal(t, policy.RuleBlockedDomain, violation.Rule)
+				domains = append(domains, violation.Detail)
+			}
+			assert.Equal(t, tt.want, domains)
+		})
+	}
+
+	_, err = policy.NewDomainBlocklist(nil, "")
+	assert.Error(t, err)
+}
+
+func TestMentionLimit_Check(t *testing.T) {
+	limit, err := policy.NewMentionLimit(2, model.PolicySensitive)
+	require.NoError(t, err)
+
+	assert.Empty(t, limit.Check(newPost("@alice @bob")))
+	assert.Empty(t, limit.Check(newPost("@alice @bob @Alice")), "repeated mentions count once")
+
+	violations := limit.Check(newPost("@alice @bob @carol"))
+	require.Len(t, violations, 1)
+	assert.Equal(t, model.PolicyViolation{
+		Rule:   policy.RuleMaxMentions,
+		Action: model.PolicySensitive,
+		Detail: "3 mentions, max 2",
+	}, violations[0])
+}
+
+func TestPipeline_CollectsAllViolations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	writeList(t, path, "spam\n")
+
+	words, err := policy.NewWordFilter(path, model.PolicySensitive)
+	require.NoError(t, err)
+	domains, err := policy.NewDomainBlocklist([]string{"bad.example"}, model.PolicyReject)
+	require.NoError(t, err)
+	mentions, err := policy.NewMentionLimit(1, model.PolicyHold)
+	require.NoError(t, err)
+
+	pipeline := policy.NewPipeline(words, domains, mentions)
+	assert.Empty(t, pipeline.Check(newPost("hello @alice")))
+
+	violations := pipeline.Check(newPost("spam @alice @bob https://bad.example"))
+	require.Len(t, violations, 3)
+	assert.Equal(t, model.PolicyReject, model.StrictestAction(violations))
+	assert.Equal(t, model.PolicyHold, model.StrictestAction(violations[2:]))
+	assert.Equal(t, model.PolicyAction(""), model.StrictestAction(nil))
+}
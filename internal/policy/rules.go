@@ -0,0 +1,93 @@
+package policy
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"micro-blog/internal/model"
+)
+
+// DomainBlocklist запрещает ссылки на перечисленные домены и их поддомены.
+type DomainBlocklist struct {
+	domains []string
+	action  model.PolicyAction
+}
+
+func NewDomainBlocklist(domains []string, action model.PolicyAction) (*DomainBlocklist, error) {
+	if !action.Valid() {
+		return nil, fmt.Errorf("unknown policy action %q", action)
+	}
+
+	normalized := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		domain = strings.Trim(strings.ToLower(strings.TrimSpace(domain)), ".")
+		if domain != "" {
+			normalized = append(normalized, domain)
+		}
+	}
+	return &DomainBlocklist{domains: normalized, action: action}, nil
+}
+
+func (b *DomainBlocklist) Check(post *model.Post) []model.PolicyViolation {
+	var violations []model.PolicyViolation
+	for _, entity := range post.Entities {
+		if entity.Type != model.EntityURL {
+			continue
+		}
+
+		link, err := url.Parse(entity.Text)
+		if err != nil {
+			continue
+		}
+
+		if domain, ok := b.match(strings.ToLower(link.Hostname())); ok {
+			violations = append(violations, model.PolicyViolation{
+				Rule:   RuleBlockedDomain,
+				Action: b.action,
+				Detail: domain,
+			})
+		}
+	}
+	return violations
+}
+
+func (b *DomainBlocklist) match(host string) (string, bool) {
+	for _, domain := range b.domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return domain, true
+		}
+	}
+	return "", false
+}
+
+// MentionLimit ограничивает число разных пользователей, упомянутых в посте.
+type MentionLimit struct {
+	max    int
+	action model.PolicyAction
+}
+
+func NewMentionLimit(max int, action model.PolicyAction) (*MentionLimit, error) {
+	if !action.Valid() {
+		return nil, fmt.Errorf("unknown policy action %q", action)
+	}
+	return &MentionLimit{max: max, action: action}, nil
+}
+
+func (l *MentionLimit) Check(post *model.Post) []model.PolicyViolation {
+	mentioned := make(map[string]struct{})
+	for _, entity := range post.Entities {
+		if entity.Type == model.EntityMention {
+			mentioned[strings.ToLower(entity.Text)] = struct{}{}
+		}
+	}
+
+	if len(mentioned) <= l.max {
+		return nil
+	}
+	return []model.PolicyViolation{{
+		Rule:   RuleMaxMentions,
+		Action: l.action,
+		Detail: fmt.Sprintf("%d mentions, max %d", len(mentioned), l.max),
+	}}
+}
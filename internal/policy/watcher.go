@@ -0,0 +1,47 @@
+package policy
+
+import (
+	"log/slog"
+	"time"
+
+	"micro-blog/internal/logger"
+	"micro-blog/internal/periodic"
+)
+
+// Watcher периодически перечитывает измененные файлы словарей, так что
+// правки списков запрещенных слов применяются без перезапуска.
+type Watcher struct {
+	filters []*WordFilter
+	logger  logger.Logger
+	runner  *periodic.Runner
+}
+
+func NewWatcher(filters []*WordFilter, interval time.Duration, log logger.Logger) *Watcher {
+	w := &Watcher{
+		filters: filters,
+		logger:  log,
+	}
+
+	w.runner = periodic.Start(interval, w.reload)
+
+	return w
+}
+
+func (w *Watcher) reload() {
+	for _, filter := range w.filters {
+		reloaded, err := filter.Reload()
+		if err != nil {
+			w.logger.Error("failed to reload word list",
+				slog.String("file", filter.Path()), slog.String("error", err.Error()))
+			continue
+		}
+		if reloaded {
+			w.logger.Info("word list reloaded", slog.String("file", filter.Path()))
+		}
+	}
+}
+
+// Close останавливает наблюдение за файлами.
+func (w *Watcher) Close() {
+	w.runner.Close()
+}
@@ -0,0 +1,135 @@
+package policy
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"micro-blog/internal/model"
+)
+
+// WordFilter запрещает слова и регулярные выражения из файла. В файле
+// по одному правилу в строке: слово или фраза ищутся целиком без учета
+// регистра, строка вида /.../ - регулярное выражение, строки с # -
+// комментарии. Файл можно менять на ходу, см. Reload.
+type WordFilter struct {
+	path   string
+	action model.PolicyAction
+
+	mu       sync.RWMutex
+	patterns []*regexp.Regexp
+	modTime  time.Time
+	size     int64
+}
+
+func NewWordFilter(path string, action model.PolicyAction) (*WordFilter, error) {
+	if !action.Valid() {
+		return nil, fmt.Errorf("unknown policy action %q", action)
+	}
+
+	f := &WordFilter{path: path, action: action}
+	if _, err := f.Reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *WordFilter) Path() string {
+	return f.path
+}
+
+// Reload перечитывает файл, если он изменился с прошлой загрузки, и
+// сообщает, был ли список обновлен. При ошибке остается прежний список.
+func (f *WordFilter) Reload() (bool, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return false, err
+	}
+
+	f.mu.RLock()
+	unchanged := f.patterns != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size
+	f.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	patterns, err := loadPatterns(f.path)
+	if err != nil {
+		return false, err
+	}
+
+	f.mu.Lock()
+	f.patterns = patterns
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	f.mu.Unlock()
+	return true, nil
+}
+
+func (f *WordFilter) Check(post *model.Post) []model.PolicyViolation {
+	f.mu.RLock()
+	patterns := f.patterns
+	f.mu.RUnlock()
+
+	var violations []model.PolicyViolation
+	for _, pattern := range patterns {
+		for _, text := range texts(post) {
+			match := pattern.FindStringSubmatch(text)
+			if match == nil {
+				continue
+			}
+			violations = append(violations, model.PolicyViolation{
+				Rule:   RuleBlockedWord,
+				Action: f.action,
+				Detail: match[len(match)-1],
+			})
+			break
+		}
+	}
+	return violations
+}
+
+func loadPatterns(path string) ([]*regexp.Regexp, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	patterns := make([]*regexp.Regexp, 0)
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+
+		pattern, err := compileEntry(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return patterns, nil
+}
+
+// compileEntry превращает строку файла в выражение. Найденный текст
+// попадает в последнюю группу выражения.
+func compileEntry(entry string) (*regexp.Regexp, error) {
+	if len(entry) > 2 && strings.HasPrefix(entry, "/") && strings.HasSuffix(entry, "/") {
+		return regexp.Compile("(" + entry[1:len(entry)-1] + ")")
+	}
+
+	words := strings.Fields(entry)
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	return regexp.Compile(`(?i)(?:^|[^\p{L}\p{N}_])(` + strings.Join(words, `\s+`) + `)(?:[^\p{L}\p{N}_]|$)`)
+}
@@ -0,0 +1,95 @@
+package queue
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"micro-blog/internal/logger"
+)
+
+// Queue обрабатывает события асинхронно в одной фоновой горутине. После
+// Close новые события отбрасываются, а уже принятые дообрабатываются.
+type Queue[T any] struct {
+	name    string
+	queue   chan T
+	done    chan struct{}
+	wg      sync.WaitGroup
+	logger  logger.Logger
+	handler func(ctx context.Context, event T) error
+
+	closeOnce sync.Once
+	closed    bool
+	mu        sync.Mutex
+}
+
+// New запускает очередь с буфером sizeBuffer. name попадает в логи.
+func New[T any](name string, handler func(ctx context.Context, event T) error, sizeBuffer int, log logger.Logger) *Queue[T] {
+	if sizeBuffer < 1 {
+		sizeBuffer = 1
+	}
+
+	q := &Queue[T]{
+		name:    name,
+		queue:   make(chan T, sizeBuffer),
+		handler: handler,
+		done:    make(chan struct{}),
+		logger:  log,
+	}
+
+	q.wg.Add(1)
+	go q.worker()
+
+	return q
+}
+
+func (q *Queue[T]) Enqueue(event T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		q.logger.Info(q.name + " is closed; skipping enqueue")
+		return
+	}
+
+	q.queue <- event
+}
+
+func (q *Queue[T]) worker() {
+	defer q.wg.Done()
+
+	for {
+		select {
+		case event := <-q.queue:
+			q.process(event)
+
+		case <-q.done:
+			for {
+				select {
+				case event := <-q.queue:
+					q.process(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (q *Queue[T]) process(event T) {
+	err := q.handler(context.Background(), event)
+	if err != nil {
+		q.logger.Error("failed to process "+q.name+" event", slog.String("error", err.Error()))
+	}
+}
+
+func (q *Queue[T]) Close() {
+	q.closeOnce.Do(func() {
+		q.mu.Lock()
+		q.closed = true
+		q.mu.Unlock()
+
+		close(q.done)
+		q.wg.Wait()
+	})
+}
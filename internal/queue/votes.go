@@ -0,0 +1,23 @@
+package queue
+
+import (
+	"context"
+
+	"micro-blog/internal/logger"
+	"micro-blog/internal/model"
+)
+
+type VoteHandler interface {
+	HandleVote(ctx context.Context, vote *model.PollVote) error
+}
+
+type VoteEnqueuer interface {
+	Enqueue(vote *model.PollVote)
+}
+
+// VoteQueue учитывает голоса в опросах асинхронно, как LikeQueue - реакции.
+type VoteQueue = Queue[*model.PollVote]
+
+func NewVoteQueue(serv VoteHandler, sizeBuffer int, log logger.Logger) *VoteQueue {
+	return New("voteQueue", serv.HandleVote, sizeBuffer, log)
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/model"
+)
+
+// AuditRepo - журнал аудита только на дописывание: записи нельзя ни
+// изменить, ни удалить. Номер записи в журнале на единицу больше ее
+// индекса и служит курсором.
+type AuditRepo struct {
+	entries []*model.AuditEntry
+	mu      sync.RWMutex
+}
+
+func NewAuditRepo() *AuditRepo {
+	return &AuditRepo{
+		mu: sync.RWMutex{},
+	}
+}
+
+func (r *AuditRepo) AppendAuditEntry(entry *model.AuditEntry) (*model.AuditEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *entry
+	stored.ID = uuid.New()
+	stored.Details = maps.Clone(entry.Details)
+	r.entries = append(r.entries, &stored)
+
+	return copyAuditEntry(&stored), nil
+}
+
+// ListAuditEntries возвращает подходящие под фильтр записи от новых к
+// старым, начиная с более старых, чем курсор before (0 - с начала).
+func (r *AuditRepo) ListAuditEntries(filter model.AuditFilter, before int64, limit int) ([]*model.AuditEntry, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	start := int64(len(r.entries))
+	if before > 0 && before-1 < start {
+		start = before - 1
+	}
+
+	page := make([]*model.AuditEntry, 0, limit)
+	var lastSeq int64
+	for i := start - 1; i >= 0; i-- {
+		entry := r.entries[i]
+		if !filter.Match(entry) {
+			continue
+		}
+		if len(page) == limit {
+			return page, lastSeq, nil
+		}
+		page = append(page, copyAuditEntry(entry))
+		lastSeq = i + 1
+	}
+	return page, 0, nil
+}
+
+func copyAuditEntry(entry *model.AuditEntry) *model.AuditEntry {
+	c := *entry
+	c.Details = maps.Clone(entry.Details)
+	return &c
+}
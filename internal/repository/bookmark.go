@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/model"
+)
+
+type BookmarkRepo struct {
+	bookmarks   map[uuid.UUID]*userBookmarks
+	collections map[uuid.UUID]map[uuid.UUID]*model.BookmarkCollection
+	seq         int64
+	mu          sync.RWMutex
+}
+
+// userBookmarks хранит закладки пользователя в порядке добавления.
+// seq у записи монотонно растет и служит курсором пагинации.
+type userBookmarks struct {
+	entries []bookmarkEntry
+	byPost  map[uuid.UUID]struct{}
+}
+
+type bookmarkEntry struct {
+	bookmark model.Bookmark
+	seq      int64
+}
+
+func NewBookmarkRepo() *BookmarkRepo {
+	return &BookmarkRepo{
+		bookmarks:   make(map[uuid.UUID]*userBookmarks),
+		collections: make(map[uuid.UUID]map[uuid.UUID]*model.BookmarkCollection),
+		mu:          sync.RWMutex{},
+	}
+}
+
+// AddBookmark сохраняет закладку. Повторное добавление поста переносит
+// закладку в указанную коллекцию, не меняя ее места в списке.
+func (r *BookmarkRepo) AddBookmark(bookmark *model.Bookmark) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if bookmark.CollectionID != uuid.Nil {
+		if _, ok := r.collections[bookmark.UserID][bookmark.CollectionID]; !ok {
+			return model.ErrCollectionNotFound
+		}
+	}
+
+	user, ok := r.bookmarks[bookmark.UserID]
+	if !ok {
+		user = &userBookmarks{byPost: make(map[uuid.UUID]struct{})}
+		r.bookmarks[bookmark.UserID] = user
+	}
+
+	if _, ok = user.byPost[bookmark.PostID]; ok {
+		for i := range user.entries {
+			if user.entries[i].bookmark.PostID == bookmark.PostID {
+				user.entries[i].bookmark.CollectionID = bookmark.CollectionID
+			}
+		}
+		return nil
+	}
+
+	r.seq++
+	user.entries = append(user.entries, bookmarkEntry{bookmark: *bookmark, seq: r.seq})
+	user.byPost[bookmark.PostID] = struct{}{}
+	return nil
+}
+
+func (r *BookmarkRepo) RemoveBookmark(userID, postID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.bookmarks[userID]
+	if !ok {
+		return nil
+	}
+
+	delete(user.byPost, postID)
+	user.entries = slices.DeleteFunc(user.entries, func(entry bookmarkEntry) bool {
+		return entry.bookmark.PostID == postID
+	})
+	return nil
+}
+
+// ListBookmarks возвращает закладки пользователя от новых к старым,
+// начиная с записей старше курсора before (0 - с начала). Если задан
+// collectionID, возвращаются только закладки этой коллекции.
+func (r *BookmarkRepo) ListBookmarks(
+	userID uuid.UUID,
+	collectionID uuid.UUID,
+	before int64,
+	limit int,
+) ([]*model.Bookmark, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if collectionID != uuid.Nil {
+		if _, ok := r.collections[userID][collectionID]; !ok {
+			return nil, 0, model.ErrCollectionNotFound
+		}
+	}
+
+	user, ok := r.bookmarks[userID]
+	if !ok {
+		return []*model.Bookmark{}, 0, nil
+	}
+
+	end := len(user.entries)
+	if before > 0 {
+		end, _ = slices.BinarySearchFunc(user.entries, before, func(entry bookmarkEntry, seq int64) int {
+			return cmp.Compare(entry.seq, seq)
+		})
+	}
+
+	page := make([]*model.Bookmark, 0, limit)
+	var lastSeq int64
+	for i := end - 1; i >= 0; i-- {
+		entry := user.entries[i]
+		if collectionID != uuid.Nil && entry.bookmark.CollectionID != collectionID {
+			continue
+		}
+		if len(page) == limit {
+			return page, lastSeq, nil
+		}
+		bookmark := entry.bookmark
+		page = append(page, &bookmark)
+		lastSeq = entry.seq
+	}
+	return page, 0, nil
+}
+
+func (r *BookmarkRepo) CreateCollection(collection *model.BookmarkCollection) (*model.BookmarkCollection, error) {
+	stored := *collection
+	stored.ID = uuid.New()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	owned, ok := r.collections[stored.OwnerID]
+	if !ok {
+		owned = make(map[uuid.UUID]*model.BookmarkCollection)
+		r.collections[stored.OwnerID] = owned
+	}
+	owned[stored.ID] = &stored
+
+	cp := stored
+	return &cp, nil
+}
+
+// ListCollections возвращает коллекции пользователя в порядке создания.
+func (r *BookmarkRepo) ListCollections(ownerID uuid.UUID) ([]*model.BookmarkCollection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	collections := make([]*model.BookmarkCollection, 0, len(r.collections[ownerID]))
+	for _, collection := range r.collections[ownerID] {
+		cp := *collection
+		collections = append(collections, &cp)
+	}
+
+	slices.SortFunc(collections, func(a, b *model.BookmarkCollection) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return collections, nil
+}
+
+// DeleteCollection удаляет коллекцию. Ее закладки остаются без коллекции.
+func (r *BookmarkRepo) DeleteCollection(ownerID, collectionID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.collections[ownerID][collectionID]; !ok {
+		return model.ErrCollectionNotFound
+	}
+	delete(r.collections[ownerID], collectionID)
+
+	if user, ok := r.bookmarks[ownerID]; ok {
+		for i := range user.entries {
+			if user.entries[i].bookmark.CollectionID == collectionID {
+				user.entries[i].bookmark.CollectionID = uuid.Nil
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/model"
+)
+
+type ConversationRepo struct {
+	conversations map[uuid.UUID]*conversationState
+	byMember      map[uuid.UUID]map[uuid.UUID]struct{}
+	direct        map[[2]uuid.UUID]uuid.UUID
+	seq           int64
+	mu            sync.RWMutex
+}
+
+// conversationState хранит сообщения переписки в порядке отправки и
+// маркеры прочтения участников. seq сообщений монотонно растет и служит
+// курсором пагинации; created - seq создания переписки.
+type conversationState struct {
+	conversation model.Conversation
+	messages     []messageEntry
+	lastRead     map[uuid.UUID]int64
+	created      int64
+}
+
+type messageEntry struct {
+	message model.Message
+	seq     int64
+}
+
+func NewConversationRepo() *ConversationRepo {
+	return &ConversationRepo{
+		conversations: make(map[uuid.UUID]*conversationState),
+		byMember:      make(map[uuid.UUID]map[uuid.UUID]struct{}),
+		direct:        make(map[[2]uuid.UUID]uuid.UUID),
+		mu:            sync.RWMutex{},
+	}
+}
+
+// CreateConversation сохраняет переписку. Для пары пользователей
+// существует только одна личная переписка: повторное создание
+// возвращает уже существующую.
+func (r *ConversationRepo) CreateConversation(conversation *model.Conversation) (*model.Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var key [2]uuid.UUID
+	if conversation.IsDirect() {
+		key = directKey(conversation.MemberIDs[0], conversation.MemberIDs[1])
+		if id, ok := r.direct[key]; ok {
+			return r.viewConversation(r.conversations[id], conversation.MemberIDs[0]), nil
+		}
+	}
+
+	stored := *conversation
+	stored.ID = uuid.New()
+	stored.MemberIDs = slices.Clone(conversation.MemberIDs)
+
+	r.seq++
+	state := &conversationState{
+		conversation: stored,
+		lastRead:     make(map[uuid.UUID]int64, len(stored.MemberIDs)),
+		created:      r.seq,
+	}
+	r.conversations[stored.ID] = state
+
+	for _, memberID := range stored.MemberIDs {
+		if _, ok := r.byMember[memberID]; !ok {
+			r.byMember[memberID] = make(map[uuid.UUID]struct{})
+		}
+		r.byMember[memberID][stored.ID] = struct{}{}
+	}
+	if stored.IsDirect() {
+		r.direct[key] = stored.ID
+	}
+
+	return r.viewConversation(state, stored.MemberIDs[0]), nil
+}
+
+// GetConversation возвращает переписку глазами участника viewerID.
+// Для остальных пользователей переписки не существует.
+func (r *ConversationRepo) GetConversation(conversationID, viewerID uuid.UUID) (*model.Conversation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	state, err := r.memberState(conversationID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return r.viewConversation(state, viewerID), nil
+}
+
+// ListConversations возвращает переписки пользователя, начиная с самой
+// свежей по последнему событию. Порядок считается на момент снимка из
+// курсора, поэтому новые сообщения между запросами страниц не приводят
+// к пропускам и повторам: переписки, созданные после снимка, в страницы
+// не попадают, а остальные остаются на своих местах.
+func (r *ConversationRepo) ListConversations(
+	userID uuid.UUID,
+	cursor model.ConversationCursor,
+	limit int,
+) ([]*model.Conversation, model.ConversationCursor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := cursor.Snapshot
+	if snapshot == 0 {
+		snapshot = r.seq
+	}
+
+	type candidate struct {
+		state    *conversationState
+		activity int64
+	}
+	candidates := make([]candidate, 0, len(r.byMember[userID]))
+	for id := range r.byMember[userID] {
+		state := r.conversations[id]
+		activity := state.activityAt(snapshot)
+		if activity == 0 || cursor.Before > 0 && activity >= cursor.Before {
+			continue
+		}
+		candidates = append(candidates, candidate{state: state, activity: activity})
+	}
+
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(b.activity, a.activity)
+	})
+
+	var next model.ConversationCursor
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+		next = model.ConversationCursor{Snapshot: snapshot, Before: candidates[limit-1].activity}
+	}
+
+	page := make([]*model.Conversation, len(candidates))
+	for i, c := range candidates {
+		page[i] = r.viewConversation(c.state, userID)
+	}
+	return page, next, nil
+}
+
+// activityAt возвращает seq последнего события переписки не позже
+// snapshot: последнего сообщения или создания. 0 - переписка создана
+// после снимка.
+func (s *conversationState) activityAt(snapshot int64) int64 {
+	if i := searchMessage(s.messages, snapshot+1); i > 0 {
+		return s.messages[i-1].seq
+	}
+	if s.created <= snapshot {
+		return s.created
+	}
+	return 0
+}
+
+// AddMessage сохраняет сообщение участника переписки. Свое сообщение
+// отправитель считает прочитанным.
+func (r *ConversationRepo) AddMessage(message *model.Message) (*model.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, err := r.memberState(message.ConversationID, message.SenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := *message
+	stored.ID = uuid.New()
+
+	r.seq++
+	state.messages = append(state.messages, messageEntry{message: stored, seq: r.seq})
+	state.lastRead[stored.SenderID] = r.seq
+
+	cp := stored
+	return &cp, nil
+}
+
+// ListMessages возвращает сообщения переписки от новых к старым,
+// начиная с сообщений старше курсора before (0 - с начала).
+func (r *ConversationRepo) ListMessages(conversationID, viewerID uuid.UUID, before int64, limit int) ([]*model.Message, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	state, err := r.memberState(conversationID, viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	end := len(state.messages)
+	if before > 0 {
+		end = searchMessage(state.messages, before)
+	}
+
+	page := make([]*model.Message, 0, min(limit, end))
+	var lastSeq int64
+	for i := end - 1; i >= 0; i-- {
+		if len(page) == limit {
+			return page, lastSeq, nil
+		}
+		message := state.messages[i].message
+		page = append(page, &message)
+		lastSeq = state.messages[i].seq
+	}
+	return page, 0, nil
+}
+
+// MarkRead сдвигает маркер прочтения участника до сообщения messageID
+// включительно или до последнего сообщения, если messageID равен
+// uuid.Nil. Маркер никогда не сдвигается назад.
+func (r *ConversationRepo) MarkRead(conversationID, userID, messageID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, err := r.memberState(conversationID, userID)
+	if err != nil {
+		return err
+	}
+	if len(state.messages) == 0 {
+		return nil
+	}
+
+	seq := state.messages[len(state.messages)-1].seq
+	if messageID != uuid.Nil {
+		i := slices.IndexFunc(state.messages, func(entry messageEntry) bool {
+			return entry.message.ID == messageID
+		})
+		if i < 0 {
+			return model.ErrMessageNotFound
+		}
+		seq = state.messages[i].seq
+	}
+
+	state.lastRead[userID] = max(state.lastRead[userID], seq)
+	return nil
+}
+
+// CountUnread возвращает число непрочитанных сообщений во всех
+// переписках пользователя.
+func (r *ConversationRepo) CountUnread(userID uuid.UUID) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var total int
+	for id := range r.byMember[userID] {
+		total += unreadCount(r.conversations[id], userID)
+	}
+	return total, nil
+}
+
+func (r *ConversationRepo) memberState(conversationID, userID uuid.UUID) (*conversationState, error) {
+	state, ok := r.conversations[conversationID]
+	if !ok {
+		return nil, model.ErrConversationNotFound
+	}
+	if _, ok = r.byMember[userID][conversationID]; !ok {
+		return nil, model.ErrConversationNotFound
+	}
+	return state, nil
+}
+
+// viewConversation возвращает копию переписки с полями, вычисленными
+// для участника viewerID.
+func (r *ConversationRepo) viewConversation(state *conversationState, viewerID uuid.UUID) *model.Conversation {
+	cp := state.conversation
+	cp.MemberIDs = slices.Clone(state.conversation.MemberIDs)
+
+	if n := len(state.messages); n > 0 {
+		last := state.messages[n-1].message
+		cp.LastMessage = &last
+	}
+	cp.UnreadCount = unreadCount(state, viewerID)
+
+	if seq := state.lastRead[viewerID]; seq > 0 {
+		if i := searchMessage(state.messages, seq); i < len(state.messages) && state.messages[i].seq == seq {
+			cp.LastReadMessageID = state.messages[i].message.ID
+		}
+	}
+	return &cp
+}
+
+// unreadCount считает чужие сообщения после маркера прочтения участника.
+func unreadCount(state *conversationState, userID uuid.UUID) int {
+	var count int
+	for _, entry := range state.messages[searchMessage(state.messages, state.lastRead[userID]+1):] {
+		if entry.message.SenderID != userID {
+			count++
+		}
+	}
+	return count
+}
+
+// searchMessage возвращает индекс первого сообщения с seq не меньше
+// заданного.
+func searchMessage(messages []messageEntry, seq int64) int {
+	i, _ := slices.BinarySearchFunc(messages, seq, func(entry messageEntry, seq int64) int {
+		return cmp.Compare(entry.seq, seq)
+	})
+	return i
+}
+
+func directKey(a, b uuid.UUID) [2]uuid.UUID {
+	if cmp.Compare(a.String(), b.String()) > 0 {
+		a, b = b, a
+	}
+	return [2]uuid.UUID{a, b}
+}
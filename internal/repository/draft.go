@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/model"
+)
+
+type DraftRepo struct {
+	drafts map[uuid.UUID]*model.Draft
+	mu     sync.RWMutex
+}
+
+func NewDraftRepo() *DraftRepo {
+	return &DraftRepo{
+		drafts: make(map[uuid.UUID]*model.Draft),
+		mu:     sync.RWMutex{},
+	}
+}
+
+func (r *DraftRepo) CreateDraft(draft *model.Draft) (*model.Draft, error) {
+	stored := copyDraft(draft)
+	stored.ID = uuid.New()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.drafts[stored.ID] = stored
+	return copyDraft(stored), nil
+}
+
+func (r *DraftRepo) GetDraft(id uuid.UUID) (*model.Draft, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	draft, ok := r.drafts[id]
+	if !ok {
+		return nil, model.ErrDraftNotFound
+	}
+	return copyDraft(draft), nil
+}
+
+// ListDrafts возвращает черновики автора, недавно измененные первыми.
+func (r *DraftRepo) ListDrafts(authorID uuid.UUID) ([]*model.Draft, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	drafts := make([]*model.Draft, 0)
+	for _, draft := range r.drafts {
+		if draft.AuthorID == authorID {
+			drafts = append(drafts, copyDraft(draft))
+		}
+	}
+
+	slices.SortFunc(drafts, func(a, b *model.Draft) int {
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	})
+	return drafts, nil
+}
+
+func (r *DraftRepo) UpdateDraft(draft *model.Draft) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.drafts[draft.ID]; !ok {
+		return model.ErrDraftNotFound
+	}
+	r.drafts[draft.ID] = copyDraft(draft)
+	return nil
+}
+
+func (r *DraftRepo) DeleteDraft(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.drafts[id]; !ok {
+		return model.ErrDraftNotFound
+	}
+	delete(r.drafts, id)
+	return nil
+}
+
+// TakeDraft атомарно забирает черновик из хранилища для публикации:
+// из двух одновременных публикаций черновик получит только одна.
+func (r *DraftRepo) TakeDraft(id uuid.UUID) (*model.Draft, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	draft, ok := r.drafts[id]
+	if !ok {
+		return nil, model.ErrDraftNotFound
+	}
+	delete(r.drafts, id)
+	return draft, nil
+}
+
+// RestoreDraft возвращает забранный черновик, если публикация не
+// удалась. Черновик с тем же ID, появившийся за это время, не
+// перезаписывается.
+func (r *DraftRepo) RestoreDraft(draft *model.Draft) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.drafts[draft.ID]; !ok {
+		r.drafts[draft.ID] = copyDraft(draft)
+	}
+	return nil
+}
+
+// ListDueDrafts возвращает запланированные черновики, время публикации
+// которых наступило, в порядке этого времени.
+func (r *DraftRepo) ListDueDrafts(now time.Time) ([]*model.Draft, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	due := make([]*model.Draft, 0)
+	for _, draft := range r.drafts {
+		if draft.Scheduled() && !draft.PublishAt.After(now) {
+			due = append(due, copyDraft(draft))
+		}
+	}
+
+	slices.SortFunc(due, func(a, b *model.Draft) int {
+		return a.PublishAt.Compare(b.PublishAt)
+	})
+	return due, nil
+}
+
+func copyDraft(draft *model.Draft) *model.Draft {
+	cp := *draft
+	cp.AttachmentIDs = slices.Clone(draft.AttachmentIDs)
+	cp.SensitiveAttachmentIDs = slices.Clone(draft.SensitiveAttachmentIDs)
+	if draft.Poll != nil {
+		poll := *draft.Poll
+		poll.Options = slices.Clone(draft.Poll.Options)
+		cp.Poll = &poll
+	}
+	return &cp
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type FollowRepo struct {
+	following map[uuid.UUID]map[uuid.UUID]struct{}
+	followers map[uuid.UUID]map[uuid.UUID]struct{}
+	blocks    map[uuid.UUID]*relationList
+	mutes     map[uuid.UUID]*relationList
+	requests  map[uuid.UUID]*relationList
+	seq       int64
+	mu        sync.RWMutex
+}
+
+func NewFollowRepo() *FollowRepo {
+	return &FollowRepo{
+		following: make(map[uuid.UUID]map[uuid.UUID]struct{}),
+		followers: make(map[uuid.UUID]map[uuid.UUID]struct{}),
+		blocks:    make(map[uuid.UUID]*relationList),
+		mutes:     make(map[uuid.UUID]*relationList),
+		requests:  make(map[uuid.UUID]*relationList),
+		mu:        sync.RWMutex{},
+	}
+}
+
+func (r *FollowRepo) Follow(followerID, followeeID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	addEdge(r.following, followerID, followeeID)
+	addEdge(r.followers, followeeID, followerID)
+	return nil
+}
+
+// Unfollow снимает подписку или отменяет ожидающий запрос на нее.
+func (r *FollowRepo) Unfollow(followerID, followeeID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.following[followerID], followeeID)
+	delete(r.followers[followeeID], followerID)
+	removeRelation(r.requests, followeeID, followerID)
+	return nil
+}
+
+func (r *FollowRepo) CountFollowers(userID uuid.UUID) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.followers[userID])
+}
+
+func (r *FollowRepo) CountFollowing(userID uuid.UUID) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.following[userID])
+}
+
+func (r *FollowRepo) IsFollowing(followerID, followeeID uuid.UUID) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.following[followerID][followeeID]
+	return ok
+}
+
+func addEdge(edges map[uuid.UUID]map[uuid.UUID]struct{}, from, to uuid.UUID) {
+	set, ok := edges[from]
+	if !ok {
+		set = make(map[uuid.UUID]struct{})
+		edges[from] = set
+	}
+	set[to] = struct{}{}
+}
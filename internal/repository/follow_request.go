@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"micro-blog/internal/model"
+)
+
+// Запросы на подписку хранятся списками по владельцу закрытого аккаунта:
+// OwnerID записи - на кого подписываются, TargetID - кто просит.
+
+// RequestFollow сохраняет запрос на подписку. Повторный запрос и запрос
+// от уже подписанного пользователя ничего не меняют.
+func (r *FollowRepo) RequestFollow(request *model.FollowRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.following[request.FollowerID][request.FolloweeID]; ok {
+		return nil
+	}
+
+	r.addRelation(r.requests, &model.Relation{
+		OwnerID:   request.FolloweeID,
+		TargetID:  request.FollowerID,
+		CreatedAt: request.CreatedAt,
+	})
+	return nil
+}
+
+func (r *FollowRepo) HasFollowRequest(followerID, followeeID uuid.UUID) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return hasRelation(r.requests, followeeID, followerID)
+}
+
+// ListFollowRequests возвращает входящие запросы от новых к старым,
+// начиная с запросов старше курсора before (0 - с начала).
+func (r *FollowRepo) ListFollowRequests(followeeID uuid.UUID, before int64, limit int) ([]*model.FollowRequest, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	relations, next := listRelations(r.requests[followeeID], before, limit)
+
+	requests := make([]*model.FollowRequest, len(relations))
+	for i, relation := range relations {
+		requests[i] = &model.FollowRequest{
+			FollowerID: relation.TargetID,
+			FolloweeID: relation.OwnerID,
+			CreatedAt:  relation.CreatedAt,
+		}
+	}
+	return requests, next, nil
+}
+
+// ApproveFollowRequest превращает запрос в подписку.
+func (r *FollowRepo) ApproveFollowRequest(followeeID, followerID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !hasRelation(r.requests, followeeID, followerID) {
+		return model.ErrFollowRequestNotFound
+	}
+
+	removeRelation(r.requests, followeeID, followerID)
+	addEdge(r.following, followerID, followeeID)
+	addEdge(r.followers, followeeID, followerID)
+	return nil
+}
+
+func (r *FollowRepo) RejectFollowRequest(followeeID, followerID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !hasRelation(r.requests, followeeID, followerID) {
+		return model.ErrFollowRequestNotFound
+	}
+
+	removeRelation(r.requests, followeeID, followerID)
+	return nil
+}
+
+// ApproveAllFollowRequests одобряет все ожидающие запросы, например
+// когда аккаунт перестает быть закрытым.
+func (r *FollowRepo) ApproveAllFollowRequests(followeeID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list, ok := r.requests[followeeID]
+	if !ok {
+		return nil
+	}
+
+	for _, entry := range list.entries {
+		addEdge(r.following, entry.relation.TargetID, followeeID)
+		addEdge(r.followers, followeeID, entry.relation.TargetID)
+	}
+	delete(r.requests, followeeID)
+	return nil
+}
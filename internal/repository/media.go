@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/model"
+)
+
+type MediaRepo struct {
+	media map[uuid.UUID]*model.Media
+	mu    sync.RWMutex
+}
+
+func NewMediaRepo() *MediaRepo {
+	return &MediaRepo{
+		media: make(map[uuid.UUID]*model.Media),
+		mu:    sync.RWMutex{},
+	}
+}
+
+func (r *MediaRepo) CreateMedia(media *model.Media) error {
+	stored := *media
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.media[stored.ID] = &stored
+	return nil
+}
+
+func (r *MediaRepo) GetMedia(id uuid.UUID) (*model.Media, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if val, ok := r.media[id]; ok {
+		cp := *val
+		return &cp, nil
+	}
+	return nil, model.ErrMediaNotFound
+}
@@ -1,7 +1,11 @@
 package repository
 
 import (
+	"cmp"
+	"maps"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"micro-blog/internal/model"
@@ -12,14 +16,50 @@ const (
 )
 
 type PostRepo struct {
-	Posts []*model.Post
-	mu    sync.RWMutex
+	posts []*model.Post
+	byID  map[uuid.UUID]*model.Post
+	// byAuthor - посты каждого автора в порядке создания. seq записи
+	// монотонно растет и служит курсором ленты автора.
+	byAuthor map[uuid.UUID][]authorEntry
+	postSeq  int64
+	// pinned - закрепленные посты автора, последний закрепленный первым.
+	pinned      map[uuid.UUID][]*model.Post
+	reactions   map[uuid.UUID]*postReactions
+	reactionSeq int64
+	revisions   map[uuid.UUID][]*model.PostRevision
+	// pollVoters - выбор каждого проголосовавшего по постам с опросами.
+	pollVoters map[uuid.UUID]map[uuid.UUID][]int
+	mu         sync.RWMutex
+}
+
+// postReactions хранит реакции на пост в порядке их появления.
+// seq у записи монотонно растет и служит курсором пагинации.
+type postReactions struct {
+	entries []reactionEntry
+	byUser  map[uuid.UUID]model.ReactionType
+}
+
+type authorEntry struct {
+	post *model.Post
+	seq  int64
+}
+
+type reactionEntry struct {
+	userID       uuid.UUID
+	reactionType model.ReactionType
+	seq          int64
 }
 
 func NewPostRepo() *PostRepo {
 	return &PostRepo{
-		Posts: make([]*model.Post, 0, initPostsCapacity),
-		mu:    sync.RWMutex{},
+		posts:      make([]*model.Post, 0, initPostsCapacity),
+		byID:       make(map[uuid.UUID]*model.Post, initPostsCapacity),
+		byAuthor:   make(map[uuid.UUID][]authorEntry),
+		pinned:     make(map[uuid.UUID][]*model.Post),
+		reactions:  make(map[uuid.UUID]*postReactions, initPostsCapacity),
+		revisions:  make(map[uuid.UUID][]*model.PostRevision, initPostsCapacity),
+		pollVoters: make(map[uuid.UUID]map[uuid.UUID][]int),
+		mu:         sync.RWMutex{},
 	}
 }
 
@@ -29,35 +69,478 @@ func (r *PostRepo) CreatePost(post *model.Post) (*model.Post, error) {
 		return nil, err
 	}
 
-	post.ID = id
+	stored := copyPost(post)
+	stored.ID = id
+	stored.Reactions = make(map[model.ReactionType]int)
+	stored.MyReaction = model.ReactionNone
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.Posts = append(r.Posts, post)
-	return post, nil
+	r.posts = append(r.posts, stored)
+	r.byID[id] = stored
+	r.postSeq++
+	r.byAuthor[stored.AuthorID] = append(r.byAuthor[stored.AuthorID], authorEntry{post: stored, seq: r.postSeq})
+	r.reactions[id] = &postReactions{byUser: make(map[uuid.UUID]model.ReactionType)}
+	if stored.Poll != nil {
+		r.pollVoters[id] = make(map[uuid.UUID][]int)
+	}
+	r.revisions[id] = []*model.PostRevision{{
+		PostID:    id,
+		Number:    1,
+		Text:      stored.Text,
+		Entities:  slices.Clone(stored.Entities),
+		CreatedAt: stored.CreatedAt,
+	}}
+	return copyPost(stored), nil
 }
 
-func (r *PostRepo) GetListPost() ([]*model.Post, error) {
+func (r *PostRepo) GetPost(postID uuid.UUID, viewerID uuid.UUID) (*model.Post, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	return r.Posts, nil
+
+	post, ok := r.byID[postID]
+	if !ok {
+		return nil, model.ErrPostNotFound
+	}
+	return r.viewPost(post, viewerID), nil
+}
+
+// EditPost меняет текст поста и сохраняет новую ревизию.
+func (r *PostRepo) EditPost(edit *model.PostEdit) (*model.Post, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, ok := r.byID[edit.PostID]
+	if !ok {
+		return nil, model.ErrPostNotFound
+	}
+
+	post.Text = edit.Text
+	post.Entities = slices.Clone(edit.Entities)
+	post.EditedAt = edit.EditedAt
+	post.Sensitive = post.Sensitive || edit.Sensitive
+	post.Held = post.Held || edit.Held
+
+	revisions := r.revisions[edit.PostID]
+	r.revisions[edit.PostID] = append(revisions, &model.PostRevision{
+		PostID:    edit.PostID,
+		Number:    len(revisions) + 1,
+		Text:      edit.Text,
+		Entities:  slices.Clone(edit.Entities),
+		CreatedAt: edit.EditedAt,
+	})
+
+	return r.viewPost(post, post.AuthorID), nil
+}
+
+// GetPostRevisions возвращает ревизии поста от первой к последней.
+func (r *PostRepo) GetPostRevisions(postID uuid.UUID) ([]*model.PostRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revisions, ok := r.revisions[postID]
+	if !ok {
+		return nil, model.ErrPostNotFound
+	}
+
+	out := make([]*model.PostRevision, len(revisions))
+	for i, revision := range revisions {
+		cp := *revision
+		cp.Entities = slices.Clone(revision.Entities)
+		out[i] = &cp
+	}
+	return out, nil
+}
+
+// GetListPost возвращает снимок постов: вызывающий получает копии,
+// которые не меняются при последующих реакциях. MyReaction заполняется
+// относительно viewerID.
+func (r *PostRepo) GetListPost(viewerID uuid.UUID) ([]*model.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	posts := make([]*model.Post, len(r.posts))
+	for i, post := range r.posts {
+		posts[i] = r.viewPost(post, viewerID)
+	}
+	return posts, nil
+}
+
+// DeleteExpiredPosts удаляет истекшие к now посты вместе с реакциями
+// и ревизиями и возвращает их количество.
+func (r *PostRepo) DeleteExpiredPosts(now time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.posts[:0]
+	for _, post := range r.posts {
+		if !post.Expired(now) {
+			kept = append(kept, post)
+			continue
+		}
+		r.forgetPost(post)
+	}
+
+	purged := len(r.posts) - len(kept)
+	clear(r.posts[len(kept):])
+	r.posts = kept
+	return purged, nil
+}
+
+// DeletePost удаляет пост вместе с реакциями, ревизиями и голосами.
+func (r *PostRepo) DeletePost(postID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, ok := r.byID[postID]
+	if !ok {
+		return model.ErrPostNotFound
+	}
+
+	r.forgetPost(post)
+	r.posts = removePost(r.posts, postID)
+	return nil
+}
+
+// forgetPost убирает пост из всех индексов, кроме общего списка r.posts.
+func (r *PostRepo) forgetPost(post *model.Post) {
+	delete(r.byID, post.ID)
+	delete(r.reactions, post.ID)
+	delete(r.revisions, post.ID)
+	delete(r.pollVoters, post.ID)
+	r.byAuthor[post.AuthorID] = slices.DeleteFunc(r.byAuthor[post.AuthorID], func(entry authorEntry) bool {
+		return entry.post.ID == post.ID
+	})
+	r.pinned[post.AuthorID] = removePost(r.pinned[post.AuthorID], post.ID)
+}
+
+// GetPostsWithAttachment возвращает посты, к которым приложен медиафайл.
+func (r *PostRepo) GetPostsWithAttachment(mediaID uuid.UUID) ([]*model.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var posts []*model.Post
+	for _, post := range r.posts {
+		if slices.Contains(post.AttachmentIDs, mediaID) {
+			posts = append(posts, copyPost(post))
+		}
+	}
+	return posts, nil
+}
+
+// CountPostsByAuthor считает опубликованные посты автора: задержанные
+// и истекшие не учитываются.
+func (r *PostRepo) CountPostsByAuthor(authorID uuid.UUID, now time.Time) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, entry := range r.byAuthor[authorID] {
+		if !entry.post.Held && !entry.post.Expired(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// GetAuthorPosts возвращает страницу ленты автора от новых постов к старым,
+// начиная с постов старше курсора before (0 - с начала). На первой странице
+// перед остальными идут закрепленные посты, последний закрепленный первым;
+// в limit они не входят. Доступ зрителя к постам здесь не проверяется.
+func (r *PostRepo) GetAuthorPosts(
+	authorID uuid.UUID,
+	viewerID uuid.UUID,
+	filter model.AuthorFeedFilter,
+	before int64,
+	limit int,
+) ([]*model.Post, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	posts := make([]*model.Post, 0, limit)
+	if before == 0 {
+		for _, post := range r.pinned[authorID] {
+			if filter.Match(post) {
+				posts = append(posts, r.viewPost(post, viewerID))
+			}
+		}
+	}
+
+	entries := r.byAuthor[authorID]
+	end := len(entries)
+	if before > 0 {
+		end, _ = slices.BinarySearchFunc(entries, before, func(entry authorEntry, seq int64) int {
+			return cmp.Compare(entry.seq, seq)
+		})
+	}
+
+	taken := 0
+	var lastSeq int64
+	for i := end - 1; i >= 0; i-- {
+		entry := entries[i]
+		if !entry.post.PinnedAt.IsZero() || !filter.Match(entry.post) {
+			continue
+		}
+		if taken == limit {
+			return posts, lastSeq, nil
+		}
+		posts = append(posts, r.viewPost(entry.post, viewerID))
+		lastSeq = entry.seq
+		taken++
+	}
+	return posts, 0, nil
+}
+
+// PinPost закрепляет пост в профиле автора. Повторное закрепление
+// ничего не меняет.
+func (r *PostRepo) PinPost(postID uuid.UUID, pinnedAt time.Time, maxPinned int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, ok := r.byID[postID]
+	if !ok {
+		return model.ErrPostNotFound
+	}
+	if !post.PinnedAt.IsZero() {
+		return nil
+	}
+
+	pinned := r.pinned[post.AuthorID]
+	if len(pinned) >= maxPinned {
+		return model.ErrPinLimitReached
+	}
+
+	post.PinnedAt = pinnedAt
+	r.pinned[post.AuthorID] = append([]*model.Post{post}, pinned...)
+	return nil
+}
+
+func (r *PostRepo) UnpinPost(postID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, ok := r.byID[postID]
+	if !ok {
+		return model.ErrPostNotFound
+	}
+
+	post.PinnedAt = time.Time{}
+	r.pinned[post.AuthorID] = removePost(r.pinned[post.AuthorID], postID)
+	return nil
+}
+
+// ReleasePost снимает с поста задержку контент-политики.
+func (r *PostRepo) ReleasePost(postID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, ok := r.byID[postID]
+	if !ok {
+		return model.ErrPostNotFound
+	}
+
+	post.Held = false
+	return nil
 }
 
-func (r *PostRepo) LikePost(like *model.Like) error {
+// MarkPostSensitive помечает пост как деликатный. Пустой contentWarning
+// оставляет предупреждение, заданное автором.
+func (r *PostRepo) MarkPostSensitive(postID uuid.UUID, contentWarning string) (*model.Post, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	for i, post := range r.Posts {
-		if post.ID == like.PostID {
-			var alreadyLiked bool
-			for _, v := range post.Likes {
-				if v == like.UserID {
-					alreadyLiked = true
-				}
+
+	post, ok := r.byID[postID]
+	if !ok {
+		return nil, model.ErrPostNotFound
+	}
+
+	post.Sensitive = true
+	if contentWarning != "" {
+		post.ContentWarning = contentWarning
+	}
+	return copyPost(post), nil
+}
+
+// ReactToPost ставит реакцию пользователя, заменяя предыдущую.
+// Реакция с типом ReactionNone снимает реакцию пользователя.
+func (r *PostRepo) ReactToPost(reaction *model.Reaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reactions, ok := r.reactions[reaction.PostID]
+	if !ok {
+		return model.ErrPostNotFound
+	}
+	post := r.byID[reaction.PostID]
+
+	prev, reacted := reactions.byUser[reaction.UserID]
+	if reacted && prev == reaction.Type {
+		return nil
+	}
+
+	if reacted {
+		reactions.entries = slices.DeleteFunc(reactions.entries, func(e reactionEntry) bool {
+			return e.userID == reaction.UserID
+		})
+		delete(reactions.byUser, reaction.UserID)
+		post.Reactions[prev]--
+		if post.Reactions[prev] == 0 {
+			delete(post.Reactions, prev)
+		}
+	}
+
+	if reaction.Type == model.ReactionNone {
+		return nil
+	}
+
+	r.reactionSeq++
+	reactions.entries = append(reactions.entries, reactionEntry{
+		userID:       reaction.UserID,
+		reactionType: reaction.Type,
+		seq:          r.reactionSeq,
+	})
+	reactions.byUser[reaction.UserID] = reaction.Type
+	post.Reactions[reaction.Type]++
+	return nil
+}
+
+// CountReactionsBy считает по каждому посту из postIDs реакции
+// пользователей userIDs. Посты без таких реакций в ответ не попадают.
+func (r *PostRepo) CountReactionsBy(postIDs, userIDs []uuid.UUID) (map[uuid.UUID]map[model.ReactionType]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[uuid.UUID]map[model.ReactionType]int)
+	for _, postID := range postIDs {
+		reactions, ok := r.reactions[postID]
+		if !ok {
+			continue
+		}
+		for _, userID := range userIDs {
+			reactionType := reactions.byUser[userID]
+			if reactionType == model.ReactionNone {
+				continue
 			}
-			if !alreadyLiked {
-				r.Posts[i].Likes = append(r.Posts[i].Likes, like.UserID)
+			if counts[postID] == nil {
+				counts[postID] = make(map[model.ReactionType]int)
 			}
-			return nil
+			counts[postID][reactionType]++
+		}
+	}
+	return counts, nil
+}
+
+// GetPostReactions возвращает до limit реакций после курсора after и курсор
+// следующей страницы (ноль, если дальше ничего нет). Если reactionType не пустой,
+// возвращаются только реакции этого типа.
+func (r *PostRepo) GetPostReactions(
+	postID uuid.UUID,
+	reactionType model.ReactionType,
+	after int64,
+	limit int,
+) ([]*model.Reaction, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reactions, ok := r.reactions[postID]
+	if !ok {
+		return nil, 0, model.ErrPostNotFound
+	}
+
+	page := make([]*model.Reaction, 0, limit)
+	var lastSeq, next int64
+	for _, entry := range reactions.entries {
+		if entry.seq <= after {
+			continue
+		}
+		if reactionType != model.ReactionNone && entry.reactionType != reactionType {
+			continue
+		}
+		if len(page) == limit {
+			next = lastSeq
+			break
+		}
+		page = append(page, &model.Reaction{
+			UserID: entry.userID,
+			PostID: postID,
+			Type:   entry.reactionType,
+		})
+		lastSeq = entry.seq
+	}
+	return page, next, nil
+}
+
+// viewPost копирует пост и дополняет его полями, зависящими от зрителя.
+// Вызывается под блокировкой.
+func (r *PostRepo) viewPost(post *model.Post, viewerID uuid.UUID) *model.Post {
+	cp := copyPost(post)
+	if reactions, ok := r.reactions[post.ID]; ok {
+		cp.MyReaction = reactions.byUser[viewerID]
+	}
+	if cp.Poll != nil {
+		cp.Poll.MyChoices = slices.Clone(r.pollVoters[post.ID][viewerID])
+	}
+	return cp
+}
+
+// VotePoll учитывает голос в опросе поста. Каждый пользователь голосует
+// один раз: повторный голос отклоняется, даже если пришел одновременно
+// с первым.
+func (r *PostRepo) VotePoll(vote *model.PollVote) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, ok := r.byID[vote.PostID]
+	if !ok {
+		return model.ErrPostNotFound
+	}
+	if post.Poll == nil {
+		return model.ErrPollNotFound
+	}
+	if post.Poll.Closed(vote.VotedAt) {
+		return model.ErrPollClosed
+	}
+
+	voters := r.pollVoters[vote.PostID]
+	if _, voted := voters[vote.UserID]; voted {
+		return model.ErrAlreadyVoted
+	}
+
+	for _, choice := range vote.Choices {
+		if choice < 0 || choice >= len(post.Poll.Options) {
+			return model.ErrInvalidVote
 		}
 	}
-	return model.ErrPostNotFound
+
+	for _, choice := range vote.Choices {
+		post.Poll.Options[choice].Votes++
+	}
+	post.Poll.VotersCount++
+	voters[vote.UserID] = slices.Clone(vote.Choices)
+	return nil
+}
+
+func removePost(posts []*model.Post, postID uuid.UUID) []*model.Post {
+	return slices.DeleteFunc(posts, func(post *model.Post) bool {
+		return post.ID == postID
+	})
+}
+
+// copyPost делает глубокую копию поста, чтобы наружу не утекали
+// данные, которые репозиторий меняет под своей блокировкой.
+func copyPost(post *model.Post) *model.Post {
+	cp := *post
+	if post.Reactions != nil {
+		cp.Reactions = maps.Clone(post.Reactions)
+	}
+	cp.AttachmentIDs = slices.Clone(post.AttachmentIDs)
+	cp.SensitiveAttachmentIDs = slices.Clone(post.SensitiveAttachmentIDs)
+	cp.Entities = slices.Clone(post.Entities)
+	if post.Poll != nil {
+		poll := *post.Poll
+		poll.Options = slices.Clone(post.Poll.Options)
+		poll.MyChoices = slices.Clone(post.Poll.MyChoices)
+		cp.Poll = &poll
+	}
+	return &cp
 }
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/model"
+)
+
+// relationList хранит блокировки или заглушения одного пользователя в
+// порядке добавления. seq у записи монотонно растет и служит курсором
+// пагинации.
+type relationList struct {
+	entries  []relationEntry
+	byTarget map[uuid.UUID]struct{}
+}
+
+type relationEntry struct {
+	relation model.Relation
+	seq      int64
+}
+
+// Block блокирует пользователя и в той же операции снимает подписки и
+// запросы на них в обе стороны. Повторная блокировка ничего не меняет.
+func (r *FollowRepo) Block(relation *model.Relation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.addRelation(r.blocks, relation)
+
+	delete(r.following[relation.OwnerID], relation.TargetID)
+	delete(r.followers[relation.TargetID], relation.OwnerID)
+	delete(r.following[relation.TargetID], relation.OwnerID)
+	delete(r.followers[relation.OwnerID], relation.TargetID)
+	removeRelation(r.requests, relation.OwnerID, relation.TargetID)
+	removeRelation(r.requests, relation.TargetID, relation.OwnerID)
+	return nil
+}
+
+func (r *FollowRepo) Unblock(ownerID, targetID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	removeRelation(r.blocks, ownerID, targetID)
+	return nil
+}
+
+// IsBlocked сообщает, заблокировал ли хотя бы один из пользователей другого.
+func (r *FollowRepo) IsBlocked(userID, otherID uuid.UUID) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return hasRelation(r.blocks, userID, otherID) || hasRelation(r.blocks, otherID, userID)
+}
+
+func (r *FollowRepo) ListBlocked(ownerID uuid.UUID, before int64, limit int) ([]*model.Relation, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	page, next := listRelations(r.blocks[ownerID], before, limit)
+	return page, next, nil
+}
+
+// Mute заглушает пользователя. Повторное заглушение ничего не меняет.
+func (r *FollowRepo) Mute(relation *model.Relation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.addRelation(r.mutes, relation)
+	return nil
+}
+
+func (r *FollowRepo) Unmute(ownerID, targetID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	removeRelation(r.mutes, ownerID, targetID)
+	return nil
+}
+
+func (r *FollowRepo) IsMuted(ownerID, targetID uuid.UUID) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return hasRelation(r.mutes, ownerID, targetID)
+}
+
+func (r *FollowRepo) ListMuted(ownerID uuid.UUID, before int64, limit int) ([]*model.Relation, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	page, next := listRelations(r.mutes[ownerID], before, limit)
+	return page, next, nil
+}
+
+func (r *FollowRepo) addRelation(relations map[uuid.UUID]*relationList, relation *model.Relation) {
+	list, ok := relations[relation.OwnerID]
+	if !ok {
+		list = &relationList{byTarget: make(map[uuid.UUID]struct{})}
+		relations[relation.OwnerID] = list
+	}
+
+	if _, ok = list.byTarget[relation.TargetID]; ok {
+		return
+	}
+
+	r.seq++
+	list.entries = append(list.entries, relationEntry{relation: *relation, seq: r.seq})
+	list.byTarget[relation.TargetID] = struct{}{}
+}
+
+func removeRelation(relations map[uuid.UUID]*relationList, ownerID, targetID uuid.UUID) {
+	list, ok := relations[ownerID]
+	if !ok {
+		return
+	}
+
+	delete(list.byTarget, targetID)
+	list.entries = slices.DeleteFunc(list.entries, func(entry relationEntry) bool {
+		return entry.relation.TargetID == targetID
+	})
+}
+
+func hasRelation(relations map[uuid.UUID]*relationList, ownerID, targetID uuid.UUID) bool {
+	list, ok := relations[ownerID]
+	if !ok {
+		return false
+	}
+	_, ok = list.byTarget[targetID]
+	return ok
+}
+
+// listRelations возвращает записи от новых к старым, начиная с записей
+// старше курсора before (0 - с начала).
+func listRelations(list *relationList, before int64, limit int) ([]*model.Relation, int64) {
+	if list == nil {
+		return []*model.Relation{}, 0
+	}
+
+	end := len(list.entries)
+	if before > 0 {
+		end, _ = slices.BinarySearchFunc(list.entries, before, func(entry relationEntry, seq int64) int {
+			return cmp.Compare(entry.seq, seq)
+		})
+	}
+
+	start := max(end-limit, 0)
+	page := make([]*model.Relation, 0, end-start)
+	for i := end - 1; i >= start; i-- {
+		relation := list.entries[i].relation
+		page = append(page, &relation)
+	}
+
+	var next int64
+	if start > 0 {
+		next = list.entries[start].seq
+	}
+	return page, next
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/model"
+)
+
+type ReportRepo struct {
+	reports []*reportEntry
+	byID    map[uuid.UUID]*reportEntry
+	open    map[reportKey]uuid.UUID
+	seq     int64
+	mu      sync.RWMutex
+}
+
+// reportEntry хранит жалобу в порядке поступления. seq монотонно растет
+// и служит курсором пагинации очереди.
+type reportEntry struct {
+	report model.Report
+	seq    int64
+}
+
+// reportKey связывает автора жалобы с ее целью: на одну цель у автора
+// может быть только одна открытая жалоба.
+type reportKey struct {
+	reporterID uuid.UUID
+	targetType model.ReportTarget
+	targetID   uuid.UUID
+}
+
+func NewReportRepo() *ReportRepo {
+	return &ReportRepo{
+		byID: make(map[uuid.UUID]*reportEntry),
+		open: make(map[reportKey]uuid.UUID),
+		mu:   sync.RWMutex{},
+	}
+}
+
+func (r *ReportRepo) CreateReport(report *model.Report) (*model.Report, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := reportKey{reporterID: report.ReporterID, targetType: report.TargetType, targetID: report.TargetID}
+	if _, ok := r.open[key]; ok {
+		return nil, model.ErrAlreadyReported
+	}
+
+	stored := *report
+	stored.ID = uuid.New()
+	stored.Status = model.ReportStatusOpen
+	stored.Actions = nil
+
+	r.seq++
+	entry := &reportEntry{report: stored, seq: r.seq}
+	r.reports = append(r.reports, entry)
+	r.byID[stored.ID] = entry
+	r.open[key] = stored.ID
+
+	return copyReport(&entry.report), nil
+}
+
+func (r *ReportRepo) GetReport(reportID uuid.UUID) (*model.Report, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.byID[reportID]
+	if !ok {
+		return nil, model.ErrReportNotFound
+	}
+	return copyReport(&entry.report), nil
+}
+
+// ListReports возвращает жалобы со статусом status (пустой - любые) от
+// новых к старым, начиная с жалоб старше курсора before (0 - с начала).
+func (r *ReportRepo) ListReports(status model.ReportStatus, before int64, limit int) ([]*model.Report, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	end := len(r.reports)
+	if before > 0 {
+		end, _ = slices.BinarySearchFunc(r.reports, before, func(entry *reportEntry, seq int64) int {
+			return cmp.Compare(entry.seq, seq)
+		})
+	}
+
+	page := make([]*model.Report, 0, limit)
+	var lastSeq int64
+	for i := end - 1; i >= 0; i-- {
+		entry := r.reports[i]
+		if status != "" && entry.report.Status != status {
+			continue
+		}
+		if len(page) == limit {
+			return page, lastSeq, nil
+		}
+		page = append(page, copyReport(&entry.report))
+		lastSeq = entry.seq
+	}
+	return page, 0, nil
+}
+
+// AddModerationAction записывает действие модератора и, если status не
+// пустой, закрывает жалобу с этим статусом. Закрытую жалобу менять нельзя.
+func (r *ReportRepo) AddModerationAction(action *model.ModerationAction, status model.ReportStatus) (*model.Report, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.byID[action.ReportID]
+	if !ok {
+		return nil, model.ErrReportNotFound
+	}
+
+	report := &entry.report
+	if report.Status != model.ReportStatusOpen {
+		return nil, model.ErrReportClosed
+	}
+
+	report.Actions = append(report.Actions, *action)
+	if status != "" {
+		report.Status = status
+		report.ClosedAt = action.CreatedAt
+		delete(r.open, reportKey{reporterID: report.ReporterID, targetType: report.TargetType, targetID: report.TargetID})
+	}
+
+	return copyReport(report), nil
+}
+
+// RevertModerationAction отменяет последнее действие модератора и снова
+// открывает жалобу. Используется, когда записанное решение не удалось
+// применить.
+func (r *ReportRepo) RevertModerationAction(reportID uuid.UUID) (*model.Report, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.byID[reportID]
+	if !ok {
+		return nil, model.ErrReportNotFound
+	}
+
+	report := &entry.report
+	if len(report.Actions) > 0 {
+		report.Actions = report.Actions[:len(report.Actions)-1]
+	}
+	report.Status = model.ReportStatusOpen
+	report.ClosedAt = time.Time{}
+	key := reportKey{reporterID: report.ReporterID, targetType: report.TargetType, targetID: report.TargetID}
+	if _, ok = r.open[key]; !ok {
+		r.open[key] = report.ID
+	}
+
+	return copyReport(report), nil
+}
+
+func copyReport(report *model.Report) *model.Report {
+	cp := *report
+	cp.Actions = slices.Clone(report.Actions)
+	return &cp
+}
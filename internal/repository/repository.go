@@ -3,11 +3,29 @@ package repository
 type Repository struct {
 	*UserRepo
 	*PostRepo
+	*FollowRepo
+	*MediaRepo
+	*DraftRepo
+	*BookmarkRepo
+	*ConversationRepo
+	*ReportRepo
+	*SpamRepo
+	*AuditRepo
+	*TokenRepo
 }
 
 func NewRepository() *Repository {
 	return &Repository{
-		UserRepo: NewUserRepo(),
-		PostRepo: NewPostRepo(),
+		UserRepo:         NewUserRepo(),
+		PostRepo:         NewPostRepo(),
+		FollowRepo:       NewFollowRepo(),
+		MediaRepo:        NewMediaRepo(),
+		DraftRepo:        NewDraftRepo(),
+		BookmarkRepo:     NewBookmarkRepo(),
+		ConversationRepo: NewConversationRepo(),
+		ReportRepo:       NewReportRepo(),
+		SpamRepo:         NewSpamRepo(),
+		AuditRepo:        NewAuditRepo(),
+		TokenRepo:        NewTokenRepo(),
 	}
 }
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/model"
+)
+
+type SpamRepo struct {
+	flags map[uuid.UUID]*spamEntry
+	seq   int64
+	mu    sync.RWMutex
+}
+
+// spamEntry хранит подозрения аккаунта. seq присваивается при первом
+// подозрении и не меняется, поэтому служит устойчивым курсором: недавно
+// замеченные аккаунты идут первыми. decayedAt - момент, до которого
+// забывание очков уже учтено.
+type spamEntry struct {
+	flag      model.SpamFlag
+	seq       int64
+	decayedAt time.Time
+}
+
+func NewSpamRepo() *SpamRepo {
+	return &SpamRepo{
+		flags: make(map[uuid.UUID]*spamEntry),
+		mu:    sync.RWMutex{},
+	}
+}
+
+// AddSpamSignals прибавляет к счету пользователя points очков и отмечает
+// сработавшие признаки. Перед этим счет уменьшается на одно очко за каждый
+// полный период decay с прошлого учета; нулевой decay отключает забывание.
+func (r *SpamRepo) AddSpamSignals(
+	userID uuid.UUID,
+	signals []model.SpamSignal,
+	points int,
+	ip string,
+	now time.Time,
+	decay time.Duration,
+) (*model.SpamFlag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := r.entry(userID, now)
+	if decay > 0 && now.After(entry.decayedAt) {
+		periods := int64(now.Sub(entry.decayedAt) / decay)
+		entry.flag.Score = int(max(int64(entry.flag.Score)-periods, 0))
+		entry.decayedAt = entry.decayedAt.Add(time.Duration(periods) * decay)
+	}
+	entry.flag.Score += points
+	for _, signal := range signals {
+		entry.flag.Signals[signal]++
+	}
+	if ip != "" {
+		entry.flag.LastIP = ip
+	}
+	r.touch(entry, now)
+
+	return copySpamFlag(&entry.flag), nil
+}
+
+// QuarantineUser помещает пользователя в карантин. Повторный вызов
+// сохраняет время первого помещения.
+func (r *SpamRepo) QuarantineUser(userID uuid.UUID, now time.Time) (*model.SpamFlag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := r.entry(userID, now)
+	if entry.flag.QuarantinedAt.IsZero() {
+		entry.flag.QuarantinedAt = now
+	}
+	r.touch(entry, now)
+
+	return copySpamFlag(&entry.flag), nil
+}
+
+func (r *SpamRepo) IsQuarantined(userID uuid.UUID) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.flags[userID]
+	return ok && entry.flag.Quarantined()
+}
+
+// ListSpamFlags возвращает подозрения от недавно замеченных к давним,
+// начиная с более давних, чем курсор before (0 - с начала).
+// quarantinedOnly оставляет только аккаунты в карантине.
+func (r *SpamRepo) ListSpamFlags(quarantinedOnly bool, before int64, limit int) ([]*model.SpamFlag, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := slices.SortedFunc(maps.Values(r.flags), func(a, b *spamEntry) int {
+		return cmp.Compare(b.seq, a.seq)
+	})
+
+	page := make([]*model.SpamFlag, 0, limit)
+	var lastSeq int64
+	for _, entry := range entries {
+		if before > 0 && entry.seq >= before {
+			continue
+		}
+		if quarantinedOnly && !entry.flag.Quarantined() {
+			continue
+		}
+		if len(page) == limit {
+			return page, lastSeq, nil
+		}
+		page = append(page, copySpamFlag(&entry.flag))
+		lastSeq = entry.seq
+	}
+	return page, 0, nil
+}
+
+// ClearSpamFlag снимает с пользователя подозрения и карантин.
+func (r *SpamRepo) ClearSpamFlag(userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.flags[userID]; !ok {
+		return model.ErrSpamFlagNotFound
+	}
+	delete(r.flags, userID)
+	return nil
+}
+
+func (r *SpamRepo) entry(userID uuid.UUID, now time.Time) *spamEntry {
+	entry, ok := r.flags[userID]
+	if !ok {
+		r.seq++
+		entry = &spamEntry{
+			flag: model.SpamFlag{
+				UserID:    userID,
+				Signals:   make(map[model.SpamSignal]int),
+				FlaggedAt: now,
+			},
+			seq:       r.seq,
+			decayedAt: now,
+		}
+		r.flags[userID] = entry
+	}
+	return entry
+}
+
+func (r *SpamRepo) touch(entry *spamEntry, now time.Time) {
+	entry.flag.UpdatedAt = now
+}
+
+func copySpamFlag(flag *model.SpamFlag) *model.SpamFlag {
+	cp := *flag
+	cp.Signals = maps.Clone(flag.Signals)
+	return &cp
+}
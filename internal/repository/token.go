@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/model"
+)
+
+// TokenRepo хранит хеши ключей доступа сотрудников.
+type TokenRepo struct {
+	byHash map[string]*model.AccessToken
+	mu     sync.RWMutex
+}
+
+func NewTokenRepo() *TokenRepo {
+	return &TokenRepo{
+		byHash: make(map[string]*model.AccessToken),
+		mu:     sync.RWMutex{},
+	}
+}
+
+func (r *TokenRepo) CreateAccessToken(token *model.AccessToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *token
+	r.byHash[token.Hash] = &stored
+	return nil
+}
+
+func (r *TokenRepo) GetAccessToken(hash string) (*model.AccessToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	token, ok := r.byHash[hash]
+	if !ok {
+		return nil, model.ErrInvalidToken
+	}
+	cp := *token
+	return &cp, nil
+}
+
+// DeleteAccessTokens отзывает все ключи пользователя и возвращает их
+// количество.
+func (r *TokenRepo) DeleteAccessTokens(userID uuid.UUID) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for hash, token := range r.byHash {
+		if token.UserID == userID {
+			delete(r.byHash, hash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
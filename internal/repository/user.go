@@ -2,43 +2,74 @@ package repository
 
 import (
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
+	"micro-blog/internal/clock"
 	"micro-blog/internal/model"
 )
 
 type UserRepo struct {
-	Users map[string]*model.User
-	mu    sync.RWMutex
+	users   map[string]*model.User
+	byID    map[uuid.UUID]*model.User
+	aliases map[string]nameAlias
+	clock   clock.Clock
+	mu      sync.RWMutex
+}
+
+// nameAlias - старое имя переименованного пользователя,
+// удерживаемое за ним до until.
+type nameAlias struct {
+	userID uuid.UUID
+	until  time.Time
 }
 
 func NewUserRepo() *UserRepo {
 	return &UserRepo{
-		Users: make(map[string]*model.User),
-		mu:    sync.RWMutex{},
+		users:   make(map[string]*model.User),
+		byID:    make(map[uuid.UUID]*model.User),
+		aliases: make(map[string]nameAlias),
+		clock:   clock.Real{},
+		mu:      sync.RWMutex{},
 	}
 }
 
+// SetClock подменяет источник времени, по которому истекает удержание
+// старых имен.
+func (r *UserRepo) SetClock(c clock.Clock) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clock = c
+}
+
 func (r *UserRepo) CreateUser(user *model.User) (*model.User, error) {
 	id, err := uuid.NewUUID()
 	if err != nil {
 		return nil, err
 	}
 
-	user.ID = id
+	stored := copyUser(user)
+	stored.ID = id
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.Users[user.Name] = user
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = r.clock.Now()
+	}
+	if r.nameBusy(stored.Name, uuid.Nil) {
+		return nil, model.ErrUsernameTaken
+	}
+	r.users[stored.Name] = stored
+	r.byID[id] = stored
 
-	return user, nil
+	return copyUser(stored), nil
 }
 
 func (r *UserRepo) GetUserByName(name string) (*model.User, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	if val, ok := r.Users[name]; ok {
-		return val, nil
+	if val, ok := r.users[name]; ok {
+		return copyUser(val), nil
 	}
 
 	return nil, model.ErrUserNotFound
@@ -47,11 +78,212 @@ func (r *UserRepo) GetUserByName(name string) (*model.User, error) {
 func (r *UserRepo) GetUserById(id uuid.UUID) (*model.User, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	for _, val := range r.Users {
-		if val.ID == id {
-			return val, nil
-		}
+	if val, ok := r.byID[id]; ok {
+		return copyUser(val), nil
+	}
+
+	return nil, model.ErrUserNotFound
+}
+
+// GetUserByAlias ищет пользователя по старому имени, пока оно удерживается.
+func (r *UserRepo) GetUserByAlias(name string) (*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	alias, ok := r.aliases[name]
+	if !ok || !r.clock.Now().Before(alias.until) {
+		return nil, model.ErrUserNotFound
 	}
 
+	if val, ok := r.byID[alias.userID]; ok {
+		return copyUser(val), nil
+	}
 	return nil, model.ErrUserNotFound
 }
+
+// RenameUser меняет имя пользователя и удерживает старое имя за ним до holdUntil.
+// Свое же удерживаемое имя можно вернуть обратно.
+func (r *UserRepo) RenameUser(id uuid.UUID, newName string, holdUntil time.Time) (*model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.byID[id]
+	if !ok {
+		return nil, model.ErrUserNotFound
+	}
+
+	if user.Name == newName {
+		return copyUser(user), nil
+	}
+
+	if r.nameBusy(newName, id) {
+		return nil, model.ErrUsernameTaken
+	}
+
+	oldName := user.Name
+	delete(r.users, oldName)
+	delete(r.aliases, newName)
+
+	user.Name = newName
+	r.users[newName] = user
+	r.aliases[oldName] = nameAlias{userID: id, until: holdUntil}
+
+	return copyUser(user), nil
+}
+
+// nameBusy проверяет, занято ли имя другим пользователем или удерживается ли
+// оно за кем-то, кроме owner. Просроченные удержания удаляются.
+// Вызывается под блокировкой на запись.
+func (r *UserRepo) nameBusy(name string, owner uuid.UUID) bool {
+	if _, ok := r.users[name]; ok {
+		return true
+	}
+
+	alias, ok := r.aliases[name]
+	if !ok {
+		return false
+	}
+	if !r.clock.Now().Before(alias.until) {
+		delete(r.aliases, name)
+		return false
+	}
+	return alias.userID != owner
+}
+
+func (r *UserRepo) UpdateUser(id uuid.UUID, update *model.ProfileUpdate) (*model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.byID[id]
+	if !ok {
+		return nil, model.ErrUserNotFound
+	}
+
+	setIfPresent(&user.DisplayName, update.DisplayName)
+	setIfPresent(&user.Bio, update.Bio)
+	setIfPresent(&user.Location, update.Location)
+	setIfPresent(&user.Website, update.Website)
+	setIfPresent(&user.AvatarID, update.AvatarID)
+	if update.Private != nil {
+		user.Private = *update.Private
+	}
+	if update.ExpandSensitive != nil {
+		user.ExpandSensitive = *update.ExpandSensitive
+	}
+
+	return copyUser(user), nil
+}
+
+// SuspendUser блокирует аккаунт до until с указанной причиной. Нулевой
+// until снимает блокировку.
+func (r *UserRepo) SuspendUser(id uuid.UUID, reason string, until time.Time) (*model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.byID[id]
+	if !ok {
+		return nil, model.ErrUserNotFound
+	}
+
+	user.SuspendedUntil = until
+	user.SuspensionReason = reason
+	return copyUser(user), nil
+}
+
+// ShadowBanUser включает теневой бан до until с указанной причиной.
+// Нулевой until снимает бан.
+func (r *UserRepo) ShadowBanUser(id uuid.UUID, reason string, until time.Time) (*model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.byID[id]
+	if !ok {
+		return nil, model.ErrUserNotFound
+	}
+
+	user.ShadowBannedUntil = until
+	user.ShadowBanReason = reason
+	return copyUser(user), nil
+}
+
+// ListShadowBanned возвращает ID пользователей, теневой бан которых
+// действует в момент now.
+func (r *UserRepo) ListShadowBanned(now time.Time) ([]uuid.UUID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, user := range r.byID {
+		if user.ShadowBanned(now) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// LiftExpiredSanctions снимает блокировки и теневые баны, срок которых
+// истек к now, и возвращает число пользователей, с которых они сняты.
+func (r *UserRepo) LiftExpiredSanctions(now time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var lifted int
+	for _, user := range r.byID {
+		changed := false
+		if !user.SuspendedUntil.IsZero() && !user.Suspended(now) {
+			user.SuspendedUntil = time.Time{}
+			user.SuspensionReason = ""
+			changed = true
+		}
+		if !user.ShadowBannedUntil.IsZero() && !user.ShadowBanned(now) {
+			user.ShadowBannedUntil = time.Time{}
+			user.ShadowBanReason = ""
+			changed = true
+		}
+		if changed {
+			lifted++
+		}
+	}
+	return lifted, nil
+}
+
+// SetRole меняет роль пользователя. Понизить последнего администратора
+// нельзя: иначе управлять ролями станет некому.
+func (r *UserRepo) SetRole(id uuid.UUID, role model.Role) (*model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.byID[id]
+	if !ok {
+		return nil, model.ErrUserNotFound
+	}
+
+	if user.Role == model.RoleAdmin && role != model.RoleAdmin && r.countAdmins() == 1 {
+		return nil, model.ErrLastAdmin
+	}
+
+	user.Role = role
+	return copyUser(user), nil
+}
+
+// countAdmins вызывается под блокировкой.
+func (r *UserRepo) countAdmins() int {
+	var count int
+	for _, user := range r.byID {
+		if user.Role == model.RoleAdmin {
+			count++
+		}
+	}
+	return count
+}
+
+func setIfPresent(dst *string, src *string) {
+	if src != nil {
+		*dst = *src
+	}
+}
+
+func copyUser(user *model.User) *model.User {
+	cp := *user
+	return &cp
+}
@@ -0,0 +1,130 @@
+package richtext
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"micro-blog/internal/model"
+)
+
+var (
+	urlRe     = regexp.MustCompile(`https?://[^\s<>"]+`)
+	mentionRe = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@])@([A-Za-z0-9_]{3,30})\b`)
+	hashtagRe = regexp.MustCompile(`(?:^|[^\p{L}\p{M}\p{N}_&#])#([\p{L}\p{M}\p{N}_]*\p{L}[\p{L}\p{M}\p{N}_]*)`)
+)
+
+// urlTrailing - знаки препинания, которые обычно закрывают предложение,
+// а не входят в ссылку.
+const urlTrailing = `.,!?:;'")]`
+
+// Sanitize приводит переводы строк к \n, удаляет управляющие символы
+// (кроме перевода строки) и невидимые символы форматирования: переопределение
+// направления текста, пробелы нулевой ширины, BOM. Соединители нулевой
+// ширины остаются только между видимыми символами, где они склеивают эмодзи
+// и буквы, а теги - только внутри эмодзи флагов. Пробелы по краям обрезаются.
+func Sanitize(text string) string {
+	runes := []rune(strings.ReplaceAll(text, "\r\n", "\n"))
+
+	var b strings.Builder
+	b.Grow(len(text))
+	var prev rune
+	for i, r := range runes {
+		switch {
+		case r == '\n':
+		case r == '\t':
+			r = ' '
+		case unicode.IsControl(r):
+			continue
+		case r == zwj || r == zwnj:
+			if i+1 == len(runes) || !visible(prev) || !visible(runes[i+1]) {
+				continue
+			}
+		case r >= tagFirst && r <= tagLast:
+			if !visible(prev) && !(prev >= tagFirst && prev <= tagLast) {
+				continue
+			}
+		case unicode.Is(unicode.Cf, r):
+			continue
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+const (
+	zwnj = '\u200C'
+	zwj  = '\u200D'
+	// Теги уточняют эмодзи флага, например флаг Англии.
+	tagFirst = '\U000E0020'
+	tagLast  = '\U000E007F'
+)
+
+// visible сообщает, что r - видимый символ: не пробел, не управляющий
+// и не символ форматирования.
+func visible(r rune) bool {
+	return r != 0 && !unicode.IsSpace(r) && !unicode.IsControl(r) && !unicode.Is(unicode.Cf, r)
+}
+
+// Length - длина текста в графемах, то есть в символах, которые видит читатель:
+// эмодзи с модификаторами и буквы с диакритикой считаются за один.
+func Length(text string) int {
+	return uniseg.GraphemeClusterCount(text)
+}
+
+// Parse находит в тексте ссылки, упоминания и хештеги. Смещения сущностей
+// задаются в кодовых точках Unicode. UserID у упоминаний не заполняется.
+func Parse(text string) []model.Entity {
+	var entities []model.Entity
+
+	urls := urlRe.FindAllStringIndex(text, -1)
+	for _, loc := range urls {
+		raw := strings.TrimRight(text[loc[0]:loc[1]], urlTrailing)
+		loc[1] = loc[0] + len(raw)
+		entities = append(entities, newEntity(text, model.EntityURL, loc[0], loc[1], raw))
+	}
+
+	for _, loc := range mentionRe.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[2]-1, loc[3]
+		if !insideAny(urls, start) {
+			entities = append(entities, newEntity(text, model.EntityMention, start, end, text[loc[2]:loc[3]]))
+		}
+	}
+
+	for _, loc := range hashtagRe.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[2]-1, loc[3]
+		if !insideAny(urls, start) {
+			entities = append(entities, newEntity(text, model.EntityHashtag, start, end, text[loc[2]:loc[3]]))
+		}
+	}
+
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i].Start < entities[j].Start
+	})
+	return entities
+}
+
+// newEntity переводит байтовые смещения в смещения в кодовых точках.
+func newEntity(text string, entityType model.EntityType, start, end int, value string) model.Entity {
+	runeStart := utf8.RuneCountInString(text[:start])
+	return model.Entity{
+		Type:  entityType,
+		Start: runeStart,
+		End:   runeStart + utf8.RuneCountInString(text[start:end]),
+		Text:  value,
+	}
+}
+
+func insideAny(ranges [][]int, pos int) bool {
+	for _, r := range ranges {
+		if pos >= r[0] && pos < r[1] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package richtext_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"micro-blog/internal/model"
+	"micro-blog/internal/richtext"
+)
+
+func TestLength(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{name: "empty", text: "", want: 0},
+		{name: "ascii", text: "hello", want: 5},
+		{name: "cyrillic", text: "привет", want: 6},
+		{name: "zwj family", text: "👨\u200D👩\u200D👧", want: 1},
+		{name: "skin tone modifier", text: "👍\U0001F3FD", want: 1},
+		{name: "flag", text: "🇷🇺", want: 1},
+		{name: "combining acute", text: "Cafe\u0301", want: 4},
+		{name: "stacked combining marks", text: "a\u0301\u0323b", want: 2},
+		{name: "hangul jamo", text: "\u1100\u1161", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, richtext.Length(tt.text))
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "crlf", text: "a\r\nb", want: "a\nb"},
+		{name: "tab", text: "a\tb", want: "a b"},
+		{name: "control characters", text: "a\x00b\x07c\x7f", want: "abc"},
+		{name: "trim", text: "  hi \n", want: "hi"},
+		{name: "rlo", text: "invoice\u202Efdp.exe", want: "invoicefdp.exe"},
+		{name: "lre and pdf", text: "\u202Aabc\u202C", want: "abc"},
+		{name: "lri and pdi", text: "\u2066name\u2069", want: "name"},
+		{name: "rli and fsi", text: "\u2067a\u2068b\u2069", want: "ab"},
+		{name: "zwj kept", text: "👨\u200D👩\u200D👧", want: "👨\u200D👩\u200D👧"},
+		{name: "combining mark kept", text: "e\u0301", want: "e\u0301"},
+		{name: "zero width space, word joiner and bom", text: "\uFEFFa\u200Bb\u2060c", want: "abc"},
+		{name: "invisible only", text: "\u200B\u2060\uFEFF\u200D", want: ""},
+		{name: "lrm and soft hyphen", text: "a\u200Eb\u00ADc", want: "abc"},
+		{name: "dangling zwj", text: "\u200Dhi \u200D\u200Dthere\u200D", want: "hi there"},
+		{name: "zwnj between letters kept", text: "می\u200Cخواهم", want: "می\u200Cخواهم"},
+		{name: "emoji with zwj and variation selector", text: "❤\uFE0F\u200D🔥", want: "❤\uFE0F\u200D🔥"},
+		{name: "flag tags kept", text: "🏴\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F", want: "🏴\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F"},
+		{name: "stray tags", text: "a \U000E0067b", want: "a b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, richtext.Sanitize(tt.text))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	url := func(start, end int, text string) model.Entity {
+		return model.Entity{Type: model.EntityURL, Start: start, End: end, Text: text}
+	}
+	mention := func(start, end int, text string) model.Entity {
+		return model.Entity{Type: model.EntityMention, Start: start, End: end, Text: text}
+	}
+	hashtag := func(start, end int, text string) model.Entity {
+		return model.Entity{Type: model.EntityHashtag, Start: start, End: end, Text: text}
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want []model.Entity
+	}{
+		{name: "plain text", text: "nothing here", want: nil},
+
+		{name: "url", text: "see https://example.com/a", want: []model.Entity{url(4, 25, "https://example.com/a")}},
+		{name: "url trailing punctuation", text: "see https://example.com/a.", want: []model.Entity{url(4, 25, "https://example.com/a")}},
+		{name: "url in parentheses", text: "(https://example.com)", want: []model.Entity{url(1, 20, "https://example.com")}},
+		{name: "url keeps query", text: "http://x.io/?q=1&p=2", want: []model.Entity{url(0, 20, "http://x.io/?q=1&p=2")}},
+		{name: "url needs scheme", text: "example.com", want: nil},
+		{name: "url fragment is not a hashtag", text: "https://example.com/#top", want: []model.Entity{url(0, 24, "https://example.com/#top")}},
+		{name: "url path is not a mention", text: "https://example.com/@bob", want: []model.Entity{url(0, 24, "https://example.com/@bob")}},
+
+		{name: "mention", text: "@bob", want: []model.Entity{mention(0, 4, "bob")}},
+		{name: "mention before punctuation", text: "hi @bob_1!", want: []model.Entity{mention(3, 9, "bob_1")}},
+		{name: "mention too short", text: "@al", want: nil},
+		{name: "email is not a mention", text: "mail bob@example.com", want: nil},
+		{name: "double at", text: "@@bob", want: nil},
+		{name: "mention after emoji", text: "👍@bob", want: []model.Entity{mention(1, 5, "bob")}},
+
+		{name: "hashtag", text: "#go is fun", want: []model.Entity{hashtag(0, 3, "go")}},
+		{name: "cyrillic hashtag", text: "привет #тег", want: []model.Entity{hashtag(7, 11, "тег")}},
+		{name: "digits only", text: "#2024", want: nil},
+		{name: "digits and letters", text: "#2024год", want: []model.Entity{hashtag(0, 8, "2024год")}},
+		{name: "inside a word", text: "a#tag", want: nil},
+		{name: "after a combining mark", text: "e\u0301#tag", want: nil},
+		{name: "html entity", text: "&#39;", want: nil},
+		{name: "hashtag after zwj emoji", text: "👨\u200D👩\u200D👧 #tag", want: []model.Entity{hashtag(6, 10, "tag")}},
+		{name: "hashtag with combining mark", text: "#cafe\u0301 ok", want: []model.Entity{hashtag(0, 6, "cafe\u0301")}},
+
+		{
+			name: "mixed",
+			text: "@ann see https://a.io #news",
+			want: []model.Entity{mention(0, 4, "ann"), url(9, 21, "https://a.io"), hashtag(22, 27, "news")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, richtext.Parse(tt.text))
+		})
+	}
+}
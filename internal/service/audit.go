@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/model"
+	"micro-blog/pkg/pkglogger"
+)
+
+// auditExportPage - по сколько записей выгрузка читает журнал.
+const auditExportPage = 500
+
+type AuditRepository interface {
+	AppendAuditEntry(entry *model.AuditEntry) (*model.AuditEntry, error)
+	ListAuditEntries(filter model.AuditFilter, before int64, limit int) ([]*model.AuditEntry, int64, error)
+}
+
+// Auditor записывает события в журнал аудита.
+type Auditor interface {
+	Record(ctx context.Context, entry *model.AuditEntry) error
+}
+
+// AuditService ведет журнал аудита входов, смены ролей, модерации и
+// удалений. В отличие от AsyncLogger запись синхронная и не
+// отбрасывается при нагрузке: ошибку записи получает действие, которое
+// ее вызвало. Читать журнал могут только администраторы.
+type AuditService struct {
+	auditRepo AuditRepository
+	userRepo  UserRepository
+	clock     clock.Clock
+}
+
+func NewAuditService(ar AuditRepository, ur UserRepository) *AuditService {
+	return &AuditService{
+		auditRepo: ar,
+		userRepo:  ur,
+		clock:     clock.Real{},
+	}
+}
+
+// SetClock подменяет источник времени сервиса.
+func (s *AuditService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// Record дописывает событие в журнал, дополняя его адресом клиента и
+// ID запроса из контекста.
+func (s *AuditService) Record(ctx context.Context, entry *model.AuditEntry) error {
+	entry.IP = clientIP(ctx)
+	entry.RequestID, _ = ctx.Value(pkglogger.RequestIDKey).(string)
+	entry.CreatedAt = s.clock.Now()
+
+	_, err := s.auditRepo.AppendAuditEntry(entry)
+	return err
+}
+
+// ListAuditLog возвращает записи журнала от новых к старым.
+func (s *AuditService) ListAuditLog(
+	ctx context.Context,
+	actorID uuid.UUID,
+	filter model.AuditFilter,
+	cursor int64,
+	limit int,
+) (*model.AuditPage, error) {
+	if _, err := actorWithRole(s.userRepo, actorID, model.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	entries, next, err := s.auditRepo.ListAuditEntries(filter, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.AuditPage{
+		Entries:    entries,
+		NextCursor: next,
+	}, nil
+}
+
+// ExportAuditLog передает yield все подходящие под фильтр записи от
+// новых к старым. Ошибка yield прерывает выгрузку.
+func (s *AuditService) ExportAuditLog(
+	ctx context.Context,
+	actorID uuid.UUID,
+	filter model.AuditFilter,
+	yield func(entry *model.AuditEntry) error,
+) error {
+	if _, err := actorWithRole(s.userRepo, actorID, model.RoleAdmin); err != nil {
+		return err
+	}
+
+	var cursor int64
+	for {
+		entries, next, err := s.auditRepo.ListAuditEntries(filter, cursor, auditExportPage)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err = yield(entry); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		cursor = next
+	}
+}
+
+// auditDetails собирает подробности события из пар ключ-значение,
+// пропуская пустые значения.
+func auditDetails(pairs ...string) map[string]string {
+	details := make(map[string]string, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			details[pairs[i]] = pairs[i+1]
+		}
+	}
+	return details
+}
+
+// audit записывает событие, если журнал аудита подключен.
+func audit(ctx context.Context, auditor Auditor, entry *model.AuditEntry) error {
+	if auditor == nil {
+		return nil
+	}
+	return auditor.Record(ctx, entry)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/model"
+)
+
+const maxCollectionNameLength = 64
+
+type BookmarkRepository interface {
+	AddBookmark(bookmark *model.Bookmark) error
+	RemoveBookmark(userID, postID uuid.UUID) error
+	ListBookmarks(userID uuid.UUID, collectionID uuid.UUID, before int64, limit int) ([]*model.Bookmark, int64, error)
+	CreateCollection(collection *model.BookmarkCollection) (*model.BookmarkCollection, error)
+	ListCollections(ownerID uuid.UUID) ([]*model.BookmarkCollection, error)
+	DeleteCollection(ownerID, collectionID uuid.UUID) error
+}
+
+// PostReader отдает пост с учетом доступа зрителя к нему.
+type PostReader interface {
+	GetPost(ctx context.Context, viewerID uuid.UUID, postID uuid.UUID) (*model.Post, error)
+}
+
+// BookmarkService работает только с закладками текущего пользователя:
+// ни другие пользователи, ни автор поста их не видят.
+type BookmarkService struct {
+	bookmarkRepo BookmarkRepository
+	posts        PostReader
+	auditor      Auditor
+	clock        clock.Clock
+}
+
+func NewBookmarkService(br BookmarkRepository, posts PostReader) *BookmarkService {
+	return &BookmarkService{
+		bookmarkRepo: br,
+		posts:        posts,
+		clock:        clock.Real{},
+	}
+}
+
+// SetClock подменяет источник времени сервиса.
+func (s *BookmarkService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// AddBookmark сохраняет доступный пользователю пост в закладки.
+func (s *BookmarkService) AddBookmark(ctx context.Context, bookmark *model.Bookmark) error {
+	if _, err := s.posts.GetPost(ctx, bookmark.UserID, bookmark.PostID); err != nil {
+		return err
+	}
+
+	bookmark.CreatedAt = s.clock.Now()
+	return s.bookmarkRepo.AddBookmark(bookmark)
+}
+
+func (s *BookmarkService) RemoveBookmark(ctx context.Context, userID, postID uuid.UUID) error {
+	return s.bookmarkRepo.RemoveBookmark(userID, postID)
+}
+
+// ListBookmarks возвращает страницу закладок. Удаленные или ставшие
+// недоступными посты остаются в списке без содержимого.
+func (s *BookmarkService) ListBookmarks(
+	ctx context.Context,
+	userID uuid.UUID,
+	collectionID uuid.UUID,
+	cursor int64,
+	limit int,
+) (*model.BookmarkPage, error) {
+	bookmarks, next, err := s.bookmarkRepo.ListBookmarks(userID, collectionID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := make([]*model.BookmarkedPost, len(bookmarks))
+	for i, bookmark := range bookmarks {
+		post, err := s.posts.GetPost(ctx, userID, bookmark.PostID)
+		if err != nil && !errors.Is(err, model.ErrPostNotFound) {
+			return nil, err
+		}
+		page[i] = &model.BookmarkedPost{Bookmark: bookmark, Post: post}
+	}
+
+	return &model.BookmarkPage{
+		Bookmarks:  page,
+		NextCursor: next,
+	}, nil
+}
+
+func (s *BookmarkService) CreateCollection(ctx context.Context, ownerID uuid.UUID, name string) (*model.BookmarkCollection, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxCollectionNameLength {
+		return nil, model.ErrInvalidCollectionName
+	}
+
+	return s.bookmarkRepo.CreateCollection(&model.BookmarkCollection{
+		OwnerID:   ownerID,
+		Name:      name,
+		CreatedAt: s.clock.Now(),
+	})
+}
+
+func (s *BookmarkService) ListCollections(ctx context.Context, ownerID uuid.UUID) ([]*model.BookmarkCollection, error) {
+	return s.bookmarkRepo.ListCollections(ownerID)
+}
+
+// AttachAuditor включает запись удаления коллекций в журнал аудита.
+func (s *BookmarkService) AttachAuditor(auditor Auditor) {
+	s.auditor = auditor
+}
+
+func (s *BookmarkService) DeleteCollection(ctx context.Context, ownerID, collectionID uuid.UUID) error {
+	if err := s.bookmarkRepo.DeleteCollection(ownerID, collectionID); err != nil {
+		return err
+	}
+
+	return audit(ctx, s.auditor, &model.AuditEntry{
+		Action:     model.AuditCollectionDeleted,
+		ActorID:    ownerID,
+		TargetType: model.AuditTargetCollection,
+		TargetID:   collectionID,
+	})
+}
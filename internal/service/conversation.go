@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/model"
+	"micro-blog/internal/richtext"
+)
+
+const maxMessageLength = 1000
+
+type ConversationRepository interface {
+	CreateConversation(conversation *model.Conversation) (*model.Conversation, error)
+	GetConversation(conversationID, viewerID uuid.UUID) (*model.Conversation, error)
+	ListConversations(userID uuid.UUID, cursor model.ConversationCursor, limit int) ([]*model.Conversation, model.ConversationCursor, error)
+	AddMessage(message *model.Message) (*model.Message, error)
+	ListMessages(conversationID, viewerID uuid.UUID, before int64, limit int) ([]*model.Message, int64, error)
+	MarkRead(conversationID, userID, messageID uuid.UUID) error
+	CountUnread(userID uuid.UUID) (int, error)
+}
+
+// ConversationService ведет личные переписки. Переписка и ее сообщения
+// видны только участникам; для остальных она не существует.
+type ConversationService struct {
+	conversationRepo ConversationRepository
+	userRepo         UserRepository
+	followRepo       FollowRepository
+	clock            clock.Clock
+}
+
+func NewConversationService(cr ConversationRepository, ur UserRepository, fr FollowRepository) *ConversationService {
+	return &ConversationService{
+		conversationRepo: cr,
+		userRepo:         ur,
+		followRepo:       fr,
+		clock:            clock.Real{},
+	}
+}
+
+// SetClock подменяет источник времени сервиса.
+func (s *ConversationService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// CreateConversation создает переписку создателя с memberIDs. Для двух
+// участников возвращается уже существующая личная переписка. Нельзя
+// начать переписку с тем, с кем у создателя блокировка.
+func (s *ConversationService) CreateConversation(
+	ctx context.Context,
+	creatorID uuid.UUID,
+	memberIDs []uuid.UUID,
+) (*model.Conversation, error) {
+	members := []uuid.UUID{creatorID}
+	for _, memberID := range memberIDs {
+		if !slices.Contains(members, memberID) {
+			members = append(members, memberID)
+		}
+	}
+
+	if len(members) < 2 || len(members) > model.MaxConversationMembers {
+		return nil, fmt.Errorf("%w: 2 to %d members required", model.ErrInvalidConversation, model.MaxConversationMembers)
+	}
+
+	if _, err := activeUser(s.userRepo, creatorID, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	for _, memberID := range members[1:] {
+		if _, err := s.userRepo.GetUserById(memberID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.checkBlocks(creatorID, members); err != nil {
+		return nil, err
+	}
+
+	return s.conversationRepo.CreateConversation(&model.Conversation{
+		MemberIDs: members,
+		CreatedAt: s.clock.Now(),
+	})
+}
+
+func (s *ConversationService) GetConversation(ctx context.Context, userID, conversationID uuid.UUID) (*model.Conversation, error) {
+	return s.conversationRepo.GetConversation(conversationID, userID)
+}
+
+func (s *ConversationService) ListConversations(
+	ctx context.Context,
+	userID uuid.UUID,
+	cursor model.ConversationCursor,
+	limit int,
+) (*model.ConversationPage, error) {
+	conversations, next, err := s.conversationRepo.ListConversations(userID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ConversationPage{
+		Conversations: conversations,
+		NextCursor:    next,
+	}, nil
+}
+
+// SendMessage сохраняет сообщение участника переписки. Если у
+// отправителя блокировка с кем-то из участников или его аккаунт
+// заблокирован модератором, писать в переписку он не может.
+func (s *ConversationService) SendMessage(ctx context.Context, message *model.Message) (*model.Message, error) {
+	message.Text = richtext.Sanitize(message.Text)
+	if message.Text == "" {
+		return nil, model.ErrEmptyMessage
+	}
+	if richtext.Length(message.Text) > maxMessageLength {
+		return nil, fmt.Errorf("%w: max %d characters", model.ErrMessageTooLong, maxMessageLength)
+	}
+
+	conversation, err := s.conversationRepo.GetConversation(message.ConversationID, message.SenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = activeUser(s.userRepo, message.SenderID, s.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err = s.checkBlocks(message.SenderID, conversation.MemberIDs); err != nil {
+		return nil, err
+	}
+
+	message.CreatedAt = s.clock.Now()
+	return s.conversationRepo.AddMessage(message)
+}
+
+func (s *ConversationService) ListMessages(
+	ctx context.Context,
+	userID uuid.UUID,
+	conversationID uuid.UUID,
+	cursor int64,
+	limit int,
+) (*model.MessagePage, error) {
+	messages, next, err := s.conversationRepo.ListMessages(conversationID, userID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.MessagePage{
+		Messages:   messages,
+		NextCursor: next,
+	}, nil
+}
+
+// MarkRead отмечает сообщения переписки прочитанными до messageID
+// включительно; uuid.Nil означает все сообщения.
+func (s *ConversationService) MarkRead(ctx context.Context, userID, conversationID, messageID uuid.UUID) error {
+	return s.conversationRepo.MarkRead(conversationID, userID, messageID)
+}
+
+func (s *ConversationService) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	return s.conversationRepo.CountUnread(userID)
+}
+
+func (s *ConversationService) checkBlocks(userID uuid.UUID, memberIDs []uuid.UUID) error {
+	for _, memberID := range memberIDs {
+		if memberID != userID && s.followRepo.IsBlocked(userID, memberID) {
+			return model.ErrBlocked
+		}
+	}
+	return nil
+}
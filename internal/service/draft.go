@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/model"
+	"micro-blog/internal/richtext"
+)
+
+type DraftRepository interface {
+	CreateDraft(draft *model.Draft) (*model.Draft, error)
+	GetDraft(id uuid.UUID) (*model.Draft, error)
+	ListDrafts(authorID uuid.UUID) ([]*model.Draft, error)
+	UpdateDraft(draft *model.Draft) error
+	DeleteDraft(id uuid.UUID) error
+	TakeDraft(id uuid.UUID) (*model.Draft, error)
+	RestoreDraft(draft *model.Draft) error
+	ListDueDrafts(now time.Time) ([]*model.Draft, error)
+}
+
+// PostPublisher публикует пост со всеми проверками обычного создания.
+type PostPublisher interface {
+	CreatePost(ctx context.Context, post *model.Post) (*model.Post, error)
+}
+
+type DraftService struct {
+	draftRepo DraftRepository
+	userRepo  UserRepository
+	publisher PostPublisher
+	auditor   Auditor
+	limits    model.PostLimits
+	clock     clock.Clock
+}
+
+func NewDraftService(dr DraftRepository, ur UserRepository, publisher PostPublisher, limits model.PostLimits) *DraftService {
+	return &DraftService{
+		draftRepo: dr,
+		userRepo:  ur,
+		publisher: publisher,
+		limits:    limits,
+		clock:     clock.Real{},
+	}
+}
+
+// SetClock подменяет источник времени сервиса.
+func (s *DraftService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+func (s *DraftService) CreateDraft(ctx context.Context, draft *model.Draft) (*model.Draft, error) {
+	if _, err := s.userRepo.GetUserById(draft.AuthorID); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	if err := s.prepare(draft, now); err != nil {
+		return nil, err
+	}
+
+	draft.CreatedAt = now
+	draft.UpdatedAt = now
+	return s.draftRepo.CreateDraft(draft)
+}
+
+// GetDraft возвращает черновик автору. Чужой черновик неотличим от несуществующего.
+func (s *DraftService) GetDraft(ctx context.Context, authorID uuid.UUID, draftID uuid.UUID) (*model.Draft, error) {
+	draft, err := s.draftRepo.GetDraft(draftID)
+	if err != nil {
+		return nil, err
+	}
+
+	if draft.AuthorID != authorID {
+		return nil, model.ErrDraftNotFound
+	}
+	return draft, nil
+}
+
+func (s *DraftService) ListDrafts(ctx context.Context, authorID uuid.UUID) ([]*model.Draft, error) {
+	return s.draftRepo.ListDrafts(authorID)
+}
+
+// UpdateDraft целиком заменяет содержимое и расписание черновика.
+func (s *DraftService) UpdateDraft(ctx context.Context, draft *model.Draft) (*model.Draft, error) {
+	stored, err := s.GetDraft(ctx, draft.AuthorID, draft.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	if err = s.prepare(draft, now); err != nil {
+		return nil, err
+	}
+
+	draft.CreatedAt = stored.CreatedAt
+	draft.UpdatedAt = now
+	if err = s.draftRepo.UpdateDraft(draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// AttachAuditor включает запись удаления черновиков в журнал аудита.
+func (s *DraftService) AttachAuditor(auditor Auditor) {
+	s.auditor = auditor
+}
+
+func (s *DraftService) DeleteDraft(ctx context.Context, authorID uuid.UUID, draftID uuid.UUID) error {
+	if _, err := s.GetDraft(ctx, authorID, draftID); err != nil {
+		return err
+	}
+
+	if err := s.draftRepo.DeleteDraft(draftID); err != nil {
+		return err
+	}
+
+	return audit(ctx, s.auditor, &model.AuditEntry{
+		Action:     model.AuditDraftDeleted,
+		ActorID:    authorID,
+		TargetType: model.AuditTargetDraft,
+		TargetID:   draftID,
+	})
+}
+
+// PublishDraft сразу публикует черновик и удаляет его. Черновик
+// забирается из хранилища до публикации, поэтому одновременные
+// публикация вручную и по расписанию не создадут два поста.
+func (s *DraftService) PublishDraft(ctx context.Context, authorID uuid.UUID, draftID uuid.UUID) (*model.Post, error) {
+	if _, err := s.GetDraft(ctx, authorID, draftID); err != nil {
+		return nil, err
+	}
+
+	draft, err := s.draftRepo.TakeDraft(draftID)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.publisher.CreatePost(ctx, postFromDraft(draft))
+	if err != nil {
+		return nil, errors.Join(err, s.draftRepo.RestoreDraft(draft))
+	}
+	return post, nil
+}
+
+// PublishDue публикует черновики, время публикации которых наступило.
+// Черновик, который не удалось опубликовать, снимается с расписания и
+// остается у автора, чтобы не повторять заведомо неудачную попытку.
+// Черновик, опубликованный или перенесенный автором после выборки,
+// пропускается.
+func (s *DraftService) PublishDue(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.draftRepo.ListDueDrafts(now)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	var errs []error
+	for _, candidate := range due {
+		draft, err := s.draftRepo.TakeDraft(candidate.ID)
+		if errors.Is(err, model.ErrDraftNotFound) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("draft %s: %w", candidate.ID, err))
+			continue
+		}
+
+		if !draft.Scheduled() || draft.PublishAt.After(now) {
+			if err = s.draftRepo.RestoreDraft(draft); err != nil {
+				errs = append(errs, fmt.Errorf("draft %s: %w", draft.ID, err))
+			}
+			continue
+		}
+
+		if _, err = s.publisher.CreatePost(ctx, postFromDraft(draft)); err != nil {
+			errs = append(errs, fmt.Errorf("draft %s: %w", draft.ID, err))
+			draft.PublishAt = time.Time{}
+			draft.UpdatedAt = now
+			if err = s.draftRepo.RestoreDraft(draft); err != nil {
+				errs = append(errs, fmt.Errorf("draft %s: %w", draft.ID, err))
+			}
+			continue
+		}
+		published++
+	}
+
+	return published, errors.Join(errs...)
+}
+
+// prepare очищает текст и проверяет черновик. Пустой черновик допустим,
+// но запланировать можно только тот, что пройдет проверки поста.
+func (s *DraftService) prepare(draft *model.Draft, now time.Time) error {
+	draft.Text = richtext.Sanitize(draft.Text)
+
+	if draft.Visibility == "" {
+		draft.Visibility = model.VisibilityPublic
+	}
+	if !draft.Visibility.Valid() {
+		return model.ErrInvalidVisibility
+	}
+
+	if richtext.Length(draft.Text) > s.limits.MaxTextLength {
+		return fmt.Errorf("%w: max %d characters", model.ErrPostTooLong, s.limits.MaxTextLength)
+	}
+
+	post := postFromDraft(draft)
+	if err := prepareContentWarning(post); err != nil {
+		return err
+	}
+	draft.ContentWarning = post.ContentWarning
+
+	if draft.TTL < 0 || draft.TTL > s.limits.MaxTTL {
+		return fmt.Errorf("%w: max %s", model.ErrInvalidTTL, s.limits.MaxTTL)
+	}
+
+	if !draft.Scheduled() {
+		return nil
+	}
+
+	if !draft.PublishAt.After(now) {
+		return model.ErrScheduleInPast
+	}
+
+	// Опрос должен быть открыт в момент публикации, а не сохранения.
+	if err := checkPoll(draft.Poll, draft.PublishAt); err != nil {
+		return err
+	}
+
+	if draft.Text == "" && len(draft.AttachmentIDs) == 0 {
+		return model.ErrEmptyPost
+	}
+	return nil
+}
+
+func postFromDraft(draft *model.Draft) *model.Post {
+	return &model.Post{
+		AuthorID:               draft.AuthorID,
+		Text:                   draft.Text,
+		AttachmentIDs:          draft.AttachmentIDs,
+		Visibility:             draft.Visibility,
+		TTL:                    draft.TTL,
+		Poll:                   draft.Poll,
+		ContentWarning:         draft.ContentWarning,
+		Sensitive:              draft.Sensitive,
+		SensitiveAttachmentIDs: draft.SensitiveAttachmentIDs,
+	}
+}
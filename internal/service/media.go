@@ -0,0 +1,221 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/imaging"
+	"micro-blog/internal/model"
+)
+
+const sniffLen = 512
+
+// allowedMedia - поддерживаемые типы по результату определения содержимого,
+// а не по заголовку клиента.
+var allowedMedia = map[string]model.MediaKind{
+	"image/jpeg": model.MediaImage,
+	"image/png":  model.MediaImage,
+	"image/gif":  model.MediaImage,
+	"video/mp4":  model.MediaVideo,
+	"video/webm": model.MediaVideo,
+}
+
+type MediaRepository interface {
+	CreateMedia(media *model.Media) error
+	GetMedia(id uuid.UUID) (*model.Media, error)
+}
+
+// MediaGuard решает, кому можно отдать медиафайл и можно ли его кешировать
+// публично.
+type MediaGuard interface {
+	MediaAccess(media *model.Media, viewerID uuid.UUID) (visible bool, public bool, err error)
+}
+
+type MediaStore interface {
+	Save(id uuid.UUID, r io.Reader) (int64, error)
+	Open(id uuid.UUID) (io.ReadSeekCloser, error)
+	Delete(id uuid.UUID) error
+}
+
+type MediaService struct {
+	repo     MediaRepository
+	userRepo UserRepository
+	store    MediaStore
+	guard    MediaGuard
+	limits   model.MediaLimits
+	clock    clock.Clock
+}
+
+func NewMediaService(
+	repo MediaRepository,
+	ur UserRepository,
+	store MediaStore,
+	guard MediaGuard,
+	limits model.MediaLimits,
+) *MediaService {
+	return &MediaService{
+		repo:     repo,
+		userRepo: ur,
+		store:    store,
+		guard:    guard,
+		limits:   limits,
+		clock:    clock.Real{},
+	}
+}
+
+// SetClock подменяет источник времени сервиса.
+func (s *MediaService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// Upload определяет тип файла по содержимому, проверяет размер,
+// у изображений вычищает метаданные и строит превью. Заблокированный
+// пользователь загружать файлы не может.
+func (s *MediaService) Upload(ctx context.Context, ownerID uuid.UUID, r io.Reader) (*model.Media, error) {
+	if _, err := activeUser(s.userRepo, ownerID, s.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReaderSize(r, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	contentType := http.DetectContentType(head)
+	kind, ok := allowedMedia[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", model.ErrUnsupportedMedia, contentType)
+	}
+
+	media := &model.Media{
+		OwnerID:     ownerID,
+		Kind:        kind,
+		ContentType: contentType,
+		CreatedAt:   s.clock.Now(),
+	}
+
+	if kind == model.MediaImage {
+		err = s.saveImage(media, br)
+	} else {
+		err = s.saveVideo(media, br)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.CreateMedia(media); err != nil {
+		s.discard(media.ID, media.ThumbnailID)
+		return nil, err
+	}
+	return media, nil
+}
+
+func (s *MediaService) saveImage(media *model.Media, r io.Reader) error {
+	data, err := io.ReadAll(io.LimitReader(r, s.limits.MaxImageSize+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) > s.limits.MaxImageSize {
+		return model.ErrMediaTooLarge
+	}
+
+	clean, img, err := imaging.Sanitize(data, media.ContentType)
+	if errors.Is(err, imaging.ErrTooManyPixels) {
+		return model.ErrMediaTooLarge
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", model.ErrUnsupportedMedia, err)
+	}
+
+	thumb, thumbType, err := imaging.Thumbnail(img, s.limits.ThumbnailSize, media.ContentType)
+	if err != nil {
+		return err
+	}
+
+	media.ID = uuid.New()
+	if media.Size, err = s.store.Save(media.ID, bytes.NewReader(clean)); err != nil {
+		return err
+	}
+
+	// Превью сохраняется после оригинала, чтобы при сбое не осталось
+	// превью без изображения; при ошибке файлы удаляются.
+	thumbnail := &model.Media{
+		ID:          uuid.New(),
+		OwnerID:     media.OwnerID,
+		OriginalID:  media.ID,
+		Kind:        model.MediaImage,
+		ContentType: thumbType,
+		CreatedAt:   media.CreatedAt,
+	}
+	if thumbnail.Size, err = s.store.Save(thumbnail.ID, bytes.NewReader(thumb)); err != nil {
+		s.discard(media.ID)
+		return err
+	}
+	if err = s.repo.CreateMedia(thumbnail); err != nil {
+		s.discard(media.ID, thumbnail.ID)
+		return err
+	}
+
+	media.ThumbnailID = thumbnail.ID
+	return nil
+}
+
+// discard удаляет файлы, оставшиеся от неудавшейся загрузки. Ошибка
+// удаления не скрывает исходную ошибку.
+func (s *MediaService) discard(ids ...uuid.UUID) {
+	for _, id := range ids {
+		if id != uuid.Nil {
+			_ = s.store.Delete(id)
+		}
+	}
+}
+
+func (s *MediaService) saveVideo(media *model.Media, r io.Reader) error {
+	media.ID = uuid.New()
+
+	size, err := s.store.Save(media.ID, io.LimitReader(r, s.limits.MaxVideoSize+1))
+	if err != nil {
+		return err
+	}
+	if size > s.limits.MaxVideoSize {
+		s.discard(media.ID)
+		return model.ErrMediaTooLarge
+	}
+
+	media.Size = size
+	return nil
+}
+
+// Open возвращает метаданные и содержимое файла, если зритель имеет к нему
+// доступ. Недоступный файл неотличим от несуществующего. Файл закрывает
+// вызывающий.
+func (s *MediaService) Open(ctx context.Context, viewerID uuid.UUID, id uuid.UUID) (*model.Media, io.ReadSeekCloser, error) {
+	media, err := s.repo.GetMedia(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	visible, public, err := s.guard.MediaAccess(media, viewerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !visible {
+		return nil, nil, model.ErrMediaNotFound
+	}
+	media.Public = public
+
+	file, err := s.store.Open(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return media, file, nil
+}
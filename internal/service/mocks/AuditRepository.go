@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditRepository is an autogenerated mock type for the AuditRepository type
+type AuditRepository struct {
+	mock.Mock
+}
+
+// AppendAuditEntry provides a mock function with given fields: entry
+func (_m *AuditRepository) AppendAuditEntry(entry *model.AuditEntry) (*model.AuditEntry, error) {
+	ret := _m.Called(entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AppendAuditEntry")
+	}
+
+	var r0 *model.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.AuditEntry) (*model.AuditEntry, error)); ok {
+		return rf(entry)
+	}
+	if rf, ok := ret.Get(0).(func(*model.AuditEntry) *model.AuditEntry); ok {
+		r0 = rf(entry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.AuditEntry) error); ok {
+		r1 = rf(entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAuditEntries provides a mock function with given fields: filter, before, limit
+func (_m *AuditRepository) ListAuditEntries(filter model.AuditFilter, before int64, limit int) ([]*model.AuditEntry, int64, error) {
+	ret := _m.Called(filter, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEntries")
+	}
+
+	var r0 []*model.AuditEntry
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(model.AuditFilter, int64, int) ([]*model.AuditEntry, int64, error)); ok {
+		return rf(filter, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(model.AuditFilter, int64, int) []*model.AuditEntry); ok {
+		r0 = rf(filter, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model.AuditFilter, int64, int) int64); ok {
+		r1 = rf(filter, before, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(model.AuditFilter, int64, int) error); ok {
+		r2 = rf(filter, before, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewAuditRepository creates a new instance of AuditRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditRepository {
+	mock := &AuditRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
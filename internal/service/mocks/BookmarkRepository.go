@@ -0,0 +1,181 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// BookmarkRepository is an autogenerated mock type for the BookmarkRepository type
+type BookmarkRepository struct {
+	mock.Mock
+}
+
+// AddBookmark provides a mock function with given fields: bookmark
+func (_m *BookmarkRepository) AddBookmark(bookmark *model.Bookmark) error {
+	ret := _m.Called(bookmark)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBookmark")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Bookmark) error); ok {
+		r0 = rf(bookmark)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateCollection provides a mock function with given fields: collection
+func (_m *BookmarkRepository) CreateCollection(collection *model.BookmarkCollection) (*model.BookmarkCollection, error) {
+	ret := _m.Called(collection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCollection")
+	}
+
+	var r0 *model.BookmarkCollection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.BookmarkCollection) (*model.BookmarkCollection, error)); ok {
+		return rf(collection)
+	}
+	if rf, ok := ret.Get(0).(func(*model.BookmarkCollection) *model.BookmarkCollection); ok {
+		r0 = rf(collection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BookmarkCollection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.BookmarkCollection) error); ok {
+		r1 = rf(collection)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteCollection provides a mock function with given fields: ownerID, collectionID
+func (_m *BookmarkRepository) DeleteCollection(ownerID uuid.UUID, collectionID uuid.UUID) error {
+	ret := _m.Called(ownerID, collectionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCollection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ownerID, collectionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListBookmarks provides a mock function with given fields: userID, collectionID, before, limit
+func (_m *BookmarkRepository) ListBookmarks(userID uuid.UUID, collectionID uuid.UUID, before int64, limit int) ([]*model.Bookmark, int64, error) {
+	ret := _m.Called(userID, collectionID, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBookmarks")
+	}
+
+	var r0 []*model.Bookmark
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, int64, int) ([]*model.Bookmark, int64, error)); ok {
+		return rf(userID, collectionID, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, int64, int) []*model.Bookmark); ok {
+		r0 = rf(userID, collectionID, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID, int64, int) int64); ok {
+		r1 = rf(userID, collectionID, before, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uuid.UUID, uuid.UUID, int64, int) error); ok {
+		r2 = rf(userID, collectionID, before, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListCollections provides a mock function with given fields: ownerID
+func (_m *BookmarkRepository) ListCollections(ownerID uuid.UUID) ([]*model.BookmarkCollection, error) {
+	ret := _m.Called(ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCollections")
+	}
+
+	var r0 []*model.BookmarkCollection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]*model.BookmarkCollection, error)); ok {
+		return rf(ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*model.BookmarkCollection); ok {
+		r0 = rf(ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BookmarkCollection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveBookmark provides a mock function with given fields: userID, postID
+func (_m *BookmarkRepository) RemoveBookmark(userID uuid.UUID, postID uuid.UUID) error {
+	ret := _m.Called(userID, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveBookmark")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(userID, postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewBookmarkRepository creates a new instance of BookmarkRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBookmarkRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BookmarkRepository {
+	mock := &BookmarkRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,48 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ContentPolicy is an autogenerated mock type for the ContentPolicy type
+type ContentPolicy struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: post
+func (_m *ContentPolicy) Check(post *model.Post) []model.PolicyViolation {
+	ret := _m.Called(post)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 []model.PolicyViolation
+	if rf, ok := ret.Get(0).(func(*model.Post) []model.PolicyViolation); ok {
+		r0 = rf(post)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PolicyViolation)
+		}
+	}
+
+	return r0
+}
+
+// NewContentPolicy creates a new instance of ContentPolicy. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewContentPolicy(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ContentPolicy {
+	mock := &ContentPolicy{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,240 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ConversationRepository is an autogenerated mock type for the ConversationRepository type
+type ConversationRepository struct {
+	mock.Mock
+}
+
+// AddMessage provides a mock function with given fields: message
+func (_m *ConversationRepository) AddMessage(message *model.Message) (*model.Message, error) {
+	ret := _m.Called(message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMessage")
+	}
+
+	var r0 *model.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Message) (*model.Message, error)); ok {
+		return rf(message)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Message) *model.Message); ok {
+		r0 = rf(message)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Message) error); ok {
+		r1 = rf(message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountUnread provides a mock function with given fields: userID
+func (_m *ConversationRepository) CountUnread(userID uuid.UUID) (int, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUnread")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (int, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) int); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateConversation provides a mock function with given fields: conversation
+func (_m *ConversationRepository) CreateConversation(conversation *model.Conversation) (*model.Conversation, error) {
+	ret := _m.Called(conversation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateConversation")
+	}
+
+	var r0 *model.Conversation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Conversation) (*model.Conversation, error)); ok {
+		return rf(conversation)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Conversation) *model.Conversation); ok {
+		r0 = rf(conversation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Conversation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Conversation) error); ok {
+		r1 = rf(conversation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConversation provides a mock function with given fields: conversationID, viewerID
+func (_m *ConversationRepository) GetConversation(conversationID uuid.UUID, viewerID uuid.UUID) (*model.Conversation, error) {
+	ret := _m.Called(conversationID, viewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConversation")
+	}
+
+	var r0 *model.Conversation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) (*model.Conversation, error)); ok {
+		return rf(conversationID, viewerID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) *model.Conversation); ok {
+		r0 = rf(conversationID, viewerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Conversation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(conversationID, viewerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListConversations provides a mock function with given fields: userID, cursor, limit
+func (_m *ConversationRepository) ListConversations(userID uuid.UUID, cursor model.ConversationCursor, limit int) ([]*model.Conversation, model.ConversationCursor, error) {
+	ret := _m.Called(userID, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListConversations")
+	}
+
+	var r0 []*model.Conversation
+	var r1 model.ConversationCursor
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, model.ConversationCursor, int) ([]*model.Conversation, model.ConversationCursor, error)); ok {
+		return rf(userID, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, model.ConversationCursor, int) []*model.Conversation); ok {
+		r0 = rf(userID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Conversation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, model.ConversationCursor, int) model.ConversationCursor); ok {
+		r1 = rf(userID, cursor, limit)
+	} else {
+		r1 = ret.Get(1).(model.ConversationCursor)
+	}
+
+	if rf, ok := ret.Get(2).(func(uuid.UUID, model.ConversationCursor, int) error); ok {
+		r2 = rf(userID, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListMessages provides a mock function with given fields: conversationID, viewerID, before, limit
+func (_m *ConversationRepository) ListMessages(conversationID uuid.UUID, viewerID uuid.UUID, before int64, limit int) ([]*model.Message, int64, error) {
+	ret := _m.Called(conversationID, viewerID, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMessages")
+	}
+
+	var r0 []*model.Message
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, int64, int) ([]*model.Message, int64, error)); ok {
+		return rf(conversationID, viewerID, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, int64, int) []*model.Message); ok {
+		r0 = rf(conversationID, viewerID, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID, int64, int) int64); ok {
+		r1 = rf(conversationID, viewerID, before, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uuid.UUID, uuid.UUID, int64, int) error); ok {
+		r2 = rf(conversationID, viewerID, before, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MarkRead provides a mock function with given fields: conversationID, userID, messageID
+func (_m *ConversationRepository) MarkRead(conversationID uuid.UUID, userID uuid.UUID, messageID uuid.UUID) error {
+	ret := _m.Called(conversationID, userID, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRead")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(conversationID, userID, messageID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewConversationRepository creates a new instance of ConversationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewConversationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ConversationRepository {
+	mock := &ConversationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
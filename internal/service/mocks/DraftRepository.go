@@ -0,0 +1,236 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// DraftRepository is an autogenerated mock type for the DraftRepository type
+type DraftRepository struct {
+	mock.Mock
+}
+
+// CreateDraft provides a mock function with given fields: draft
+func (_m *DraftRepository) CreateDraft(draft *model.Draft) (*model.Draft, error) {
+	ret := _m.Called(draft)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDraft")
+	}
+
+	var r0 *model.Draft
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Draft) (*model.Draft, error)); ok {
+		return rf(draft)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Draft) *model.Draft); ok {
+		r0 = rf(draft)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Draft)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Draft) error); ok {
+		r1 = rf(draft)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteDraft provides a mock function with given fields: id
+func (_m *DraftRepository) DeleteDraft(id uuid.UUID) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDraft")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDraft provides a mock function with given fields: id
+func (_m *DraftRepository) GetDraft(id uuid.UUID) (*model.Draft, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDraft")
+	}
+
+	var r0 *model.Draft
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*model.Draft, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *model.Draft); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Draft)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDrafts provides a mock function with given fields: authorID
+func (_m *DraftRepository) ListDrafts(authorID uuid.UUID) ([]*model.Draft, error) {
+	ret := _m.Called(authorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDrafts")
+	}
+
+	var r0 []*model.Draft
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]*model.Draft, error)); ok {
+		return rf(authorID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*model.Draft); ok {
+		r0 = rf(authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Draft)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDueDrafts provides a mock function with given fields: now
+func (_m *DraftRepository) ListDueDrafts(now time.Time) ([]*model.Draft, error) {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDueDrafts")
+	}
+
+	var r0 []*model.Draft
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]*model.Draft, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []*model.Draft); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Draft)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreDraft provides a mock function with given fields: draft
+func (_m *DraftRepository) RestoreDraft(draft *model.Draft) error {
+	ret := _m.Called(draft)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreDraft")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Draft) error); ok {
+		r0 = rf(draft)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TakeDraft provides a mock function with given fields: id
+func (_m *DraftRepository) TakeDraft(id uuid.UUID) (*model.Draft, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeDraft")
+	}
+
+	var r0 *model.Draft
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*model.Draft, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *model.Draft); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Draft)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateDraft provides a mock function with given fields: draft
+func (_m *DraftRepository) UpdateDraft(draft *model.Draft) error {
+	ret := _m.Called(draft)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDraft")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Draft) error); ok {
+		r0 = rf(draft)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDraftRepository creates a new instance of DraftRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDraftRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DraftRepository {
+	mock := &DraftRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
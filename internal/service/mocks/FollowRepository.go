@@ -0,0 +1,429 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// FollowRepository is an autogenerated mock type for the FollowRepository type
+type FollowRepository struct {
+	mock.Mock
+}
+
+// ApproveAllFollowRequests provides a mock function with given fields: followeeID
+func (_m *FollowRepository) ApproveAllFollowRequests(followeeID uuid.UUID) error {
+	ret := _m.Called(followeeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveAllFollowRequests")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(followeeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ApproveFollowRequest provides a mock function with given fields: followeeID, followerID
+func (_m *FollowRepository) ApproveFollowRequest(followeeID uuid.UUID, followerID uuid.UUID) error {
+	ret := _m.Called(followeeID, followerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveFollowRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(followeeID, followerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Block provides a mock function with given fields: relation
+func (_m *FollowRepository) Block(relation *model.Relation) error {
+	ret := _m.Called(relation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Block")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Relation) error); ok {
+		r0 = rf(relation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountFollowers provides a mock function with given fields: userID
+func (_m *FollowRepository) CountFollowers(userID uuid.UUID) int {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountFollowers")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(uuid.UUID) int); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// CountFollowing provides a mock function with given fields: userID
+func (_m *FollowRepository) CountFollowing(userID uuid.UUID) int {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountFollowing")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(uuid.UUID) int); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// Follow provides a mock function with given fields: followerID, followeeID
+func (_m *FollowRepository) Follow(followerID uuid.UUID, followeeID uuid.UUID) error {
+	ret := _m.Called(followerID, followeeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Follow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(followerID, followeeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HasFollowRequest provides a mock function with given fields: followerID, followeeID
+func (_m *FollowRepository) HasFollowRequest(followerID uuid.UUID, followeeID uuid.UUID) bool {
+	ret := _m.Called(followerID, followeeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasFollowRequest")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) bool); ok {
+		r0 = rf(followerID, followeeID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// IsBlocked provides a mock function with given fields: userID, otherID
+func (_m *FollowRepository) IsBlocked(userID uuid.UUID, otherID uuid.UUID) bool {
+	ret := _m.Called(userID, otherID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsBlocked")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) bool); ok {
+		r0 = rf(userID, otherID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// IsFollowing provides a mock function with given fields: followerID, followeeID
+func (_m *FollowRepository) IsFollowing(followerID uuid.UUID, followeeID uuid.UUID) bool {
+	ret := _m.Called(followerID, followeeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsFollowing")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) bool); ok {
+		r0 = rf(followerID, followeeID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// IsMuted provides a mock function with given fields: ownerID, targetID
+func (_m *FollowRepository) IsMuted(ownerID uuid.UUID, targetID uuid.UUID) bool {
+	ret := _m.Called(ownerID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsMuted")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) bool); ok {
+		r0 = rf(ownerID, targetID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ListBlocked provides a mock function with given fields: ownerID, before, limit
+func (_m *FollowRepository) ListBlocked(ownerID uuid.UUID, before int64, limit int) ([]*model.Relation, int64, error) {
+	ret := _m.Called(ownerID, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBlocked")
+	}
+
+	var r0 []*model.Relation
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int64, int) ([]*model.Relation, int64, error)); ok {
+		return rf(ownerID, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int64, int) []*model.Relation); ok {
+		r0 = rf(ownerID, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Relation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, int64, int) int64); ok {
+		r1 = rf(ownerID, before, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uuid.UUID, int64, int) error); ok {
+		r2 = rf(ownerID, before, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListFollowRequests provides a mock function with given fields: followeeID, before, limit
+func (_m *FollowRepository) ListFollowRequests(followeeID uuid.UUID, before int64, limit int) ([]*model.FollowRequest, int64, error) {
+	ret := _m.Called(followeeID, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFollowRequests")
+	}
+
+	var r0 []*model.FollowRequest
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int64, int) ([]*model.FollowRequest, int64, error)); ok {
+		return rf(followeeID, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int64, int) []*model.FollowRequest); ok {
+		r0 = rf(followeeID, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FollowRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, int64, int) int64); ok {
+		r1 = rf(followeeID, before, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uuid.UUID, int64, int) error); ok {
+		r2 = rf(followeeID, before, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListMuted provides a mock function with given fields: ownerID, before, limit
+func (_m *FollowRepository) ListMuted(ownerID uuid.UUID, before int64, limit int) ([]*model.Relation, int64, error) {
+	ret := _m.Called(ownerID, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMuted")
+	}
+
+	var r0 []*model.Relation
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int64, int) ([]*model.Relation, int64, error)); ok {
+		return rf(ownerID, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int64, int) []*model.Relation); ok {
+		r0 = rf(ownerID, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Relation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, int64, int) int64); ok {
+		r1 = rf(ownerID, before, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uuid.UUID, int64, int) error); ok {
+		r2 = rf(ownerID, before, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Mute provides a mock function with given fields: relation
+func (_m *FollowRepository) Mute(relation *model.Relation) error {
+	ret := _m.Called(relation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Mute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Relation) error); ok {
+		r0 = rf(relation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RejectFollowRequest provides a mock function with given fields: followeeID, followerID
+func (_m *FollowRepository) RejectFollowRequest(followeeID uuid.UUID, followerID uuid.UUID) error {
+	ret := _m.Called(followeeID, followerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectFollowRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(followeeID, followerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RequestFollow provides a mock function with given fields: request
+func (_m *FollowRepository) RequestFollow(request *model.FollowRequest) error {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestFollow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.FollowRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unblock provides a mock function with given fields: ownerID, targetID
+func (_m *FollowRepository) Unblock(ownerID uuid.UUID, targetID uuid.UUID) error {
+	ret := _m.Called(ownerID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unblock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ownerID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unfollow provides a mock function with given fields: followerID, followeeID
+func (_m *FollowRepository) Unfollow(followerID uuid.UUID, followeeID uuid.UUID) error {
+	ret := _m.Called(followerID, followeeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unfollow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(followerID, followeeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unmute provides a mock function with given fields: ownerID, targetID
+func (_m *FollowRepository) Unmute(ownerID uuid.UUID, targetID uuid.UUID) error {
+	ret := _m.Called(ownerID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unmute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ownerID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewFollowRepository creates a new instance of FollowRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFollowRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FollowRepository {
+	mock := &FollowRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -9,6 +9,6 @@ type MockLikeQueue struct {
 	mock.Mock
 }
 
-func (m *MockLikeQueue) Enqueue(like *model.Like) {
-	m.Called(like)
+func (m *MockLikeQueue) Enqueue(reaction *model.Reaction) {
+	m.Called(reaction)
 }
@@ -0,0 +1,65 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MediaGuard is an autogenerated mock type for the MediaGuard type
+type MediaGuard struct {
+	mock.Mock
+}
+
+// MediaAccess provides a mock function with given fields: media, viewerID
+func (_m *MediaGuard) MediaAccess(media *model.Media, viewerID uuid.UUID) (bool, bool, error) {
+	ret := _m.Called(media, viewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MediaAccess")
+	}
+
+	var r0 bool
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*model.Media, uuid.UUID) (bool, bool, error)); ok {
+		return rf(media, viewerID)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Media, uuid.UUID) bool); ok {
+		r0 = rf(media, viewerID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Media, uuid.UUID) bool); ok {
+		r1 = rf(media, viewerID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(*model.Media, uuid.UUID) error); ok {
+		r2 = rf(media, viewerID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewMediaGuard creates a new instance of MediaGuard. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMediaGuard(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MediaGuard {
+	mock := &MediaGuard{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,78 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MediaRepository is an autogenerated mock type for the MediaRepository type
+type MediaRepository struct {
+	mock.Mock
+}
+
+// CreateMedia provides a mock function with given fields: media
+func (_m *MediaRepository) CreateMedia(media *model.Media) error {
+	ret := _m.Called(media)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMedia")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Media) error); ok {
+		r0 = rf(media)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetMedia provides a mock function with given fields: id
+func (_m *MediaRepository) GetMedia(id uuid.UUID) (*model.Media, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMedia")
+	}
+
+	var r0 *model.Media
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*model.Media, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *model.Media); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Media)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMediaRepository creates a new instance of MediaRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMediaRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MediaRepository {
+	mock := &MediaRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
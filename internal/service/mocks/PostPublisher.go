@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PostPublisher is an autogenerated mock type for the PostPublisher type
+type PostPublisher struct {
+	mock.Mock
+}
+
+// CreatePost provides a mock function with given fields: ctx, post
+func (_m *PostPublisher) CreatePost(ctx context.Context, post *model.Post) (*model.Post, error) {
+	ret := _m.Called(ctx, post)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePost")
+	}
+
+	var r0 *model.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Post) (*model.Post, error)); ok {
+		return rf(ctx, post)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Post) *model.Post); ok {
+		r0 = rf(ctx, post)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Post) error); ok {
+		r1 = rf(ctx, post)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPostPublisher creates a new instance of PostPublisher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPostPublisher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PostPublisher {
+	mock := &PostPublisher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
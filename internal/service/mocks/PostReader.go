@@ -0,0 +1,61 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// PostReader is an autogenerated mock type for the PostReader type
+type PostReader struct {
+	mock.Mock
+}
+
+// GetPost provides a mock function with given fields: ctx, viewerID, postID
+func (_m *PostReader) GetPost(ctx context.Context, viewerID uuid.UUID, postID uuid.UUID) (*model.Post, error) {
+	ret := _m.Called(ctx, viewerID, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPost")
+	}
+
+	var r0 *model.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.Post, error)); ok {
+		return rf(ctx, viewerID, postID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.Post); ok {
+		r0 = rf(ctx, viewerID, postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, viewerID, postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPostReader creates a new instance of PostReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPostReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PostReader {
+	mock := &PostReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -6,6 +6,10 @@ import (
 	model "micro-blog/internal/model"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
 )
 
 // PostRepository is an autogenerated mock type for the PostRepository type
@@ -13,6 +17,54 @@ type PostRepository struct {
 	mock.Mock
 }
 
+// CountPostsByAuthor provides a mock function with given fields: authorID, now
+func (_m *PostRepository) CountPostsByAuthor(authorID uuid.UUID, now time.Time) int {
+	ret := _m.Called(authorID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountPostsByAuthor")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) int); ok {
+		r0 = rf(authorID, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// CountReactionsBy provides a mock function with given fields: postIDs, userIDs
+func (_m *PostRepository) CountReactionsBy(postIDs []uuid.UUID, userIDs []uuid.UUID) (map[uuid.UUID]map[model.ReactionType]int, error) {
+	ret := _m.Called(postIDs, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountReactionsBy")
+	}
+
+	var r0 map[uuid.UUID]map[model.ReactionType]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]uuid.UUID, []uuid.UUID) (map[uuid.UUID]map[model.ReactionType]int, error)); ok {
+		return rf(postIDs, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func([]uuid.UUID, []uuid.UUID) map[uuid.UUID]map[model.ReactionType]int); ok {
+		r0 = rf(postIDs, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]map[model.ReactionType]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]uuid.UUID, []uuid.UUID) error); ok {
+		r1 = rf(postIDs, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePost provides a mock function with given fields: post
 func (_m *PostRepository) CreatePost(post *model.Post) (*model.Post, error) {
 	ret := _m.Called(post)
@@ -43,9 +95,122 @@ func (_m *PostRepository) CreatePost(post *model.Post) (*model.Post, error) {
 	return r0, r1
 }
 
-// GetListPost provides a mock function with no fields
-func (_m *PostRepository) GetListPost() ([]*model.Post, error) {
-	ret := _m.Called()
+// DeleteExpiredPosts provides a mock function with given fields: now
+func (_m *PostRepository) DeleteExpiredPosts(now time.Time) (int, error) {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredPosts")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int); ok {
+		r0 = rf(now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeletePost provides a mock function with given fields: postID
+func (_m *PostRepository) DeletePost(postID uuid.UUID) error {
+	ret := _m.Called(postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditPost provides a mock function with given fields: edit
+func (_m *PostRepository) EditPost(edit *model.PostEdit) (*model.Post, error) {
+	ret := _m.Called(edit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditPost")
+	}
+
+	var r0 *model.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.PostEdit) (*model.Post, error)); ok {
+		return rf(edit)
+	}
+	if rf, ok := ret.Get(0).(func(*model.PostEdit) *model.Post); ok {
+		r0 = rf(edit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.PostEdit) error); ok {
+		r1 = rf(edit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuthorPosts provides a mock function with given fields: authorID, viewerID, filter, before, limit
+func (_m *PostRepository) GetAuthorPosts(authorID uuid.UUID, viewerID uuid.UUID, filter model.AuthorFeedFilter, before int64, limit int) ([]*model.Post, int64, error) {
+	ret := _m.Called(authorID, viewerID, filter, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorPosts")
+	}
+
+	var r0 []*model.Post
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, model.AuthorFeedFilter, int64, int) ([]*model.Post, int64, error)); ok {
+		return rf(authorID, viewerID, filter, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, model.AuthorFeedFilter, int64, int) []*model.Post); ok {
+		r0 = rf(authorID, viewerID, filter, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID, model.AuthorFeedFilter, int64, int) int64); ok {
+		r1 = rf(authorID, viewerID, filter, before, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uuid.UUID, uuid.UUID, model.AuthorFeedFilter, int64, int) error); ok {
+		r2 = rf(authorID, viewerID, filter, before, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetListPost provides a mock function with given fields: viewerID
+func (_m *PostRepository) GetListPost(viewerID uuid.UUID) ([]*model.Post, error) {
+	ret := _m.Called(viewerID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetListPost")
@@ -53,19 +218,146 @@ func (_m *PostRepository) GetListPost() ([]*model.Post, error) {
 
 	var r0 []*model.Post
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]*model.Post, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]*model.Post, error)); ok {
+		return rf(viewerID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*model.Post); ok {
+		r0 = rf(viewerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(viewerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPost provides a mock function with given fields: postID, viewerID
+func (_m *PostRepository) GetPost(postID uuid.UUID, viewerID uuid.UUID) (*model.Post, error) {
+	ret := _m.Called(postID, viewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPost")
+	}
+
+	var r0 *model.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) (*model.Post, error)); ok {
+		return rf(postID, viewerID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) *model.Post); ok {
+		r0 = rf(postID, viewerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(postID, viewerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPostReactions provides a mock function with given fields: postID, reactionType, after, limit
+func (_m *PostRepository) GetPostReactions(postID uuid.UUID, reactionType model.ReactionType, after int64, limit int) ([]*model.Reaction, int64, error) {
+	ret := _m.Called(postID, reactionType, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostReactions")
+	}
+
+	var r0 []*model.Reaction
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, model.ReactionType, int64, int) ([]*model.Reaction, int64, error)); ok {
+		return rf(postID, reactionType, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, model.ReactionType, int64, int) []*model.Reaction); ok {
+		r0 = rf(postID, reactionType, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Reaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, model.ReactionType, int64, int) int64); ok {
+		r1 = rf(postID, reactionType, after, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uuid.UUID, model.ReactionType, int64, int) error); ok {
+		r2 = rf(postID, reactionType, after, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetPostRevisions provides a mock function with given fields: postID
+func (_m *PostRepository) GetPostRevisions(postID uuid.UUID) ([]*model.PostRevision, error) {
+	ret := _m.Called(postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostRevisions")
+	}
+
+	var r0 []*model.PostRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]*model.PostRevision, error)); ok {
+		return rf(postID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*model.PostRevision); ok {
+		r0 = rf(postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PostRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPostsWithAttachment provides a mock function with given fields: mediaID
+func (_m *PostRepository) GetPostsWithAttachment(mediaID uuid.UUID) ([]*model.Post, error) {
+	ret := _m.Called(mediaID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostsWithAttachment")
+	}
+
+	var r0 []*model.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]*model.Post, error)); ok {
+		return rf(mediaID)
 	}
-	if rf, ok := ret.Get(0).(func() []*model.Post); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []*model.Post); ok {
+		r0 = rf(mediaID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.Post)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(mediaID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -73,17 +365,119 @@ func (_m *PostRepository) GetListPost() ([]*model.Post, error) {
 	return r0, r1
 }
 
-// LikePost provides a mock function with given fields: like
-func (_m *PostRepository) LikePost(like *model.Like) error {
-	ret := _m.Called(like)
+// MarkPostSensitive provides a mock function with given fields: postID, contentWarning
+func (_m *PostRepository) MarkPostSensitive(postID uuid.UUID, contentWarning string) (*model.Post, error) {
+	ret := _m.Called(postID, contentWarning)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkPostSensitive")
+	}
+
+	var r0 *model.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) (*model.Post, error)); ok {
+		return rf(postID, contentWarning)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) *model.Post); ok {
+		r0 = rf(postID, contentWarning)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(postID, contentWarning)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PinPost provides a mock function with given fields: postID, pinnedAt, maxPinned
+func (_m *PostRepository) PinPost(postID uuid.UUID, pinnedAt time.Time, maxPinned int) error {
+	ret := _m.Called(postID, pinnedAt, maxPinned)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PinPost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time, int) error); ok {
+		r0 = rf(postID, pinnedAt, maxPinned)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReactToPost provides a mock function with given fields: reaction
+func (_m *PostRepository) ReactToPost(reaction *model.Reaction) error {
+	ret := _m.Called(reaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReactToPost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Reaction) error); ok {
+		r0 = rf(reaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleasePost provides a mock function with given fields: postID
+func (_m *PostRepository) ReleasePost(postID uuid.UUID) error {
+	ret := _m.Called(postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleasePost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnpinPost provides a mock function with given fields: postID
+func (_m *PostRepository) UnpinPost(postID uuid.UUID) error {
+	ret := _m.Called(postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnpinPost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VotePoll provides a mock function with given fields: vote
+func (_m *PostRepository) VotePoll(vote *model.PollVote) error {
+	ret := _m.Called(vote)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LikePost")
+		panic("no return value specified for VotePoll")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*model.Like) error); ok {
-		r0 = rf(like)
+	if rf, ok := ret.Get(0).(func(*model.PollVote) error); ok {
+		r0 = rf(vote)
 	} else {
 		r0 = ret.Error(0)
 	}
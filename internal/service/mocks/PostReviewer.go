@@ -0,0 +1,47 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PostReviewer is an autogenerated mock type for the PostReviewer type
+type PostReviewer struct {
+	mock.Mock
+}
+
+// HoldForReview provides a mock function with given fields: ctx, post, violations
+func (_m *PostReviewer) HoldForReview(ctx context.Context, post *model.Post, violations []model.PolicyViolation) error {
+	ret := _m.Called(ctx, post, violations)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HoldForReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Post, []model.PolicyViolation) error); ok {
+		r0 = rf(ctx, post, violations)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPostReviewer creates a new instance of PostReviewer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPostReviewer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PostReviewer {
+	mock := &PostReviewer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,187 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ReportRepository is an autogenerated mock type for the ReportRepository type
+type ReportRepository struct {
+	mock.Mock
+}
+
+// AddModerationAction provides a mock function with given fields: action, status
+func (_m *ReportRepository) AddModerationAction(action *model.ModerationAction, status model.ReportStatus) (*model.Report, error) {
+	ret := _m.Called(action, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddModerationAction")
+	}
+
+	var r0 *model.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.ModerationAction, model.ReportStatus) (*model.Report, error)); ok {
+		return rf(action, status)
+	}
+	if rf, ok := ret.Get(0).(func(*model.ModerationAction, model.ReportStatus) *model.Report); ok {
+		r0 = rf(action, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.ModerationAction, model.ReportStatus) error); ok {
+		r1 = rf(action, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReport provides a mock function with given fields: report
+func (_m *ReportRepository) CreateReport(report *model.Report) (*model.Report, error) {
+	ret := _m.Called(report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReport")
+	}
+
+	var r0 *model.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Report) (*model.Report, error)); ok {
+		return rf(report)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Report) *model.Report); ok {
+		r0 = rf(report)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Report) error); ok {
+		r1 = rf(report)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReport provides a mock function with given fields: reportID
+func (_m *ReportRepository) GetReport(reportID uuid.UUID) (*model.Report, error) {
+	ret := _m.Called(reportID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReport")
+	}
+
+	var r0 *model.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*model.Report, error)); ok {
+		return rf(reportID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *model.Report); ok {
+		r0 = rf(reportID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(reportID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReports provides a mock function with given fields: status, before, limit
+func (_m *ReportRepository) ListReports(status model.ReportStatus, before int64, limit int) ([]*model.Report, int64, error) {
+	ret := _m.Called(status, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReports")
+	}
+
+	var r0 []*model.Report
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(model.ReportStatus, int64, int) ([]*model.Report, int64, error)); ok {
+		return rf(status, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(model.ReportStatus, int64, int) []*model.Report); ok {
+		r0 = rf(status, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model.ReportStatus, int64, int) int64); ok {
+		r1 = rf(status, before, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(model.ReportStatus, int64, int) error); ok {
+		r2 = rf(status, before, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RevertModerationAction provides a mock function with given fields: reportID
+func (_m *ReportRepository) RevertModerationAction(reportID uuid.UUID) (*model.Report, error) {
+	ret := _m.Called(reportID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertModerationAction")
+	}
+
+	var r0 *model.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*model.Report, error)); ok {
+		return rf(reportID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *model.Report); ok {
+		r0 = rf(reportID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(reportID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReportRepository creates a new instance of ReportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReportRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReportRepository {
+	mock := &ReportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
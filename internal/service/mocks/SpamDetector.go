@@ -0,0 +1,102 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// SpamDetector is an autogenerated mock type for the SpamDetector type
+type SpamDetector struct {
+	mock.Mock
+}
+
+// CheckLike provides a mock function with given fields: user, ip, now
+func (_m *SpamDetector) CheckLike(user *model.User, ip string, now time.Time) model.SpamVerdict {
+	ret := _m.Called(user, ip, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckLike")
+	}
+
+	var r0 model.SpamVerdict
+	if rf, ok := ret.Get(0).(func(*model.User, string, time.Time) model.SpamVerdict); ok {
+		r0 = rf(user, ip, now)
+	} else {
+		r0 = ret.Get(0).(model.SpamVerdict)
+	}
+
+	return r0
+}
+
+// CheckPost provides a mock function with given fields: user, ip, text, now
+func (_m *SpamDetector) CheckPost(user *model.User, ip string, text string, now time.Time) model.SpamVerdict {
+	ret := _m.Called(user, ip, text, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckPost")
+	}
+
+	var r0 model.SpamVerdict
+	if rf, ok := ret.Get(0).(func(*model.User, string, string, time.Time) model.SpamVerdict); ok {
+		r0 = rf(user, ip, text, now)
+	} else {
+		r0 = ret.Get(0).(model.SpamVerdict)
+	}
+
+	return r0
+}
+
+// CheckRegistration provides a mock function with given fields: ip, now
+func (_m *SpamDetector) CheckRegistration(ip string, now time.Time) model.SpamVerdict {
+	ret := _m.Called(ip, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckRegistration")
+	}
+
+	var r0 model.SpamVerdict
+	if rf, ok := ret.Get(0).(func(string, time.Time) model.SpamVerdict); ok {
+		r0 = rf(ip, now)
+	} else {
+		r0 = ret.Get(0).(model.SpamVerdict)
+	}
+
+	return r0
+}
+
+// NewAccount provides a mock function with given fields: user, now
+func (_m *SpamDetector) NewAccount(user *model.User, now time.Time) bool {
+	ret := _m.Called(user, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewAccount")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*model.User, time.Time) bool); ok {
+		r0 = rf(user, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NewSpamDetector creates a new instance of SpamDetector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSpamDetector(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SpamDetector {
+	mock := &SpamDetector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,75 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SpamGuard is an autogenerated mock type for the SpamGuard type
+type SpamGuard struct {
+	mock.Mock
+}
+
+// CheckLike provides a mock function with given fields: ctx, user
+func (_m *SpamGuard) CheckLike(ctx context.Context, user *model.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckLike")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckPost provides a mock function with given fields: ctx, author, post
+func (_m *SpamGuard) CheckPost(ctx context.Context, author *model.User, post *model.Post) (bool, error) {
+	ret := _m.Called(ctx, author, post)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckPost")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Post) (bool, error)); ok {
+		return rf(ctx, author, post)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Post) bool); ok {
+		r0 = rf(ctx, author, post)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, *model.Post) error); ok {
+		r1 = rf(ctx, author, post)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSpamGuard creates a new instance of SpamGuard. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSpamGuard(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SpamGuard {
+	mock := &SpamGuard{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
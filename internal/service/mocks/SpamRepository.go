@@ -0,0 +1,165 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "micro-blog/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// SpamRepository is an autogenerated mock type for the SpamRepository type
+type SpamRepository struct {
+	mock.Mock
+}
+
+// AddSpamSignals provides a mock function with given fields: userID, signals, points, ip, now, decay
+func (_m *SpamRepository) AddSpamSignals(userID uuid.UUID, signals []model.SpamSignal, points int, ip string, now time.Time, decay time.Duration) (*model.SpamFlag, error) {
+	ret := _m.Called(userID, signals, points, ip, now, decay)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSpamSignals")
+	}
+
+	var r0 *model.SpamFlag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []model.SpamSignal, int, string, time.Time, time.Duration) (*model.SpamFlag, error)); ok {
+		return rf(userID, signals, points, ip, now, decay)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []model.SpamSignal, int, string, time.Time, time.Duration) *model.SpamFlag); ok {
+		r0 = rf(userID, signals, points, ip, now, decay)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SpamFlag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, []model.SpamSignal, int, string, time.Time, time.Duration) error); ok {
+		r1 = rf(userID, signals, points, ip, now, decay)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClearSpamFlag provides a mock function with given fields: userID
+func (_m *SpamRepository) ClearSpamFlag(userID uuid.UUID) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearSpamFlag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IsQuarantined provides a mock function with given fields: userID
+func (_m *SpamRepository) IsQuarantined(userID uuid.UUID) bool {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsQuarantined")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uuid.UUID) bool); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ListSpamFlags provides a mock function with given fields: quarantinedOnly, before, limit
+func (_m *SpamRepository) ListSpamFlags(quarantinedOnly bool, before int64, limit int) ([]*model.SpamFlag, int64, error) {
+	ret := _m.Called(quarantinedOnly, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSpamFlags")
+	}
+
+	var r0 []*model.SpamFlag
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(bool, int64, int) ([]*model.SpamFlag, int64, error)); ok {
+		return rf(quarantinedOnly, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(bool, int64, int) []*model.SpamFlag); ok {
+		r0 = rf(quarantinedOnly, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SpamFlag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(bool, int64, int) int64); ok {
+		r1 = rf(quarantinedOnly, before, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(bool, int64, int) error); ok {
+		r2 = rf(quarantinedOnly, before, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// QuarantineUser provides a mock function with given fields: userID, now
+func (_m *SpamRepository) QuarantineUser(userID uuid.UUID, now time.Time) (*model.SpamFlag, error) {
+	ret := _m.Called(userID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QuarantineUser")
+	}
+
+	var r0 *model.SpamFlag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) (*model.SpamFlag, error)); ok {
+		return rf(userID, now)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) *model.SpamFlag); ok {
+		r0 = rf(userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SpamFlag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, time.Time) error); ok {
+		r1 = rf(userID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSpamRepository creates a new instance of SpamRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSpamRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SpamRepository {
+	mock := &SpamRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	uuid "github.com/google/uuid"
 )
 
@@ -45,6 +47,36 @@ func (_m *UserRepository) CreateUser(user *model.User) (*model.User, error) {
 	return r0, r1
 }
 
+// GetUserByAlias provides a mock function with given fields: name
+func (_m *UserRepository) GetUserByAlias(name string) (*model.User, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByAlias")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.User, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.User); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserById provides a mock function with given fields: id
 func (_m *UserRepository) GetUserById(id uuid.UUID) (*model.User, error) {
 	ret := _m.Called(id)
@@ -105,6 +137,214 @@ func (_m *UserRepository) GetUserByName(name string) (*model.User, error) {
 	return r0, r1
 }
 
+// LiftExpiredSanctions provides a mock function with given fields: now
+func (_m *UserRepository) LiftExpiredSanctions(now time.Time) (int, error) {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LiftExpiredSanctions")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int); ok {
+		r0 = rf(now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListShadowBanned provides a mock function with given fields: now
+func (_m *UserRepository) ListShadowBanned(now time.Time) ([]uuid.UUID, error) {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListShadowBanned")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]uuid.UUID, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []uuid.UUID); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenameUser provides a mock function with given fields: id, newName, holdUntil
+func (_m *UserRepository) RenameUser(id uuid.UUID, newName string, holdUntil time.Time) (*model.User, error) {
+	ret := _m.Called(id, newName, holdUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameUser")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, time.Time) (*model.User, error)); ok {
+		return rf(id, newName, holdUntil)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, time.Time) *model.User); ok {
+		r0 = rf(id, newName, holdUntil)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string, time.Time) error); ok {
+		r1 = rf(id, newName, holdUntil)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetRole provides a mock function with given fields: id, role
+func (_m *UserRepository) SetRole(id uuid.UUID, role model.Role) (*model.User, error) {
+	ret := _m.Called(id, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRole")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, model.Role) (*model.User, error)); ok {
+		return rf(id, role)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, model.Role) *model.User); ok {
+		r0 = rf(id, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, model.Role) error); ok {
+		r1 = rf(id, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShadowBanUser provides a mock function with given fields: id, reason, until
+func (_m *UserRepository) ShadowBanUser(id uuid.UUID, reason string, until time.Time) (*model.User, error) {
+	ret := _m.Called(id, reason, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShadowBanUser")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, time.Time) (*model.User, error)); ok {
+		return rf(id, reason, until)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, time.Time) *model.User); ok {
+		r0 = rf(id, reason, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string, time.Time) error); ok {
+		r1 = rf(id, reason, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SuspendUser provides a mock function with given fields: id, reason, until
+func (_m *UserRepository) SuspendUser(id uuid.UUID, reason string, until time.Time) (*model.User, error) {
+	ret := _m.Called(id, reason, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuspendUser")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, time.Time) (*model.User, error)); ok {
+		return rf(id, reason, until)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, time.Time) *model.User); ok {
+		r0 = rf(id, reason, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string, time.Time) error); ok {
+		r1 = rf(id, reason, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUser provides a mock function with given fields: id, update
+func (_m *UserRepository) UpdateUser(id uuid.UUID, update *model.ProfileUpdate) (*model.User, error) {
+	ret := _m.Called(id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *model.ProfileUpdate) (*model.User, error)); ok {
+		return rf(id, update)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *model.ProfileUpdate) *model.User); ok {
+		r0 = rf(id, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, *model.ProfileUpdate) error); ok {
+		r1 = rf(id, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
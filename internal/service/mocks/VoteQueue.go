@@ -0,0 +1,14 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"micro-blog/internal/model"
+)
+
+type MockVoteQueue struct {
+	mock.Mock
+}
+
+func (m *MockVoteQueue) Enqueue(vote *model.PollVote) {
+	m.Called(vote)
+}
@@ -0,0 +1,543 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/model"
+	"micro-blog/internal/richtext"
+)
+
+const (
+	maxReportCommentLength  = 500
+	maxModerationNoteLength = 500
+)
+
+type ReportRepository interface {
+	CreateReport(report *model.Report) (*model.Report, error)
+	GetReport(reportID uuid.UUID) (*model.Report, error)
+	ListReports(status model.ReportStatus, before int64, limit int) ([]*model.Report, int64, error)
+	AddModerationAction(action *model.ModerationAction, status model.ReportStatus) (*model.Report, error)
+	RevertModerationAction(reportID uuid.UUID) (*model.Report, error)
+}
+
+// ModerationService принимает жалобы пользователей, ведет очередь
+// модерации и накладывает на пользователей санкции. Разбирать очередь и
+// наказывать могут только пользователи с ролью модератора или
+// администратора.
+type ModerationService struct {
+	reportRepo ReportRepository
+	postRepo   PostRepository
+	userRepo   UserRepository
+	posts      PostReader
+	auditor    Auditor
+	clock      clock.Clock
+}
+
+func NewModerationService(
+	rr ReportRepository,
+	pr PostRepository,
+	ur UserRepository,
+	posts PostReader,
+) *ModerationService {
+	return &ModerationService{
+		reportRepo: rr,
+		postRepo:   pr,
+		userRepo:   ur,
+		posts:      posts,
+		clock:      clock.Real{},
+	}
+}
+
+// SetClock подменяет источник времени сервиса.
+func (s *ModerationService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// AttachAuditor включает запись решений модераторов и санкций в журнал
+// аудита.
+func (s *ModerationService) AttachAuditor(auditor Auditor) {
+	s.auditor = auditor
+}
+
+// ReportContent принимает жалобу на пост или пользователя. Пожаловаться
+// можно только на доступный автору жалобы пост и не на самого себя.
+func (s *ModerationService) ReportContent(ctx context.Context, report *model.Report) (*model.Report, error) {
+	if !report.Reason.Valid() {
+		return nil, fmt.Errorf("%w: unknown reason %q", model.ErrInvalidReport, report.Reason)
+	}
+
+	report.Comment = strings.TrimSpace(report.Comment)
+	if utf8.RuneCountInString(report.Comment) > maxReportCommentLength {
+		return nil, fmt.Errorf("%w: comment is longer than %d characters", model.ErrInvalidReport, maxReportCommentLength)
+	}
+
+	switch report.TargetType {
+	case model.ReportTargetPost:
+		post, err := s.posts.GetPost(ctx, report.ReporterID, report.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		if post.AuthorID == report.ReporterID {
+			return nil, fmt.Errorf("%w: cannot report your own post", model.ErrInvalidReport)
+		}
+	case model.ReportTargetUser:
+		if report.TargetID == report.ReporterID {
+			return nil, fmt.Errorf("%w: cannot report yourself", model.ErrInvalidReport)
+		}
+		if _, err := s.userRepo.GetUserById(report.TargetID); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown target %q", model.ErrInvalidReport, report.TargetType)
+	}
+
+	report.CreatedAt = s.clock.Now()
+	return s.reportRepo.CreateReport(report)
+}
+
+// ListReports возвращает очередь модерации; пустой status - все жалобы.
+func (s *ModerationService) ListReports(
+	ctx context.Context,
+	actorID uuid.UUID,
+	status model.ReportStatus,
+	cursor int64,
+	limit int,
+) (*model.ReportPage, error) {
+	if _, err := s.moderator(actorID); err != nil {
+		return nil, err
+	}
+
+	reports, next, err := s.reportRepo.ListReports(status, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ReportPage{
+		Reports:    reports,
+		NextCursor: next,
+	}, nil
+}
+
+func (s *ModerationService) GetReport(ctx context.Context, actorID, reportID uuid.UUID) (*model.Report, error) {
+	if _, err := s.moderator(actorID); err != nil {
+		return nil, err
+	}
+	return s.reportRepo.GetReport(reportID)
+}
+
+// Moderate применяет решение модератора к открытой жалобе. Решение сначала
+// записывается в историю жалобы и журнал аудита - это закрывает жалобу и
+// не дает двум модераторам применить решения одновременно, - а затем
+// применяется. Если применить его не удалось до первого изменения цели,
+// запись отменяется и жалоба снова открывается. После изменения цели
+// жалоба остается закрытой: повторное решение по ней снова удалило бы
+// или наказало то, что уже удалено или наказано.
+func (s *ModerationService) Moderate(ctx context.Context, action *model.ModerationAction) (*model.Report, error) {
+	actor, err := s.moderator(action.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !action.Type.Valid() {
+		return nil, fmt.Errorf("%w: unknown action %q", model.ErrInvalidModerationAction, action.Type)
+	}
+
+	action.Note = strings.TrimSpace(action.Note)
+	if utf8.RuneCountInString(action.Note) > maxModerationNoteLength {
+		return nil, fmt.Errorf("%w: note is longer than %d characters", model.ErrInvalidModerationAction, maxModerationNoteLength)
+	}
+
+	report, err := s.reportRepo.GetReport(action.ReportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.Status != model.ReportStatusOpen {
+		return nil, model.ErrReportClosed
+	}
+
+	action.CreatedAt = s.clock.Now()
+	if err = checkModeration(report, action); err != nil {
+		return nil, err
+	}
+
+	status := model.ReportStatusResolved
+	if action.Type == model.ModerationDismiss {
+		status = model.ReportStatusDismissed
+	}
+
+	moderated, err := s.reportRepo.AddModerationAction(action, status)
+	if err != nil {
+		return nil, err
+	}
+
+	err = audit(ctx, s.auditor, &model.AuditEntry{
+		Action:     model.AuditReportModerated,
+		ActorID:    actor.ID,
+		TargetType: model.AuditTargetReport,
+		TargetID:   report.ID,
+		Details:    auditDetails("action", string(action.Type), "note", action.Note),
+	})
+	if err != nil {
+		return nil, s.revertModeration(ctx, actor, report, err)
+	}
+
+	applied, err := s.applyModeration(ctx, actor, report, action)
+	if err != nil && !applied {
+		return nil, s.revertModeration(ctx, actor, report, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return moderated, nil
+}
+
+// checkModeration отсекает решения, которые заведомо нельзя применить
+// к жалобе, до их записи.
+func checkModeration(report *model.Report, action *model.ModerationAction) error {
+	switch action.Type {
+	case model.ModerationRemoveContent:
+		if report.TargetType != model.ReportTargetPost {
+			return fmt.Errorf("%w: only posts can be removed", model.ErrInvalidModerationAction)
+		}
+	case model.ModerationMarkSensitive:
+		if report.TargetType != model.ReportTargetPost {
+			return fmt.Errorf("%w: only posts can be marked sensitive", model.ErrInvalidModerationAction)
+		}
+	case model.ModerationSuspend, model.ModerationShadowBan:
+		if !action.SuspendUntil.After(action.CreatedAt) {
+			return fmt.Errorf("%w: %w: sanction must end in the future", model.ErrInvalidModerationAction, model.ErrInvalidSanction)
+		}
+	}
+	return nil
+}
+
+// applyModeration применяет записанное решение к цели жалобы и сообщает,
+// успела ли она изменить цель. Применение идемпотентно: уже удаленный пост
+// считается удаленным.
+func (s *ModerationService) applyModeration(
+	ctx context.Context,
+	actor *model.User,
+	report *model.Report,
+	action *model.ModerationAction,
+) (bool, error) {
+	var entry *model.AuditEntry
+	switch action.Type {
+	case model.ModerationRemoveContent:
+		if err := s.postRepo.DeletePost(report.TargetID); err != nil && !errors.Is(err, model.ErrPostNotFound) {
+			return false, err
+		}
+		entry = &model.AuditEntry{
+			Action:     model.AuditPostRemoved,
+			ActorID:    actor.ID,
+			TargetType: model.AuditTargetPost,
+			TargetID:   report.TargetID,
+			Details:    map[string]string{"report_id": report.ID.String()},
+		}
+	case model.ModerationMarkSensitive:
+		if _, err := s.postRepo.MarkPostSensitive(report.TargetID, ""); err != nil {
+			return false, err
+		}
+		entry = flaggedEntry(actor, report.TargetID, "", report.ID.String())
+	case model.ModerationSuspend, model.ModerationShadowBan:
+		userID, sanction, err := s.reportedSanction(report, action)
+		if err != nil {
+			return false, err
+		}
+		if _, err = s.storeCheckedSanction(actor, userID, sanction, action.CreatedAt); err != nil {
+			if errors.Is(err, model.ErrInvalidSanction) {
+				err = fmt.Errorf("%w: %w", model.ErrInvalidModerationAction, err)
+			}
+			return false, err
+		}
+		entry = sanctionEntry(actor, userID, sanction)
+	}
+
+	applied := entry != nil
+	if applied {
+		if err := audit(ctx, s.auditor, entry); err != nil {
+			return true, err
+		}
+	}
+
+	// Задержанный пост, который не удалили, публикуется.
+	if report.Reason == model.ReportReasonPolicy && action.Type != model.ModerationRemoveContent {
+		if err := s.postRepo.ReleasePost(report.TargetID); err != nil && !errors.Is(err, model.ErrPostNotFound) {
+			return applied, err
+		}
+	}
+	return applied, nil
+}
+
+// revertModeration снова открывает жалобу, решение по которой не удалось
+// применить, и отмечает это в журнале аудита. Возвращает исходную ошибку
+// вместе с ошибками отмены.
+func (s *ModerationService) revertModeration(ctx context.Context, actor *model.User, report *model.Report, cause error) error {
+	_, err := s.reportRepo.RevertModerationAction(report.ID)
+	if err != nil {
+		return errors.Join(cause, err)
+	}
+
+	return errors.Join(cause, audit(ctx, s.auditor, &model.AuditEntry{
+		Action:     model.AuditModerationReverted,
+		ActorID:    actor.ID,
+		TargetType: model.AuditTargetReport,
+		TargetID:   report.ID,
+		Details:    auditDetails("error", cause.Error()),
+	}))
+}
+
+// MarkSensitive помечает пост как деликатный по решению модератора без
+// жалобы. Непустой contentWarning заменяет предупреждение автора; снять
+// пометку правкой автор не может.
+func (s *ModerationService) MarkSensitive(
+	ctx context.Context,
+	actorID uuid.UUID,
+	postID uuid.UUID,
+	contentWarning string,
+) (*model.Post, error) {
+	actor, err := s.moderator(actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	contentWarning = richtext.Sanitize(contentWarning)
+	if richtext.Length(contentWarning) > maxContentWarningLength {
+		return nil, fmt.Errorf("%w: max %d characters", model.ErrContentWarningTooLong, maxContentWarningLength)
+	}
+
+	return s.markSensitive(ctx, actor, postID, contentWarning, "")
+}
+
+func (s *ModerationService) markSensitive(
+	ctx context.Context,
+	actor *model.User,
+	postID uuid.UUID,
+	contentWarning string,
+	reportID string,
+) (*model.Post, error) {
+	post, err := s.postRepo.MarkPostSensitive(postID, contentWarning)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = audit(ctx, s.auditor, flaggedEntry(actor, postID, contentWarning, reportID)); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// flaggedEntry - запись аудита о пометке поста деликатным.
+func flaggedEntry(actor *model.User, postID uuid.UUID, contentWarning, reportID string) *model.AuditEntry {
+	return &model.AuditEntry{
+		Action:     model.AuditPostFlagged,
+		ActorID:    actor.ID,
+		TargetType: model.AuditTargetPost,
+		TargetID:   postID,
+		Details:    auditDetails("content_warning", contentWarning, "report_id", reportID),
+	}
+}
+
+// HoldForReview ставит пост, задержанный контент-политикой, в очередь
+// модерации жалобой от имени системы: ReporterID у нее нулевой, а в
+// комментарии перечислены сработавшие правила. Если такая жалоба уже
+// открыта, новая не создается.
+func (s *ModerationService) HoldForReview(ctx context.Context, post *model.Post, violations []model.PolicyViolation) error {
+	details := make([]string, len(violations))
+	for i, violation := range violations {
+		details[i] = fmt.Sprintf("%s (%s): %s", violation.Rule, violation.Action, violation.Detail)
+	}
+
+	_, err := s.reportRepo.CreateReport(&model.Report{
+		TargetType: model.ReportTargetPost,
+		TargetID:   post.ID,
+		Reason:     model.ReportReasonPolicy,
+		Comment:    strings.Join(details, "; "),
+		CreatedAt:  s.clock.Now(),
+	})
+	if errors.Is(err, model.ErrAlreadyReported) {
+		return nil
+	}
+	return err
+}
+
+// reportedSanction строит санкцию по решению модератора для пользователя
+// из жалобы или автора поста из жалобы.
+func (s *ModerationService) reportedSanction(
+	report *model.Report,
+	action *model.ModerationAction,
+) (uuid.UUID, *model.Sanction, error) {
+	userID := report.TargetID
+	if report.TargetType == model.ReportTargetPost {
+		post, err := s.postRepo.GetPost(report.TargetID, uuid.Nil)
+		if err != nil {
+			return uuid.Nil, nil, err
+		}
+		userID = post.AuthorID
+	}
+
+	sanction := &model.Sanction{
+		Type:   model.SanctionSuspension,
+		Reason: action.Note,
+		Until:  action.SuspendUntil,
+	}
+	if action.Type == model.ModerationShadowBan {
+		sanction.Type = model.SanctionShadowBan
+	}
+	if sanction.Reason == "" {
+		sanction.Reason = string(report.Reason)
+	}
+	return userID, sanction, nil
+}
+
+// Sanction блокирует пользователя или включает ему теневой бан до
+// sanction.Until. Повторная санкция того же типа заменяет предыдущую.
+func (s *ModerationService) Sanction(
+	ctx context.Context,
+	actorID uuid.UUID,
+	userID uuid.UUID,
+	sanction *model.Sanction,
+) (*model.User, error) {
+	actor, err := s.moderator(actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	sanction.Reason = strings.TrimSpace(sanction.Reason)
+	if utf8.RuneCountInString(sanction.Reason) > maxModerationNoteLength {
+		return nil, fmt.Errorf("%w: reason is longer than %d characters", model.ErrInvalidSanction, maxModerationNoteLength)
+	}
+
+	return s.applySanction(ctx, actor, userID, sanction, s.clock.Now())
+}
+
+// LiftSanction досрочно снимает с пользователя санкцию типа sanctionType.
+func (s *ModerationService) LiftSanction(
+	ctx context.Context,
+	actorID uuid.UUID,
+	userID uuid.UUID,
+	sanctionType model.SanctionType,
+) (*model.User, error) {
+	actor, err := s.moderator(actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	lift := &model.Sanction{Type: sanctionType}
+	if !lift.Type.Valid() {
+		return nil, fmt.Errorf("%w: unknown sanction %q", model.ErrInvalidSanction, sanctionType)
+	}
+
+	if _, err = s.sanctionTarget(actor, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.storeSanction(userID, lift)
+	if err != nil {
+		return nil, err
+	}
+
+	err = audit(ctx, s.auditor, &model.AuditEntry{
+		Action:     model.AuditSanctionLifted,
+		ActorID:    actor.ID,
+		TargetType: model.AuditTargetUser,
+		TargetID:   userID,
+		Details:    map[string]string{"sanction": string(sanctionType)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// LiftExpiredSanctions снимает санкции, срок которых истек к now.
+// Вызывается фоновой задачей.
+func (s *ModerationService) LiftExpiredSanctions(ctx context.Context, now time.Time) (int, error) {
+	return s.userRepo.LiftExpiredSanctions(now)
+}
+
+func (s *ModerationService) applySanction(
+	ctx context.Context,
+	actor *model.User,
+	userID uuid.UUID,
+	sanction *model.Sanction,
+	now time.Time,
+) (*model.User, error) {
+	user, err := s.storeCheckedSanction(actor, userID, sanction, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = audit(ctx, s.auditor, sanctionEntry(actor, userID, sanction)); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// storeCheckedSanction проверяет санкцию и права actor и сохраняет ее.
+func (s *ModerationService) storeCheckedSanction(
+	actor *model.User,
+	userID uuid.UUID,
+	sanction *model.Sanction,
+	now time.Time,
+) (*model.User, error) {
+	if !sanction.Type.Valid() {
+		return nil, fmt.Errorf("%w: unknown sanction %q", model.ErrInvalidSanction, sanction.Type)
+	}
+	if !sanction.Until.After(now) {
+		return nil, fmt.Errorf("%w: sanction must end in the future", model.ErrInvalidSanction)
+	}
+
+	if _, err := s.sanctionTarget(actor, userID); err != nil {
+		return nil, err
+	}
+	return s.storeSanction(userID, sanction)
+}
+
+// sanctionEntry - запись аудита о наложенной санкции.
+func sanctionEntry(actor *model.User, userID uuid.UUID, sanction *model.Sanction) *model.AuditEntry {
+	return &model.AuditEntry{
+		Action:     model.AuditUserSanctioned,
+		ActorID:    actor.ID,
+		TargetType: model.AuditTargetUser,
+		TargetID:   userID,
+		Details: auditDetails(
+			"sanction", string(sanction.Type),
+			"until", sanction.Until.Format(time.RFC3339),
+			"reason", sanction.Reason,
+		),
+	}
+}
+
+// sanctionTarget возвращает пользователя, которого actor вправе наказать:
+// не себя и не того, чья роль не ниже роли actor.
+func (s *ModerationService) sanctionTarget(actor *model.User, userID uuid.UUID) (*model.User, error) {
+	target, err := s.userRepo.GetUserById(userID)
+	if err != nil {
+		return nil, err
+	}
+	if target.ID == actor.ID || target.Role.AtLeast(actor.Role) {
+		return nil, model.ErrForbidden
+	}
+	return target, nil
+}
+
+// storeSanction сохраняет санкцию; нулевой Until снимает ее.
+func (s *ModerationService) storeSanction(userID uuid.UUID, sanction *model.Sanction) (*model.User, error) {
+	if sanction.Type == model.SanctionShadowBan {
+		return s.userRepo.ShadowBanUser(userID, sanction.Reason, sanction.Until)
+	}
+	return s.userRepo.SuspendUser(userID, sanction.Reason, sanction.Until)
+}
+
+// moderator возвращает пользователя actorID, если он модератор или
+// администратор.
+func (s *ModerationService) moderator(actorID uuid.UUID) (*model.User, error) {
+	return actorWithRole(s.userRepo, actorID, model.RoleModerator)
+}
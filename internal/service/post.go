@@ -2,59 +2,857 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"time"
 
+	"github.com/google/uuid"
+	"micro-blog/internal/clock"
 	"micro-blog/internal/model"
 	"micro-blog/internal/queue"
+	"micro-blog/internal/richtext"
+)
+
+const (
+	maxPollOptionLength     = 100
+	maxPollDuration         = 30 * 24 * time.Hour
+	maxContentWarningLength = 100
 )
 
 type PostRepository interface {
 	CreatePost(post *model.Post) (*model.Post, error)
-	GetListPost() ([]*model.Post, error)
-	LikePost(like *model.Like) error
+	GetListPost(viewerID uuid.UUID) ([]*model.Post, error)
+	ReactToPost(reaction *model.Reaction) error
+	CountPostsByAuthor(authorID uuid.UUID, now time.Time) int
+	GetPost(postID uuid.UUID, viewerID uuid.UUID) (*model.Post, error)
+	EditPost(edit *model.PostEdit) (*model.Post, error)
+	GetPostRevisions(postID uuid.UUID) ([]*model.PostRevision, error)
+	DeleteExpiredPosts(now time.Time) (int, error)
+	DeletePost(postID uuid.UUID) error
+	GetAuthorPosts(
+		authorID uuid.UUID,
+		viewerID uuid.UUID,
+		filter model.AuthorFeedFilter,
+		before int64,
+		limit int,
+	) ([]*model.Post, int64, error)
+	PinPost(postID uuid.UUID, pinnedAt time.Time, maxPinned int) error
+	VotePoll(vote *model.PollVote) error
+	UnpinPost(postID uuid.UUID) error
+	GetPostReactions(postID uuid.UUID, reactionType model.ReactionType, after int64, limit int) ([]*model.Reaction, int64, error)
+	CountReactionsBy(postIDs, userIDs []uuid.UUID) (map[uuid.UUID]map[model.ReactionType]int, error)
+	ReleasePost(postID uuid.UUID) error
+	MarkPostSensitive(postID uuid.UUID, contentWarning string) (*model.Post, error)
+	GetPostsWithAttachment(mediaID uuid.UUID) ([]*model.Post, error)
+}
+
+// ContentPolicy проверяет пост перед публикацией и после правки.
+type ContentPolicy interface {
+	Check(post *model.Post) []model.PolicyViolation
+}
+
+// PostReviewer ставит пост, задержанный контент-политикой, в очередь
+// модерации.
+type PostReviewer interface {
+	HoldForReview(ctx context.Context, post *model.Post, violations []model.PolicyViolation) error
+}
+
+// SpamGuard оценивает посты и реакции по признакам спама.
+type SpamGuard interface {
+	CheckPost(ctx context.Context, author *model.User, post *model.Post) (bool, error)
+	CheckLike(ctx context.Context, user *model.User) error
 }
 
 type PostService struct {
-	postRepo  PostRepository
-	userRepo  UserRepository
-	likeQueue queue.LikeEnqueuer
+	postRepo   PostRepository
+	userRepo   UserRepository
+	followRepo FollowRepository
+	mediaRepo  MediaRepository
+	likeQueue  queue.LikeEnqueuer
+	voteQueue  queue.VoteEnqueuer
+	policy     ContentPolicy
+	reviewer   PostReviewer
+	spam       SpamGuard
+	limits     model.PostLimits
+	clock      clock.Clock
 }
 
-func NewPostService(pr PostRepository, up UserRepository) *PostService {
+func NewPostService(
+	pr PostRepository,
+	up UserRepository,
+	fr FollowRepository,
+	mr MediaRepository,
+	limits model.PostLimits,
+) *PostService {
 	return &PostService{
-		postRepo: pr,
-		userRepo: up,
+		postRepo:   pr,
+		userRepo:   up,
+		followRepo: fr,
+		mediaRepo:  mr,
+		limits:     limits,
+		clock:      clock.Real{},
 	}
 }
 
 func (s *PostService) CreatePost(ctx context.Context, post *model.Post) (*model.Post, error) {
-	if _, err := s.userRepo.GetUserById(post.AuthorID); err != nil {
+	author, err := activeUser(s.userRepo, post.AuthorID, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if post.Visibility == "" {
+		post.Visibility = model.VisibilityPublic
+	}
+	if !post.Visibility.Valid() {
+		return nil, model.ErrInvalidVisibility
+	}
+
+	if err = s.checkReferences(ctx, post); err != nil {
+		return nil, err
+	}
+
+	if err = s.prepareText(post); err != nil {
 		return nil, err
 	}
 
-	return s.postRepo.CreatePost(post)
+	if err = s.checkAttachments(post.AuthorID, post.AttachmentIDs); err != nil {
+		return nil, err
+	}
+
+	if err = prepareContentWarning(post); err != nil {
+		return nil, err
+	}
+
+	violations, err := s.applyPolicy(post)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.TTL < 0 || post.TTL > s.limits.MaxTTL {
+		return nil, fmt.Errorf("%w: max %s", model.ErrInvalidTTL, s.limits.MaxTTL)
+	}
+
+	now := s.clock.Now()
+	if err = checkPoll(post.Poll, now); err != nil {
+		return nil, err
+	}
+
+	if violations, err = s.checkSpam(ctx, author, post, violations); err != nil {
+		return nil, err
+	}
+
+	post.CreatedAt = now
+	if post.TTL > 0 {
+		post.ExpiresAt = post.CreatedAt.Add(post.TTL)
+	}
+
+	created, err := s.postRepo.CreatePost(post)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.holdForReview(ctx, created, violations); err != nil {
+		return nil, err
+	}
+	hidePollResults(created, now)
+	collapseSensitive([]*model.Post{created}, author)
+	return created, nil
+}
+
+// EditPost меняет текст поста. Править может только автор и только
+// в течение окна редактирования после публикации. Новый текст проходит
+// контент-политику так же, как при создании.
+func (s *PostService) EditPost(ctx context.Context, editorID uuid.UUID, postID uuid.UUID, text string) (*model.Post, error) {
+	post, err := s.postRepo.GetPost(postID, editorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.AuthorID != editorID {
+		if !s.canView(post, editorID) {
+			return nil, model.ErrPostNotFound
+		}
+		return nil, model.ErrForbidden
+	}
+
+	now := s.clock.Now()
+	editor, err := activeUser(s.userRepo, editorID, now)
+	if err != nil {
+		return nil, err
+	}
+	if now.Sub(post.CreatedAt) > s.limits.EditWindow {
+		return nil, model.ErrEditWindowClosed
+	}
+
+	post.Text = text
+	if err = s.prepareText(post); err != nil {
+		return nil, err
+	}
+
+	violations, err := s.applyPolicy(post)
+	if err != nil {
+		return nil, err
+	}
+
+	edited, err := s.postRepo.EditPost(&model.PostEdit{
+		PostID:    postID,
+		Text:      post.Text,
+		Entities:  post.Entities,
+		EditedAt:  now,
+		Sensitive: post.Sensitive,
+		Held:      post.Held,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = s.holdForReview(ctx, edited, violations); err != nil {
+		return nil, err
+	}
+	hidePollResults(edited, now)
+	collapseSensitive([]*model.Post{edited}, editor)
+	return edited, nil
+}
+
+func (s *PostService) GetPostRevisions(ctx context.Context, viewerID uuid.UUID, postID uuid.UUID) ([]*model.PostRevision, error) {
+	if _, err := s.GetPost(ctx, viewerID, postID); err != nil {
+		return nil, err
+	}
+	return s.postRepo.GetPostRevisions(postID)
+}
+
+// GetPost возвращает пост, если зритель имеет к нему доступ. Недоступный
+// пост неотличим от несуществующего, чтобы не раскрывать его наличие.
+func (s *PostService) GetPost(ctx context.Context, viewerID uuid.UUID, postID uuid.UUID) (*model.Post, error) {
+	post, err := s.postRepo.GetPost(postID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	if post.Expired(now) || !s.canView(post, viewerID) {
+		return nil, model.ErrPostNotFound
+	}
+	hidePollResults(post, now)
+	s.hideShadowReactions([]*model.Post{post}, viewerID, now)
+	s.collapseForViewer([]*model.Post{post}, viewerID)
+	return post, nil
+}
+
+// canView проверяет доступ зрителя к посту. Автор видит свои посты всегда,
+// анонимный зритель - только публичные и скрытые из ленты. Задержанные
+// посты и посты заблокированного автора или автора под теневым баном
+// не видны никому, кроме автора.
+func (s *PostService) canView(post *model.Post, viewerID uuid.UUID) bool {
+	if viewerID != uuid.Nil && post.AuthorID == viewerID {
+		return true
+	}
+	if post.Held {
+		return false
+	}
+	if viewerID != uuid.Nil && s.followRepo.IsBlocked(viewerID, post.AuthorID) {
+		return false
+	}
+
+	author, err := s.userRepo.GetUserById(post.AuthorID)
+	if err == nil && author.Hidden(s.clock.Now()) {
+		return false
+	}
+	if err == nil && author.Private && (viewerID == uuid.Nil || !s.followRepo.IsFollowing(viewerID, post.AuthorID)) {
+		return false
+	}
+
+	switch post.Visibility {
+	case model.VisibilityFollowers:
+		return viewerID != uuid.Nil && s.followRepo.IsFollowing(viewerID, post.AuthorID)
+	case model.VisibilityMentioned:
+		if viewerID == uuid.Nil {
+			return false
+		}
+		for _, entity := range post.Entities {
+			if entity.Type == model.EntityMention && entity.UserID == viewerID {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// MediaAccess определяет доступ зрителя к медиафайлу по постам, к которым
+// он приложен: файл виден, если виден хотя бы один такой пост, и публичен,
+// если такой пост доступен без входа и не исчезнет по таймеру. Превью
+// наследует доступ исходного изображения. Владелец видит свои файлы всегда,
+// в том числе еще не приложенные к постам. Текущий аватар публичен: его
+// показывают рядом с профилем любому зрителю.
+func (s *PostService) MediaAccess(media *model.Media, viewerID uuid.UUID) (visible bool, public bool, err error) {
+	mediaID := media.ID
+	if media.OriginalID != uuid.Nil {
+		mediaID = media.OriginalID
+	}
+
+	if owner, err := s.userRepo.GetUserById(media.OwnerID); err == nil && owner.AvatarID == mediaID.String() {
+		return true, true, nil
+	}
+
+	posts, err := s.postRepo.GetPostsWithAttachment(mediaID)
+	if err != nil {
+		return false, false, err
+	}
+
+	visible = viewerID != uuid.Nil && media.OwnerID == viewerID
+	now := s.clock.Now()
+	for _, post := range posts {
+		if post.Expired(now) {
+			continue
+		}
+		if post.ExpiresAt.IsZero() && s.canView(post, uuid.Nil) {
+			return true, true, nil
+		}
+		if !visible && s.canView(post, viewerID) {
+			visible = true
+		}
+	}
+	return visible, false, nil
+}
+
+// isPrivateAuthor сообщает, что автор закрыл аккаунт. Посты такого
+// автора видят только он сам и одобренные подписчики.
+func (s *PostService) isPrivateAuthor(authorID uuid.UUID) bool {
+	author, err := s.userRepo.GetUserById(authorID)
+	return err == nil && author.Private
+}
+
+// inFeed проверяет, попадает ли пост в ленту зрителя. Скрытые из ленты
+// посты показываются только автору, посты заглушенных авторов молча
+// пропускаются.
+func (s *PostService) inFeed(post *model.Post, viewerID uuid.UUID) bool {
+	if post.Visibility == model.VisibilityUnlisted {
+		return viewerID != uuid.Nil && post.AuthorID == viewerID
+	}
+	if viewerID != uuid.Nil && s.followRepo.IsMuted(viewerID, post.AuthorID) {
+		return false
+	}
+	return s.canView(post, viewerID)
+}
+
+// checkReferences проверяет, что пост, на который отвечают или которым
+// делятся, доступен автору. Поделиться можно только постом, открытым всем,
+// иначе репост раскрыл бы его тем, кому он не предназначен.
+func (s *PostService) checkReferences(ctx context.Context, post *model.Post) error {
+	if post.IsReply() && post.IsRepost() {
+		return model.ErrInvalidRepost
+	}
+
+	if post.IsReply() {
+		if _, err := s.GetPost(ctx, post.AuthorID, post.ReplyToID); err != nil {
+			return err
+		}
+	}
+
+	if post.IsRepost() {
+		original, err := s.GetPost(ctx, post.AuthorID, post.RepostOfID)
+		if err != nil {
+			return err
+		}
+		if original.Visibility != model.VisibilityPublic && original.Visibility != model.VisibilityUnlisted ||
+			s.isPrivateAuthor(original.AuthorID) {
+			return model.ErrInvalidRepost
+		}
+	}
+	return nil
+}
+
+// prepareText очищает текст, проверяет его длину и размечает сущности.
+// Пустой текст допустим только у поста с вложениями и у репоста.
+func (s *PostService) prepareText(post *model.Post) error {
+	post.Text = richtext.Sanitize(post.Text)
+
+	if post.Text == "" && len(post.AttachmentIDs) == 0 && !post.IsRepost() {
+		return model.ErrEmptyPost
+	}
+
+	if richtext.Length(post.Text) > s.limits.MaxTextLength {
+		return fmt.Errorf("%w: max %d characters", model.ErrPostTooLong, s.limits.MaxTextLength)
+	}
+
+	post.Entities = s.resolveMentions(post.AuthorID, richtext.Parse(post.Text))
+	return nil
+}
+
+// prepareContentWarning очищает предупреждение и проверяет, что скрытые
+// вложения входят во вложения поста.
+func prepareContentWarning(post *model.Post) error {
+	post.ContentWarning = richtext.Sanitize(post.ContentWarning)
+	if richtext.Length(post.ContentWarning) > maxContentWarningLength {
+		return fmt.Errorf("%w: max %d characters", model.ErrContentWarningTooLong, maxContentWarningLength)
+	}
+
+	for _, id := range post.SensitiveAttachmentIDs {
+		if !slices.Contains(post.AttachmentIDs, id) {
+			return fmt.Errorf("%w: %s is not attached to the post", model.ErrInvalidAttachment, id)
+		}
+	}
+	return nil
+}
+
+// resolveMentions проставляет ID упомянутым пользователям, если они
+// существуют. Упоминание пользователя, с которым у автора блокировка,
+// остается простым текстом: оно не дает доступа к посту.
+func (s *PostService) resolveMentions(authorID uuid.UUID, entities []model.Entity) []model.Entity {
+	for i, entity := range entities {
+		if entity.Type != model.EntityMention {
+			continue
+		}
+
+		name, err := model.NormalizeUsername(entity.Text)
+		if err != nil {
+			continue
+		}
+
+		if user, err := s.userRepo.GetUserByName(name); err == nil && !s.followRepo.IsBlocked(authorID, user.ID) {
+			entities[i].UserID = user.ID
+		}
+	}
+	return entities
+}
+
+// applyPolicy проверяет пост контент-политикой. Отклоненный пост
+// возвращается как *model.PolicyError, иначе на пост ставятся пометки
+// сработавших правил. Пометки только добавляются: правка не снимает
+// задержку, наложенную раньше. Возвращает нарушения, если пост задержан.
+func (s *PostService) applyPolicy(post *model.Post) ([]model.PolicyViolation, error) {
+	if s.policy == nil {
+		return nil, nil
+	}
+
+	violations := s.policy.Check(post)
+	for _, violation := range violations {
+		if violation.Action == model.PolicySensitive {
+			post.Sensitive = true
+		}
+	}
+
+	switch model.StrictestAction(violations) {
+	case model.PolicyReject:
+		return nil, &model.PolicyError{Violations: violations}
+	case model.PolicyHold:
+		post.Held = true
+		return violations, nil
+	default:
+		return nil, nil
+	}
 }
 
-func (s *PostService) GetListPost(ctx context.Context) ([]*model.Post, error) {
-	return s.postRepo.GetListPost()
+// checkSpam проверяет новый пост на спам. Пост аккаунта в карантине
+// задерживается до проверки модератором.
+func (s *PostService) checkSpam(
+	ctx context.Context,
+	author *model.User,
+	post *model.Post,
+	violations []model.PolicyViolation,
+) ([]model.PolicyViolation, error) {
+	if s.spam == nil {
+		return violations, nil
+	}
+
+	quarantined, err := s.spam.CheckPost(ctx, author, post)
+	if err != nil || !quarantined {
+		return violations, err
+	}
+
+	post.Held = true
+	return append(violations, model.PolicyViolation{
+		Rule:   spamQuarantineRule,
+		Action: model.PolicyHold,
+		Detail: "author is quarantined as a suspected spammer",
+	}), nil
 }
 
-func (s *PostService) LikePost(ctx context.Context, like *model.Like) error {
-	if _, err := s.userRepo.GetUserById(like.UserID); err != nil {
+// holdForReview отправляет задержанный пост модераторам.
+func (s *PostService) holdForReview(ctx context.Context, post *model.Post, violations []model.PolicyViolation) error {
+	if len(violations) == 0 || s.reviewer == nil {
+		return nil
+	}
+	return s.reviewer.HoldForReview(ctx, post, violations)
+}
+
+// checkAttachments проверяет, что вложения существуют и загружены автором поста.
+func (s *PostService) checkAttachments(authorID uuid.UUID, ids []uuid.UUID) error {
+	for _, id := range ids {
+		media, err := s.mediaRepo.GetMedia(id)
+		if err != nil || media.OwnerID != authorID {
+			return fmt.Errorf("%w: %s", model.ErrInvalidAttachment, id)
+		}
+	}
+	return nil
+}
+
+func (s *PostService) GetListPost(ctx context.Context, viewerID uuid.UUID) ([]*model.Post, error) {
+	posts, err := s.postRepo.GetListPost(viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	visible := posts[:0]
+	for _, post := range posts {
+		if !post.Expired(now) && s.inFeed(post, viewerID) {
+			hidePollResults(post, now)
+			visible = append(visible, post)
+		}
+	}
+	s.hideShadowReactions(visible, viewerID, now)
+	s.collapseForViewer(visible, viewerID)
+	return visible, nil
+}
+
+// ReactToPost ставит реакцию асинхронно через очередь.
+// Реакция с типом ReactionNone снимает текущую реакцию пользователя.
+func (s *PostService) ReactToPost(ctx context.Context, reaction *model.Reaction) error {
+	if reaction.Type != model.ReactionNone && !reaction.Type.Valid() {
+		return model.ErrInvalidReaction
+	}
+
+	user, err := activeUser(s.userRepo, reaction.UserID, s.clock.Now())
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.GetPost(ctx, reaction.UserID, reaction.PostID); err != nil {
 		return err
 	}
 
+	if s.spam != nil && reaction.Type != model.ReactionNone {
+		if err = s.spam.CheckLike(ctx, user); err != nil {
+			return err
+		}
+	}
+
 	if s.likeQueue == nil {
 		return model.ErrLikeQueue
 	}
 
-	s.likeQueue.Enqueue(like)
+	s.likeQueue.Enqueue(reaction)
 	return nil
 }
 
-func (s *PostService) HandleLike(ctx context.Context, like *model.Like) error {
-	return s.postRepo.LikePost(like)
+func (s *PostService) GetPostReactions(
+	ctx context.Context,
+	viewerID uuid.UUID,
+	postID uuid.UUID,
+	reactionType model.ReactionType,
+	cursor int64,
+	limit int,
+) (*model.ReactionPage, error) {
+	if _, err := s.GetPost(ctx, viewerID, postID); err != nil {
+		return nil, err
+	}
+
+	reactions, next, err := s.postRepo.GetPostReactions(postID, reactionType, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	page := make([]*model.UserReaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		if viewerID != uuid.Nil && s.followRepo.IsBlocked(viewerID, reaction.UserID) {
+			continue
+		}
+		user, err := s.userRepo.GetUserById(reaction.UserID)
+		if err != nil {
+			continue
+		}
+		if user.ID != viewerID && user.ShadowBanned(now) {
+			continue
+		}
+		page = append(page, &model.UserReaction{User: user, Type: reaction.Type})
+	}
+
+	return &model.ReactionPage{
+		Reactions:  page,
+		NextCursor: next,
+	}, nil
+}
+
+func (s *PostService) HandleLike(ctx context.Context, reaction *model.Reaction) error {
+	return s.postRepo.ReactToPost(reaction)
+}
+
+// GetAuthorPosts возвращает страницу ленты автора: закрепленные посты
+// первыми, затем остальные от новых к старым. Скрытые из общей ленты посты
+// здесь видны, недоступные зрителю - нет. Доступ проверяется вне
+// репозитория, поэтому страница добирается, пока не наберется limit
+// видимых постов или лента не кончится.
+func (s *PostService) GetAuthorPosts(
+	ctx context.Context,
+	viewerID uuid.UUID,
+	authorID uuid.UUID,
+	filter model.AuthorFeedFilter,
+	cursor int64,
+	limit int,
+) (*model.PostPage, error) {
+	if _, err := s.userRepo.GetUserById(authorID); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	posts := make([]*model.Post, 0, limit)
+	taken := 0
+	for {
+		batch, next, err := s.postRepo.GetAuthorPosts(authorID, viewerID, filter, cursor, limit-taken)
+		if err != nil {
+			return nil, err
+		}
+		for _, post := range batch {
+			if post.Expired(now) || !s.canView(post, viewerID) {
+				continue
+			}
+			posts = append(posts, post)
+			if post.PinnedAt.IsZero() {
+				taken++
+			}
+		}
+		cursor = next
+		if next == 0 || taken == limit {
+			break
+		}
+	}
+
+	for _, post := range posts {
+		hidePollResults(post, now)
+	}
+	s.hideShadowReactions(posts, viewerID, now)
+	s.collapseForViewer(posts, viewerID)
+
+	return &model.PostPage{
+		Posts:      posts,
+		NextCursor: cursor,
+	}, nil
+}
+
+// PinPost закрепляет собственный пост пользователя в его профиле.
+func (s *PostService) PinPost(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error {
+	if err := s.checkOwnPost(ctx, userID, postID); err != nil {
+		return err
+	}
+	return s.postRepo.PinPost(postID, s.clock.Now(), s.limits.MaxPinned)
+}
+
+func (s *PostService) UnpinPost(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error {
+	if err := s.checkOwnPost(ctx, userID, postID); err != nil {
+		return err
+	}
+	return s.postRepo.UnpinPost(postID)
+}
+
+// checkOwnPost проверяет, что пост доступен пользователю и принадлежит ему.
+func (s *PostService) checkOwnPost(ctx context.Context, userID uuid.UUID, postID uuid.UUID) error {
+	post, err := s.GetPost(ctx, userID, postID)
+	if err != nil {
+		return err
+	}
+
+	if post.AuthorID != userID {
+		return model.ErrForbidden
+	}
+	return nil
+}
+
+// PurgeExpired удаляет посты, срок жизни которых истек. Истекший пост
+// скрыт из выдачи и до удаления, поэтому проходы уборщика могут быть редкими.
+func (s *PostService) PurgeExpired(ctx context.Context, now time.Time) (int, error) {
+	return s.postRepo.DeleteExpiredPosts(now)
+}
+
+// SetClock подменяет источник времени сервиса.
+func (s *PostService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// VotePoll принимает голос в опросе и учитывает его асинхронно через очередь.
+// Здесь отсекаются заведомо неверные голоса; единственность голоса
+// окончательно проверяет репозиторий при учете.
+func (s *PostService) VotePoll(ctx context.Context, vote *model.PollVote) error {
+	if _, err := activeUser(s.userRepo, vote.UserID, s.clock.Now()); err != nil {
+		return err
+	}
+
+	post, err := s.GetPost(ctx, vote.UserID, vote.PostID)
+	if err != nil {
+		return err
+	}
+
+	poll := post.Poll
+	if poll == nil {
+		return model.ErrPollNotFound
+	}
+
+	now := s.clock.Now()
+	if poll.Closed(now) {
+		return model.ErrPollClosed
+	}
+	if len(poll.MyChoices) > 0 {
+		return model.ErrAlreadyVoted
+	}
+	if err = checkChoices(poll, vote.Choices); err != nil {
+		return err
+	}
+
+	if s.voteQueue == nil {
+		return model.ErrVoteQueue
+	}
+
+	vote.VotedAt = now
+	s.voteQueue.Enqueue(vote)
+	return nil
+}
+
+func (s *PostService) HandleVote(ctx context.Context, vote *model.PollVote) error {
+	return s.postRepo.VotePoll(vote)
+}
+
+func (s *PostService) AttachVoteQueue(q queue.VoteEnqueuer) {
+	s.voteQueue = q
 }
 
 func (s *PostService) AttachLikeQueue(q queue.LikeEnqueuer) {
 	s.likeQueue = q
 }
+
+// AttachContentPolicy включает проверку постов политикой. Задержанные
+// посты передаются reviewer.
+func (s *PostService) AttachContentPolicy(policy ContentPolicy, reviewer PostReviewer) {
+	s.policy = policy
+	s.reviewer = reviewer
+}
+
+// AttachSpamGuard включает антиспам-проверки постов и реакций.
+func (s *PostService) AttachSpamGuard(guard SpamGuard) {
+	s.spam = guard
+}
+
+// checkPoll проверяет опрос нового поста и очищает тексты вариантов.
+func checkPoll(poll *model.Poll, now time.Time) error {
+	if poll == nil {
+		return nil
+	}
+
+	if len(poll.Options) < model.MinPollOptions || len(poll.Options) > model.MaxPollOptions {
+		return fmt.Errorf("%w: %d to %d options required", model.ErrInvalidPoll, model.MinPollOptions, model.MaxPollOptions)
+	}
+
+	for i := range poll.Options {
+		text := richtext.Sanitize(poll.Options[i].Text)
+		if text == "" || richtext.Length(text) > maxPollOptionLength {
+			return fmt.Errorf("%w: option %d must be 1 to %d characters", model.ErrInvalidPoll, i+1, maxPollOptionLength)
+		}
+		poll.Options[i] = model.PollOption{Text: text}
+	}
+
+	if !poll.ClosesAt.After(now) || poll.ClosesAt.Sub(now) > maxPollDuration {
+		return fmt.Errorf("%w: must close within %s", model.ErrInvalidPoll, maxPollDuration)
+	}
+
+	poll.VotersCount = 0
+	poll.MyChoices = nil
+	poll.ResultsHidden = false
+	return nil
+}
+
+// checkChoices проверяет выбор: варианты существуют и не повторяются,
+// в опросе с одним ответом выбран ровно один.
+func checkChoices(poll *model.Poll, choices []int) error {
+	if len(choices) == 0 || (!poll.Multiple && len(choices) > 1) {
+		return model.ErrInvalidVote
+	}
+
+	seen := make(map[int]struct{}, len(choices))
+	for _, choice := range choices {
+		if choice < 0 || choice >= len(poll.Options) {
+			return model.ErrInvalidVote
+		}
+		if _, ok := seen[choice]; ok {
+			return model.ErrInvalidVote
+		}
+		seen[choice] = struct{}{}
+	}
+	return nil
+}
+
+// hideShadowReactions убирает из счетчиков реакций реакции пользователей
+// под теневым баном. Свои реакции такой пользователь продолжает видеть.
+// Реакции забаненных считаются одним запросом на всю страницу постов.
+func (s *PostService) hideShadowReactions(posts []*model.Post, viewerID uuid.UUID, now time.Time) {
+	if len(posts) == 0 {
+		return
+	}
+
+	banned, err := s.userRepo.ListShadowBanned(now)
+	if err != nil {
+		return
+	}
+	banned = slices.DeleteFunc(banned, func(userID uuid.UUID) bool { return userID == viewerID })
+	if len(banned) == 0 {
+		return
+	}
+
+	postIDs := make([]uuid.UUID, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+	hidden, err := s.postRepo.CountReactionsBy(postIDs, banned)
+	if err != nil {
+		return
+	}
+
+	for _, post := range posts {
+		for reactionType, count := range hidden[post.ID] {
+			post.Reactions[reactionType] -= count
+			if post.Reactions[reactionType] <= 0 {
+				delete(post.Reactions, reactionType)
+			}
+		}
+	}
+}
+
+// collapseForViewer сворачивает деликатные посты для зрителя viewerID.
+// Настройки зрителя загружаются, только если такие посты есть.
+func (s *PostService) collapseForViewer(posts []*model.Post, viewerID uuid.UUID) {
+	var viewer *model.User
+	if viewerID != uuid.Nil && slices.ContainsFunc(posts, (*model.Post).HasSensitiveContent) {
+		if user, err := s.userRepo.GetUserById(viewerID); err == nil {
+			viewer = user
+		}
+	}
+	collapseSensitive(posts, viewer)
+}
+
+// collapseSensitive сворачивает посты с деликатным содержимым, если
+// зритель не включил их автоматическое раскрытие. Анонимному зрителю
+// такие посты всегда приходят свернутыми.
+func collapseSensitive(posts []*model.Post, viewer *model.User) {
+	if viewer != nil && viewer.ExpandSensitive {
+		return
+	}
+	for _, post := range posts {
+		if post.HasSensitiveContent() {
+			post.Collapsed = true
+		}
+	}
+}
+
+// hidePollResults скрывает результаты опроса от зрителя, который еще
+// не голосовал, пока опрос открыт.
+func hidePollResults(post *model.Post, now time.Time) {
+	poll := post.Poll
+	if poll == nil || len(poll.MyChoices) > 0 || poll.Closed(now) {
+		return
+	}
+
+	for i := range poll.Options {
+		poll.Options[i].Votes = 0
+	}
+	poll.VotersCount = 0
+	poll.ResultsHidden = true
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/model"
+	"micro-blog/internal/richtext"
+)
+
+type FollowRepository interface {
+	Follow(followerID, followeeID uuid.UUID) error
+	Unfollow(followerID, followeeID uuid.UUID) error
+	CountFollowers(userID uuid.UUID) int
+	CountFollowing(userID uuid.UUID) int
+	IsFollowing(followerID, followeeID uuid.UUID) bool
+	Block(relation *model.Relation) error
+	Unblock(ownerID, targetID uuid.UUID) error
+	IsBlocked(userID, otherID uuid.UUID) bool
+	ListBlocked(ownerID uuid.UUID, before int64, limit int) ([]*model.Relation, int64, error)
+	Mute(relation *model.Relation) error
+	Unmute(ownerID, targetID uuid.UUID) error
+	IsMuted(ownerID, targetID uuid.UUID) bool
+	ListMuted(ownerID uuid.UUID, before int64, limit int) ([]*model.Relation, int64, error)
+	RequestFollow(request *model.FollowRequest) error
+	HasFollowRequest(followerID, followeeID uuid.UUID) bool
+	ListFollowRequests(followeeID uuid.UUID, before int64, limit int) ([]*model.FollowRequest, int64, error)
+	ApproveFollowRequest(followeeID, followerID uuid.UUID) error
+	RejectFollowRequest(followeeID, followerID uuid.UUID) error
+	ApproveAllFollowRequests(followeeID uuid.UUID) error
+}
+
+type ProfileService struct {
+	userRepo   UserRepository
+	postRepo   PostRepository
+	followRepo FollowRepository
+	mediaRepo  MediaRepository
+	clock      clock.Clock
+}
+
+func NewProfileService(ur UserRepository, pr PostRepository, fr FollowRepository, mr MediaRepository) *ProfileService {
+	return &ProfileService{
+		userRepo:   ur,
+		postRepo:   pr,
+		followRepo: fr,
+		mediaRepo:  mr,
+		clock:      clock.Real{},
+	}
+}
+
+// SetClock подменяет источник времени сервиса.
+func (s *ProfileService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+func (s *ProfileService) GetProfile(ctx context.Context, userID uuid.UUID) (*model.Profile, error) {
+	user, err := s.userRepo.GetUserById(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildProfile(user), nil
+}
+
+// GetProfileByName ищет профиль по имени в любом написании. Если имя
+// принадлежало переименованному пользователю, возвращает *model.UsernameMovedError.
+func (s *ProfileService) GetProfileByName(ctx context.Context, name string) (*model.Profile, error) {
+	canonical, err := model.NormalizeUsername(name)
+	if err != nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	user, err := s.userRepo.GetUserByName(canonical)
+	if err == nil {
+		return s.buildProfile(user), nil
+	}
+
+	if moved, aliasErr := s.userRepo.GetUserByAlias(canonical); aliasErr == nil {
+		return nil, &model.UsernameMovedError{NewName: moved.Name}
+	}
+
+	return nil, err
+}
+
+// UpdateProfile очищает текстовые поля так же, как текст постов, и
+// принимает только сайты со схемой http или https.
+func (s *ProfileService) UpdateProfile(ctx context.Context, userID uuid.UUID, update *model.ProfileUpdate) (*model.Profile, error) {
+	for _, field := range []*string{update.DisplayName, update.Bio, update.Location} {
+		if field != nil {
+			*field = richtext.Sanitize(*field)
+		}
+	}
+	if update.Website != nil && *update.Website != "" && !isWebURL(*update.Website) {
+		return nil, model.ErrInvalidWebsite
+	}
+
+	if update.AvatarID != nil && *update.AvatarID != "" {
+		if err := s.checkAvatar(userID, *update.AvatarID); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := s.userRepo.UpdateUser(userID, update)
+	if err != nil {
+		return nil, err
+	}
+
+	// Открытый аккаунт не может держать запросы в ожидании.
+	if update.Private != nil && !user.Private {
+		if err = s.followRepo.ApproveAllFollowRequests(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.buildProfile(user), nil
+}
+
+// Follow подписывает на открытый аккаунт сразу, а на закрытый оставляет
+// запрос, который владелец одобряет или отклоняет.
+func (s *ProfileService) Follow(ctx context.Context, followerID, followeeID uuid.UUID) (model.FollowStatus, error) {
+	if followerID == followeeID {
+		return "", model.ErrSelfFollow
+	}
+
+	if _, err := activeUser(s.userRepo, followerID, s.clock.Now()); err != nil {
+		return "", err
+	}
+
+	followee, err := s.userRepo.GetUserById(followeeID)
+	if err != nil {
+		return "", err
+	}
+
+	if s.followRepo.IsBlocked(followerID, followeeID) {
+		return "", model.ErrBlocked
+	}
+
+	if followee.Private && !s.followRepo.IsFollowing(followerID, followeeID) {
+		err = s.followRepo.RequestFollow(&model.FollowRequest{
+			FollowerID: followerID,
+			FolloweeID: followeeID,
+			CreatedAt:  s.clock.Now(),
+		})
+		if err != nil {
+			return "", err
+		}
+		return model.FollowStatusRequested, nil
+	}
+
+	if err = s.followRepo.Follow(followerID, followeeID); err != nil {
+		return "", err
+	}
+	return model.FollowStatusFollowing, nil
+}
+
+func (s *ProfileService) Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	return s.followRepo.Unfollow(followerID, followeeID)
+}
+
+// ListFollowRequests возвращает входящие запросы на подписку владельца
+// аккаунта; запросы удаленных пользователей пропускаются.
+func (s *ProfileService) ListFollowRequests(ctx context.Context, ownerID uuid.UUID, cursor int64, limit int) (*model.RelationPage, error) {
+	requests, next, err := s.followRepo.ListFollowRequests(ownerID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*model.RelatedUser, 0, len(requests))
+	for _, request := range requests {
+		user, err := s.userRepo.GetUserById(request.FollowerID)
+		if err != nil {
+			continue
+		}
+		users = append(users, &model.RelatedUser{User: user, CreatedAt: request.CreatedAt})
+	}
+
+	return &model.RelationPage{
+		Users:      users,
+		NextCursor: next,
+	}, nil
+}
+
+func (s *ProfileService) ApproveFollowRequest(ctx context.Context, ownerID, followerID uuid.UUID) error {
+	return s.followRepo.ApproveFollowRequest(ownerID, followerID)
+}
+
+func (s *ProfileService) RejectFollowRequest(ctx context.Context, ownerID, followerID uuid.UUID) error {
+	return s.followRepo.RejectFollowRequest(ownerID, followerID)
+}
+
+// isWebURL проверяет, что ссылка абсолютная и ведет на http(s)-сайт:
+// схемы вроде javascript: и data: в профиле не допускаются.
+func isWebURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	return scheme == "http" || scheme == "https"
+}
+
+// checkAvatar проверяет, что аватар - изображение, загруженное самим пользователем.
+func (s *ProfileService) checkAvatar(userID uuid.UUID, avatarID string) error {
+	id, err := uuid.Parse(avatarID)
+	if err != nil {
+		return model.ErrInvalidAttachment
+	}
+
+	media, err := s.mediaRepo.GetMedia(id)
+	if err != nil || media.OwnerID != userID || media.Kind != model.MediaImage {
+		return model.ErrInvalidAttachment
+	}
+	return nil
+}
+
+// buildProfile считает только посты, которые видны другим: у автора под
+// санкцией, скрывающей его посты, счетчик нулевой.
+func (s *ProfileService) buildProfile(user *model.User) *model.Profile {
+	now := s.clock.Now()
+	postsCount := 0
+	if !user.Hidden(now) {
+		postsCount = s.postRepo.CountPostsByAuthor(user.ID, now)
+	}
+
+	return &model.Profile{
+		User:           user,
+		PostsCount:     postsCount,
+		FollowersCount: s.followRepo.CountFollowers(user.ID),
+		FollowingCount: s.followRepo.CountFollowing(user.ID),
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/model"
+)
+
+// RelationService ведет списки заблокированных и заглушенных
+// пользователей. Сами ограничения применяются в сервисах постов,
+// подписок и переписок.
+type RelationService struct {
+	userRepo   UserRepository
+	followRepo FollowRepository
+	clock      clock.Clock
+}
+
+func NewRelationService(ur UserRepository, fr FollowRepository) *RelationService {
+	return &RelationService{
+		userRepo:   ur,
+		followRepo: fr,
+		clock:      clock.Real{},
+	}
+}
+
+// SetClock подменяет источник времени сервиса.
+func (s *RelationService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// Block блокирует пользователя и снимает подписки между ним и владельцем.
+func (s *RelationService) Block(ctx context.Context, ownerID, targetID uuid.UUID) error {
+	relation, err := s.newRelation(ownerID, targetID)
+	if err != nil {
+		return err
+	}
+	return s.followRepo.Block(relation)
+}
+
+func (s *RelationService) Unblock(ctx context.Context, ownerID, targetID uuid.UUID) error {
+	return s.followRepo.Unblock(ownerID, targetID)
+}
+
+func (s *RelationService) ListBlocked(ctx context.Context, ownerID uuid.UUID, cursor int64, limit int) (*model.RelationPage, error) {
+	relations, next, err := s.followRepo.ListBlocked(ownerID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildPage(relations, next), nil
+}
+
+func (s *RelationService) Mute(ctx context.Context, ownerID, targetID uuid.UUID) error {
+	relation, err := s.newRelation(ownerID, targetID)
+	if err != nil {
+		return err
+	}
+	return s.followRepo.Mute(relation)
+}
+
+func (s *RelationService) Unmute(ctx context.Context, ownerID, targetID uuid.UUID) error {
+	return s.followRepo.Unmute(ownerID, targetID)
+}
+
+func (s *RelationService) ListMuted(ctx context.Context, ownerID uuid.UUID, cursor int64, limit int) (*model.RelationPage, error) {
+	relations, next, err := s.followRepo.ListMuted(ownerID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildPage(relations, next), nil
+}
+
+func (s *RelationService) newRelation(ownerID, targetID uuid.UUID) (*model.Relation, error) {
+	if ownerID == targetID {
+		return nil, model.ErrSelfRelation
+	}
+
+	if _, err := s.userRepo.GetUserById(targetID); err != nil {
+		return nil, err
+	}
+
+	return &model.Relation{
+		OwnerID:   ownerID,
+		TargetID:  targetID,
+		CreatedAt: s.clock.Now(),
+	}, nil
+}
+
+// buildPage дополняет записи данными пользователей; удаленные
+// пользователи пропускаются.
+func (s *RelationService) buildPage(relations []*model.Relation, next int64) *model.RelationPage {
+	users := make([]*model.RelatedUser, 0, len(relations))
+	for _, relation := range relations {
+		user, err := s.userRepo.GetUserById(relation.TargetID)
+		if err != nil {
+			continue
+		}
+		users = append(users, &model.RelatedUser{User: user, CreatedAt: relation.CreatedAt})
+	}
+
+	return &model.RelationPage{
+		Users:      users,
+		NextCursor: next,
+	}
+}
@@ -1,18 +1,87 @@
 package service
 
+import (
+	"micro-blog/internal/clock"
+	"micro-blog/internal/model"
+)
+
 type Repository interface {
 	UserRepository
 	PostRepository
+	FollowRepository
+	MediaRepository
+	DraftRepository
+	BookmarkRepository
+	ConversationRepository
+	ReportRepository
+	SpamRepository
+	AuditRepository
+	AccessTokenRepository
 }
 
 type Service struct {
 	*UserService
 	*PostService
+	*ProfileService
+	*MediaService
+	*DraftService
+	*BookmarkService
+	*ConversationService
+	*RelationService
+	*ModerationService
+	*SpamService
+	*AuditService
+	*AccessTokenService
 }
 
-func NewService(repo Repository) *Service {
+func NewService(repo Repository, store MediaStore, mediaLimits model.MediaLimits, postLimits model.PostLimits) *Service {
+	auditService := NewAuditService(repo, repo)
+	postService := NewPostService(repo, repo, repo, repo, postLimits)
+	userService := NewUserService(repo)
+	spamService := NewSpamService(repo, repo)
+	draftService := NewDraftService(repo, repo, postService, postLimits)
+	bookmarkService := NewBookmarkService(repo, postService)
+	moderationService := NewModerationService(repo, repo, repo, postService)
+	tokenService := NewAccessTokenService(repo, repo)
+
+	postService.AttachSpamGuard(spamService)
+	userService.AttachSpamGuard(spamService)
+
+	userService.AttachAuditor(auditService)
+	spamService.AttachAuditor(auditService)
+	draftService.AttachAuditor(auditService)
+	bookmarkService.AttachAuditor(auditService)
+	moderationService.AttachAuditor(auditService)
+	tokenService.AttachAuditor(auditService)
+
 	return &Service{
-		UserService: NewUserService(repo),
-		PostService: NewPostService(repo, repo),
+		UserService:         userService,
+		PostService:         postService,
+		ProfileService:      NewProfileService(repo, repo, repo, repo),
+		MediaService:        NewMediaService(repo, repo, store, postService, mediaLimits),
+		DraftService:        draftService,
+		BookmarkService:     bookmarkService,
+		ConversationService: NewConversationService(repo, repo, repo),
+		RelationService:     NewRelationService(repo, repo),
+		ModerationService:   moderationService,
+		SpamService:         spamService,
+		AuditService:        auditService,
+		AccessTokenService:  tokenService,
 	}
 }
+
+// SetClock подменяет источник времени всех сервисов.
+func (s *Service) SetClock(c clock.Clock) {
+	s.UserService.SetClock(c)
+	s.PostService.SetClock(c)
+	s.ProfileService.SetClock(c)
+	s.MediaService.SetClock(c)
+	s.DraftService.SetClock(c)
+	s.BookmarkService.SetClock(c)
+	s.ConversationService.SetClock(c)
+	s.RelationService.SetClock(c)
+	s.ModerationService.SetClock(c)
+	s.SpamService.SetClock(c)
+	s.AuditService.SetClock(c)
+	s.AccessTokenService.SetClock(c)
+}
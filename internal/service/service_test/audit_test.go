@@ -0,0 +1,85 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/model"
+	"micro-blog/internal/service"
+	"micro-blog/internal/service/mocks"
+	"micro-blog/pkg/pkglogger"
+)
+
+func TestAuditService_Record(t *testing.T) {
+	now := time.Now()
+	actorID := uuid.New()
+
+	repo := mocks.NewAuditRepository(t)
+	repo.On("AppendAuditEntry", mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.ActorID == actorID && entry.IP == "10.0.0.1" && entry.RequestID == "req-1" && entry.CreatedAt.Equal(now)
+	})).Return(&model.AuditEntry{}, nil)
+
+	s := service.NewAuditService(repo, mocks.NewUserRepository(t))
+	s.SetClock(clock.NewFake(now))
+
+	ctx := context.WithValue(context.Background(), pkglogger.ClientIPKey, "10.0.0.1")
+	ctx = context.WithValue(ctx, pkglogger.RequestIDKey, "req-1")
+	assert.NoError(t, s.Record(ctx, &model.AuditEntry{Action: model.AuditLogin, ActorID: actorID}))
+}
+
+func TestAuditService_ListAuditLog_AdminsOnly(t *testing.T) {
+	moderatorID := uuid.New()
+	users := mocks.NewUserRepository(t)
+	users.On("GetUserById", moderatorID).Return(&model.User{ID: moderatorID, Role: model.RoleModerator}, nil)
+
+	s := service.NewAuditService(mocks.NewAuditRepository(t), users)
+	_, err := s.ListAuditLog(context.Background(), moderatorID, model.AuditFilter{}, 0, 10)
+	assert.ErrorIs(t, err, model.ErrForbidden)
+
+	err = s.ExportAuditLog(context.Background(), moderatorID, model.AuditFilter{}, func(*model.AuditEntry) error { return nil })
+	assert.ErrorIs(t, err, model.ErrForbidden)
+}
+
+func TestAuditService_ExportAuditLog(t *testing.T) {
+	adminID := uuid.New()
+	filter := model.AuditFilter{Action: model.AuditLogin}
+	first := []*model.AuditEntry{{ID: uuid.New()}, {ID: uuid.New()}}
+	second := []*model.AuditEntry{{ID: uuid.New()}}
+
+	newService := func(t *testing.T) *service.AuditService {
+		users := mocks.NewUserRepository(t)
+		users.On("GetUserById", adminID).Return(&model.User{ID: adminID, Role: model.RoleAdmin}, nil)
+		repo := mocks.NewAuditRepository(t)
+		repo.On("ListAuditEntries", filter, int64(0), mock.Anything).Return(first, int64(5), nil).Once()
+		repo.On("ListAuditEntries", filter, int64(5), mock.Anything).Return(second, int64(0), nil).Maybe()
+		return service.NewAuditService(repo, users)
+	}
+
+	t.Run("all pages", func(t *testing.T) {
+		var got []*model.AuditEntry
+		err := newService(t).ExportAuditLog(context.Background(), adminID, filter, func(entry *model.AuditEntry) error {
+			got = append(got, entry)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, append(first, second...), got)
+	})
+
+	t.Run("stops on yield error", func(t *testing.T) {
+		errClosed := errors.New("connection closed")
+		calls := 0
+		err := newService(t).ExportAuditLog(context.Background(), adminID, filter, func(*model.AuditEntry) error {
+			calls++
+			return errClosed
+		})
+		assert.ErrorIs(t, err, errClosed)
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,55 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/model"
+	"micro-blog/internal/service"
+	"micro-blog/internal/service/mocks"
+)
+
+func TestBookmarkService_AddBookmark_HiddenPost(t *testing.T) {
+	userID := uuid.New()
+	postID := uuid.New()
+
+	posts := mocks.NewPostReader(t)
+	posts.On("GetPost", context.Background(), userID, postID).Return(nil, model.ErrPostNotFound)
+
+	s := service.NewBookmarkService(mocks.NewBookmarkRepository(t), posts)
+	err := s.AddBookmark(context.Background(), &model.Bookmark{UserID: userID, PostID: postID})
+
+	assert.ErrorIs(t, err, model.ErrPostNotFound)
+}
+
+func TestBookmarkService_ListBookmarks_UnavailablePost(t *testing.T) {
+	userID := uuid.New()
+	gone := &model.Bookmark{UserID: userID, PostID: uuid.New()}
+	kept := &model.Bookmark{UserID: userID, PostID: uuid.New()}
+
+	repo := mocks.NewBookmarkRepository(t)
+	repo.On("ListBookmarks", userID, uuid.Nil, int64(0), 10).Return([]*model.Bookmark{gone, kept}, int64(0), nil)
+
+	posts := mocks.NewPostReader(t)
+	posts.On("GetPost", context.Background(), userID, gone.PostID).Return(nil, model.ErrPostNotFound)
+	posts.On("GetPost", context.Background(), userID, kept.PostID).Return(&model.Post{ID: kept.PostID}, nil)
+
+	s := service.NewBookmarkService(repo, posts)
+	page, err := s.ListBookmarks(context.Background(), userID, uuid.Nil, 0, 10)
+
+	require.NoError(t, err)
+	require.Len(t, page.Bookmarks, 2)
+	assert.Nil(t, page.Bookmarks[0].Post)
+	assert.Equal(t, kept.PostID, page.Bookmarks[1].Post.ID)
+}
+
+func TestBookmarkService_CreateCollection_InvalidName(t *testing.T) {
+	s := service.NewBookmarkService(mocks.NewBookmarkRepository(t), mocks.NewPostReader(t))
+
+	_, err := s.CreateCollection(context.Background(), uuid.New(), "   ")
+
+	assert.ErrorIs(t, err, model.ErrInvalidCollectionName)
+}
@@ -0,0 +1,147 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/model"
+	"micro-blog/internal/service"
+	"micro-blog/internal/service/mocks"
+)
+
+func TestConversationService_CreateConversation(t *testing.T) {
+	creatorID := uuid.New()
+	memberID := uuid.New()
+
+	tests := []struct {
+		name        string
+		memberIDs   []uuid.UUID
+		setup       func(cr *mocks.ConversationRepository, ur *mocks.UserRepository, fr *mocks.FollowRepository)
+		wantMembers []uuid.UUID
+		wantErr     error
+	}{
+		{
+			name:      "only creator",
+			memberIDs: []uuid.UUID{creatorID},
+			setup:     func(cr *mocks.ConversationRepository, ur *mocks.UserRepository, fr *mocks.FollowRepository) {},
+			wantErr:   model.ErrInvalidConversation,
+		},
+		{
+			name:      "too many members",
+			memberIDs: []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()},
+			setup:     func(cr *mocks.ConversationRepository, ur *mocks.UserRepository, fr *mocks.FollowRepository) {},
+			wantErr:   model.ErrInvalidConversation,
+		},
+		{
+			name:      "unknown member",
+			memberIDs: []uuid.UUID{memberID},
+			setup: func(cr *mocks.ConversationRepository, ur *mocks.UserRepository, fr *mocks.FollowRepository) {
+				ur.On("GetUserById", creatorID).Return(&model.User{ID: creatorID}, nil)
+				ur.On("GetUserById", memberID).Return(nil, model.ErrUserNotFound)
+			},
+			wantErr: model.ErrUserNotFound,
+		},
+		{
+			name:      "blocked member",
+			memberIDs: []uuid.UUID{memberID},
+			setup: func(cr *mocks.ConversationRepository, ur *mocks.UserRepository, fr *mocks.FollowRepository) {
+				ur.On("GetUserById", creatorID).Return(&model.User{ID: creatorID}, nil)
+				ur.On("GetUserById", memberID).Return(&model.User{ID: memberID}, nil)
+				fr.On("IsBlocked", creatorID, memberID).Return(true)
+			},
+			wantErr: model.ErrBlocked,
+		},
+		{
+			name:      "duplicates removed",
+			memberIDs: []uuid.UUID{memberID, creatorID, memberID},
+			setup: func(cr *mocks.ConversationRepository, ur *mocks.UserRepository, fr *mocks.FollowRepository) {
+				ur.On("GetUserById", creatorID).Return(&model.User{ID: creatorID}, nil)
+				ur.On("GetUserById", memberID).Return(&model.User{ID: memberID}, nil)
+				fr.On("IsBlocked", creatorID, memberID).Return(false)
+				cr.On("CreateConversation", mock.Anything).Return(func(c *model.Conversation) (*model.Conversation, error) {
+					return c, nil
+				})
+			},
+			wantMembers: []uuid.UUID{creatorID, memberID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversationRepo := mocks.NewConversationRepository(t)
+			userRepo := mocks.NewUserRepository(t)
+			followRepo := mocks.NewFollowRepository(t)
+			tt.setup(conversationRepo, userRepo, followRepo)
+
+			s := service.NewConversationService(conversationRepo, userRepo, followRepo)
+			conversation, err := s.CreateConversation(context.Background(), creatorID, tt.memberIDs)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMembers, conversation.MemberIDs)
+		})
+	}
+}
+
+func TestConversationService_SendMessage_Text(t *testing.T) {
+	conversationID := uuid.New()
+	senderID := uuid.New()
+	peerID := uuid.New()
+
+	tests := []struct {
+		name      string
+		text      string
+		blocked   bool
+		suspended bool
+		wantErr   error
+	}{
+		{name: "empty after sanitize", text: " \u0000 ", wantErr: model.ErrEmptyMessage},
+		{name: "too long", text: strings.Repeat("a", 1001), wantErr: model.ErrMessageTooLong},
+		{name: "suspended sender", text: "hi", suspended: true, wantErr: model.ErrSuspended},
+		{name: "blocked peer", text: "hi", blocked: true, wantErr: model.ErrBlocked},
+		{name: "ok", text: "  hi  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversationRepo := mocks.NewConversationRepository(t)
+			followRepo := mocks.NewFollowRepository(t)
+			userRepo := mocks.NewUserRepository(t)
+			if tt.wantErr == nil || tt.blocked || tt.suspended {
+				conversationRepo.On("GetConversation", conversationID, senderID).
+					Return(&model.Conversation{ID: conversationID, MemberIDs: []uuid.UUID{senderID, peerID}}, nil)
+				sender := &model.User{ID: senderID}
+				if tt.suspended {
+					sender.SuspendedUntil = time.Now().Add(time.Hour)
+				}
+				userRepo.On("GetUserById", senderID).Return(sender, nil)
+			}
+			if tt.wantErr == nil || tt.blocked {
+				followRepo.On("IsBlocked", senderID, peerID).Return(tt.blocked)
+			}
+			if tt.wantErr == nil {
+				conversationRepo.On("AddMessage", mock.Anything).Return(&model.Message{}, nil)
+			}
+
+			s := service.NewConversationService(conversationRepo, userRepo, followRepo)
+			message := &model.Message{ConversationID: conversationID, SenderID: senderID, Text: tt.text}
+			_, err := s.SendMessage(context.Background(), message)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.False(t, message.CreatedAt.IsZero())
+		})
+	}
+}
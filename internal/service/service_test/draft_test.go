@@ -0,0 +1,171 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"micro-blog/internal/model"
+	"micro-blog/internal/service"
+	"micro-blog/internal/service/mocks"
+)
+
+func TestDraftService_CreateDraft(t *testing.T) {
+	authorID := uuid.New()
+
+	tests := []struct {
+		name    string
+		draft   *model.Draft
+		wantErr error
+	}{
+		{
+			name:  "empty unscheduled draft",
+			draft: &model.Draft{AuthorID: authorID},
+		},
+		{
+			name:  "scheduled in future",
+			draft: &model.Draft{AuthorID: authorID, Text: "later", PublishAt: time.Now().Add(time.Hour)},
+		},
+		{
+			name:    "scheduled in past",
+			draft:   &model.Draft{AuthorID: authorID, Text: "late", PublishAt: time.Now().Add(-time.Minute)},
+			wantErr: model.ErrScheduleInPast,
+		},
+		{
+			name:    "empty draft cannot be scheduled",
+			draft:   &model.Draft{AuthorID: authorID, PublishAt: time.Now().Add(time.Hour)},
+			wantErr: model.ErrEmptyPost,
+		},
+		{
+			name:    "invalid visibility",
+			draft:   &model.Draft{AuthorID: authorID, Visibility: "secret"},
+			wantErr: model.ErrInvalidVisibility,
+		},
+		{
+			name:    "ttl above limit",
+			draft:   &model.Draft{AuthorID: authorID, TTL: time.Hour},
+			wantErr: model.ErrInvalidTTL,
+		},
+		{
+			name:    "sensitive attachment not attached",
+			draft:   &model.Draft{AuthorID: authorID, SensitiveAttachmentIDs: []uuid.UUID{uuid.New()}},
+			wantErr: model.ErrInvalidAttachment,
+		},
+		{
+			name: "scheduled poll closes before publishing",
+			draft: &model.Draft{
+				AuthorID:  authorID,
+				Text:      "vote",
+				Poll:      &model.Poll{Options: []model.PollOption{{Text: "a"}, {Text: "b"}}, ClosesAt: time.Now().Add(time.Hour)},
+				PublishAt: time.Now().Add(2 * time.Hour),
+			},
+			wantErr: model.ErrInvalidPoll,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draftRepo := mocks.NewDraftRepository(t)
+			userRepo := mocks.NewUserRepository(t)
+			userRepo.On("GetUserById", authorID).Return(&model.User{ID: authorID}, nil)
+			if tt.wantErr == nil {
+				draftRepo.On("CreateDraft", mock.Anything).Return(&model.Draft{ID: uuid.New()}, nil)
+			}
+
+			s := service.NewDraftService(draftRepo, userRepo, mocks.NewPostPublisher(t), testPostLimits)
+			_, err := s.CreateDraft(context.Background(), tt.draft)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, model.VisibilityPublic, tt.draft.Visibility)
+		})
+	}
+}
+
+func TestDraftService_GetDraft_OtherAuthor(t *testing.T) {
+	draftID := uuid.New()
+	draftRepo := mocks.NewDraftRepository(t)
+	draftRepo.On("GetDraft", draftID).Return(&model.Draft{ID: draftID, AuthorID: uuid.New()}, nil)
+
+	s := service.NewDraftService(draftRepo, mocks.NewUserRepository(t), mocks.NewPostPublisher(t), testPostLimits)
+	_, err := s.GetDraft(context.Background(), uuid.New(), draftID)
+
+	assert.ErrorIs(t, err, model.ErrDraftNotFound)
+}
+
+func TestDraftService_PublishDue(t *testing.T) {
+	now := time.Now()
+	ok := &model.Draft{ID: uuid.New(), AuthorID: uuid.New(), Text: "ok", PublishAt: now.Add(-time.Second)}
+	broken := &model.Draft{ID: uuid.New(), AuthorID: uuid.New(), Text: "broken", PublishAt: now}
+
+	draftRepo := mocks.NewDraftRepository(t)
+	publisher := mocks.NewPostPublisher(t)
+
+	draftRepo.On("ListDueDrafts", now).Return([]*model.Draft{ok, broken}, nil)
+	publisher.On("CreatePost", mock.Anything, mock.MatchedBy(func(p *model.Post) bool {
+		return p.Text == "ok" && p.AuthorID == ok.AuthorID
+	})).Return(&model.Post{ID: uuid.New()}, nil)
+	publisher.On("CreatePost", mock.Anything, mock.MatchedBy(func(p *model.Post) bool {
+		return p.Text == "broken"
+	})).Return(nil, model.ErrInvalidAttachment)
+	draftRepo.On("TakeDraft", ok.ID).Return(ok, nil).Once()
+	draftRepo.On("TakeDraft", broken.ID).Return(broken, nil).Once()
+	draftRepo.On("RestoreDraft", mock.MatchedBy(func(d *model.Draft) bool {
+		return d.ID == broken.ID && !d.Scheduled()
+	})).Return(nil).Once()
+
+	s := service.NewDraftService(draftRepo, mocks.NewUserRepository(t), publisher, testPostLimits)
+	published, err := s.PublishDue(context.Background(), now)
+
+	assert.Equal(t, 1, published)
+	assert.True(t, errors.Is(err, model.ErrInvalidAttachment))
+}
+
+func TestDraftService_PublishDue_SkipsTakenAndRescheduled(t *testing.T) {
+	now := time.Now()
+	published := &model.Draft{ID: uuid.New(), AuthorID: uuid.New(), Text: "gone", PublishAt: now}
+	rescheduled := &model.Draft{ID: uuid.New(), AuthorID: uuid.New(), Text: "later", PublishAt: now}
+
+	draftRepo := mocks.NewDraftRepository(t)
+	draftRepo.On("ListDueDrafts", now).Return([]*model.Draft{published, rescheduled}, nil)
+	// Первый черновик уже опубликовал автор, второй перенесен на потом.
+	draftRepo.On("TakeDraft", published.ID).Return(nil, model.ErrDraftNotFound).Once()
+	later := *rescheduled
+	later.PublishAt = now.Add(time.Hour)
+	draftRepo.On("TakeDraft", rescheduled.ID).Return(&later, nil).Once()
+	draftRepo.On("RestoreDraft", &later).Return(nil).Once()
+
+	s := service.NewDraftService(draftRepo, mocks.NewUserRepository(t), mocks.NewPostPublisher(t), testPostLimits)
+	n, err := s.PublishDue(context.Background(), now)
+
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestDraftService_PublishDraft_RestoresOnFailure(t *testing.T) {
+	authorID := uuid.New()
+	draft := &model.Draft{ID: uuid.New(), AuthorID: authorID, Text: "hello"}
+
+	draftRepo := mocks.NewDraftRepository(t)
+	publisher := mocks.NewPostPublisher(t)
+	draftRepo.On("GetDraft", draft.ID).Return(draft, nil)
+	draftRepo.On("TakeDraft", draft.ID).Return(draft, nil).Once()
+	publisher.On("CreatePost", mock.Anything, mock.Anything).Return(nil, model.ErrInvalidAttachment).Once()
+	draftRepo.On("RestoreDraft", draft).Return(nil).Once()
+
+	s := service.NewDraftService(draftRepo, mocks.NewUserRepository(t), publisher, testPostLimits)
+	_, err := s.PublishDraft(context.Background(), authorID, draft.ID)
+	assert.ErrorIs(t, err, model.ErrInvalidAttachment)
+
+	// Черновик уже забран другой публикацией.
+	draftRepo.On("TakeDraft", draft.ID).Return(nil, model.ErrDraftNotFound).Once()
+	_, err = s.PublishDraft(context.Background(), authorID, draft.ID)
+	assert.ErrorIs(t, err, model.ErrDraftNotFound)
+}
@@ -0,0 +1,286 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/model"
+	"micro-blog/internal/service"
+	"micro-blog/internal/service/mocks"
+)
+
+var testLimits = model.MediaLimits{
+	MaxImageSize:  1 << 20,
+	MaxVideoSize:  1 << 10,
+	ThumbnailSize: 16,
+}
+
+// jpegWithExif собирает JPEG и вставляет сразу после SOI сегмент APP1 с EXIF.
+func jpegWithExif(t *testing.T, w, h int) []byte {
+	t.Helper()
+	return jpegWithApp1(t, w, h, []byte("Exif\x00\x00GPS-SECRET-LOCATION"))
+}
+
+// jpegWithOrientation собирает JPEG с EXIF, в котором задан только тег
+// Orientation.
+func jpegWithOrientation(t *testing.T, w, h int, orientation byte) []byte {
+	t.Helper()
+
+	payload := []byte("Exif\x00\x00MM\x00\x2A\x00\x00\x00\x08")
+	payload = append(payload, 0x00, 0x01)                    // одна запись IFD0
+	payload = append(payload, 0x01, 0x12, 0x00, 0x03)        // Orientation, SHORT
+	payload = append(payload, 0x00, 0x00, 0x00, 0x01)        // одно значение
+	payload = append(payload, 0x00, orientation, 0x00, 0x00) // значение
+	payload = append(payload, 0x00, 0x00, 0x00, 0x00)        // следующего IFD нет
+	return jpegWithApp1(t, w, h, payload)
+}
+
+func jpegWithApp1(t *testing.T, w, h int, payload []byte) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+
+	segment := []byte{0xFF, 0xE1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}
+	segment = append(segment, payload...)
+
+	data := buf.Bytes()
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+// newActiveUsers возвращает репозиторий, в котором любой пользователь
+// существует и не заблокирован.
+func newActiveUsers(t *testing.T) *mocks.UserRepository {
+	users := mocks.NewUserRepository(t)
+	users.On("GetUserById", mock.Anything).Return(func(id uuid.UUID) (*model.User, error) {
+		return &model.User{ID: id}, nil
+	}).Maybe()
+	return users
+}
+
+func TestMediaService_UploadSuspended(t *testing.T) {
+	ownerID := uuid.New()
+	users := mocks.NewUserRepository(t)
+	users.On("GetUserById", ownerID).Return(&model.User{ID: ownerID, SuspendedUntil: time.Now().Add(time.Hour)}, nil)
+
+	svc := service.NewMediaService(mocks.NewMediaRepository(t), users, mocks.NewMediaStore(t), mocks.NewMediaGuard(t), testLimits)
+	media, err := svc.Upload(context.Background(), ownerID, bytes.NewReader(jpegWithExif(t, 64, 32)))
+
+	assert.ErrorIs(t, err, model.ErrSuspended)
+	assert.Nil(t, media)
+}
+
+func TestMediaService_UploadImage(t *testing.T) {
+	ownerID := uuid.New()
+	repo := mocks.NewMediaRepository(t)
+	store := mocks.NewMediaStore(t)
+
+	saved := make(map[uuid.UUID][]byte)
+	store.On("Save", mock.Anything, mock.Anything).Return(func(id uuid.UUID, r io.Reader) (int64, error) {
+		data, err := io.ReadAll(r)
+		saved[id] = data
+		return int64(len(data)), err
+	})
+	repo.On("CreateMedia", mock.Anything).Return(nil)
+
+	svc := service.NewMediaService(repo, newActiveUsers(t), store, mocks.NewMediaGuard(t), testLimits)
+	media, err := svc.Upload(context.Background(), ownerID, bytes.NewReader(jpegWithExif(t, 64, 32)))
+	require.NoError(t, err)
+
+	assert.Equal(t, model.MediaImage, media.Kind)
+	assert.Equal(t, "image/jpeg", media.ContentType)
+	assert.Equal(t, ownerID, media.OwnerID)
+	assert.NotEqual(t, uuid.Nil, media.ThumbnailID)
+
+	require.Contains(t, saved, media.ID)
+	assert.NotContains(t, string(saved[media.ID]), "GPS-SECRET-LOCATION")
+
+	require.Contains(t, saved, media.ThumbnailID)
+	thumb, _, err := image.Decode(bytes.NewReader(saved[media.ThumbnailID]))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 16, 8), thumb.Bounds())
+}
+
+func TestMediaService_UploadImage_AppliesOrientation(t *testing.T) {
+	repo := mocks.NewMediaRepository(t)
+	store := mocks.NewMediaStore(t)
+
+	saved := make(map[uuid.UUID][]byte)
+	store.On("Save", mock.Anything, mock.Anything).Return(func(id uuid.UUID, r io.Reader) (int64, error) {
+		data, err := io.ReadAll(r)
+		saved[id] = data
+		return int64(len(data)), err
+	})
+	repo.On("CreateMedia", mock.Anything).Return(nil)
+
+	svc := service.NewMediaService(repo, newActiveUsers(t), store, mocks.NewMediaGuard(t), testLimits)
+	media, err := svc.Upload(context.Background(), uuid.New(), bytes.NewReader(jpegWithOrientation(t, 64, 32, 6)))
+	require.NoError(t, err)
+
+	img, _, err := image.Decode(bytes.NewReader(saved[media.ID]))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 32, 64), img.Bounds())
+
+	thumb, _, err := image.Decode(bytes.NewReader(saved[media.ThumbnailID]))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 8, 16), thumb.Bounds())
+}
+
+func TestMediaService_UploadImage_DiscardsOnThumbnailFailure(t *testing.T) {
+	repo := mocks.NewMediaRepository(t)
+	store := mocks.NewMediaStore(t)
+
+	var originalID uuid.UUID
+	store.On("Save", mock.Anything, mock.Anything).Return(func(id uuid.UUID, r io.Reader) (int64, error) {
+		originalID = id
+		return io.Copy(io.Discard, r)
+	}).Once()
+	store.On("Save", mock.Anything, mock.Anything).Return(int64(0), errors.New("disk full")).Once()
+	store.On("Delete", mock.MatchedBy(func(id uuid.UUID) bool { return id == originalID })).Return(nil).Once()
+
+	svc := service.NewMediaService(repo, newActiveUsers(t), store, mocks.NewMediaGuard(t), testLimits)
+	media, err := svc.Upload(context.Background(), uuid.New(), bytes.NewReader(jpegWithExif(t, 64, 32)))
+
+	assert.Error(t, err)
+	assert.Nil(t, media)
+}
+
+// gifWithFrames собирает GIF из frames кадров размером 1x1.
+func gifWithFrames(t *testing.T, frames int) []byte {
+	t.Helper()
+
+	anim := &gif.GIF{}
+	for i := 0; i < frames; i++ {
+		anim.Image = append(anim.Image, image.NewPaletted(image.Rect(0, 0, 1, 1), color.Palette{color.Black, color.White}))
+		anim.Delay = append(anim.Delay, 0)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, gif.EncodeAll(&buf, anim))
+	return buf.Bytes()
+}
+
+func TestMediaService_UploadRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    []byte
+		setup   func(store *mocks.MediaStore)
+		wantErr error
+	}{
+		{
+			name:    "unsupported type",
+			body:    []byte("#!/bin/sh\necho hello\n"),
+			setup:   func(store *mocks.MediaStore) {},
+			wantErr: model.ErrUnsupportedMedia,
+		},
+		{
+			name: "video too large",
+			body: append([]byte("\x1A\x45\xDF\xA3"), bytes.Repeat([]byte{0}, 2<<10)...),
+			setup: func(store *mocks.MediaStore) {
+				store.On("Save", mock.Anything, mock.Anything).Return(func(id uuid.UUID, r io.Reader) (int64, error) {
+					return io.Copy(io.Discard, r)
+				})
+				store.On("Delete", mock.Anything).Return(nil).Once()
+			},
+			wantErr: model.ErrMediaTooLarge,
+		},
+		{
+			name:    "too many gif frames",
+			body:    gifWithFrames(t, 600),
+			setup:   func(store *mocks.MediaStore) {},
+			wantErr: model.ErrMediaTooLarge,
+		},
+		{
+			name:    "image too large",
+			body:    append([]byte("\x89PNG\x0D\x0A\x1A\x0A"), strings.Repeat("x", 2<<20)...),
+			setup:   func(store *mocks.MediaStore) {},
+			wantErr: model.ErrMediaTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMediaRepository(t)
+			store := mocks.NewMediaStore(t)
+			tt.setup(store)
+
+			svc := service.NewMediaService(repo, newActiveUsers(t), store, mocks.NewMediaGuard(t), testLimits)
+			media, err := svc.Upload(context.Background(), uuid.New(), bytes.NewReader(tt.body))
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, media)
+		})
+	}
+}
+
+type nopReadSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopReadSeekCloser) Close() error { return nil }
+
+func TestMediaService_Open(t *testing.T) {
+	viewerID := uuid.New()
+	media := &model.Media{ID: uuid.New(), OwnerID: uuid.New(), Kind: model.MediaImage, ContentType: "image/png"}
+
+	tests := []struct {
+		name       string
+		visible    bool
+		public     bool
+		wantErr    error
+		wantPublic bool
+	}{
+		{name: "public", visible: true, public: true, wantPublic: true},
+		{name: "visible to viewer only", visible: true},
+		{name: "hidden", wantErr: model.ErrMediaNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMediaRepository(t)
+			store := mocks.NewMediaStore(t)
+			guard := mocks.NewMediaGuard(t)
+
+			stored := *media
+			repo.On("GetMedia", media.ID).Return(&stored, nil)
+			guard.On("MediaAccess", &stored, viewerID).Return(tt.visible, tt.public, nil)
+			if tt.wantErr == nil {
+				store.On("Open", media.ID).Return(nopReadSeekCloser{bytes.NewReader(nil)}, nil)
+			}
+
+			svc := service.NewMediaService(repo, mocks.NewUserRepository(t), store, guard, testLimits)
+			got, file, err := svc.Open(context.Background(), viewerID, media.ID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, file)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPublic, got.Public)
+			assert.NoError(t, file.Close())
+		})
+	}
+}
@@ -0,0 +1,596 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/model"
+	"micro-blog/internal/service"
+	"micro-blog/internal/service/mocks"
+)
+
+type moderationMocks struct {
+	reports *mocks.ReportRepository
+	posts   *mocks.PostRepository
+	users   *mocks.UserRepository
+	reader  *mocks.PostReader
+}
+
+func newModerationService(t *testing.T) (*service.ModerationService, moderationMocks) {
+	m := moderationMocks{
+		reports: mocks.NewReportRepository(t),
+		posts:   mocks.NewPostRepository(t),
+		users:   mocks.NewUserRepository(t),
+		reader:  mocks.NewPostReader(t),
+	}
+	return service.NewModerationService(m.reports, m.posts, m.users, m.reader), m
+}
+
+func TestModerationService_ReportContent(t *testing.T) {
+	reporterID := uuid.New()
+	authorID := uuid.New()
+	postID := uuid.New()
+
+	tests := []struct {
+		name    string
+		report  model.Report
+		setup   func(m moderationMocks)
+		wantErr error
+	}{
+		{
+			name:    "unknown reason",
+			report:  model.Report{TargetType: model.ReportTargetUser, TargetID: authorID, Reason: "boring"},
+			setup:   func(m moderationMocks) {},
+			wantErr: model.ErrInvalidReport,
+		},
+		{
+			name:    "yourself",
+			report:  model.Report{TargetType: model.ReportTargetUser, TargetID: reporterID, Reason: model.ReportReasonSpam},
+			setup:   func(m moderationMocks) {},
+			wantErr: model.ErrInvalidReport,
+		},
+		{
+			name:   "own post",
+			report: model.Report{TargetType: model.ReportTargetPost, TargetID: postID, Reason: model.ReportReasonSpam},
+			setup: func(m moderationMocks) {
+				m.reader.On("GetPost", mock.Anything, reporterID, postID).Return(&model.Post{ID: postID, AuthorID: reporterID}, nil)
+			},
+			wantErr: model.ErrInvalidReport,
+		},
+		{
+			name:   "invisible post",
+			report: model.Report{TargetType: model.ReportTargetPost, TargetID: postID, Reason: model.ReportReasonSpam},
+			setup: func(m moderationMocks) {
+				m.reader.On("GetPost", mock.Anything, reporterID, postID).Return(nil, model.ErrPostNotFound)
+			},
+			wantErr: model.ErrPostNotFound,
+		},
+		{
+			name:   "unknown user",
+			report: model.Report{TargetType: model.ReportTargetUser, TargetID: authorID, Reason: model.ReportReasonHate},
+			setup: func(m moderationMocks) {
+				m.users.On("GetUserById", authorID).Return(nil, model.ErrUserNotFound)
+			},
+			wantErr: model.ErrUserNotFound,
+		},
+		{
+			name:   "post",
+			report: model.Report{TargetType: model.ReportTargetPost, TargetID: postID, Reason: model.ReportReasonSpam, Comment: "  ads  "},
+			setup: func(m moderationMocks) {
+				m.reader.On("GetPost", mock.Anything, reporterID, postID).Return(&model.Post{ID: postID, AuthorID: authorID}, nil)
+				m.reports.On("CreateReport", mock.MatchedBy(func(r *model.Report) bool {
+					return r.ReporterID == reporterID && r.Comment == "ads" && !r.CreatedAt.IsZero()
+				})).Return(&model.Report{ID: uuid.New(), Status: model.ReportStatusOpen}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newModerationService(t)
+			tt.setup(m)
+
+			report := tt.report
+			report.ReporterID = reporterID
+			created, err := s.ReportContent(context.Background(), &report)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, model.ReportStatusOpen, created.Status)
+		})
+	}
+}
+
+func TestModerationService_ListReports_RequiresModerator(t *testing.T) {
+	moderatorID := uuid.New()
+	userID := uuid.New()
+	s, m := newModerationService(t)
+	m.users.On("GetUserById", moderatorID).Return(&model.User{ID: moderatorID, Role: model.RoleModerator}, nil)
+	m.users.On("GetUserById", userID).Return(&model.User{ID: userID}, nil)
+
+	_, err := s.ListReports(context.Background(), userID, "", 0, 20)
+	assert.ErrorIs(t, err, model.ErrForbidden)
+
+	m.reports.On("ListReports", model.ReportStatusOpen, int64(5), 20).Return([]*model.Report{{ID: uuid.New()}}, int64(2), nil)
+	page, err := s.ListReports(context.Background(), moderatorID, model.ReportStatusOpen, 5, 20)
+	require.NoError(t, err)
+	assert.Len(t, page.Reports, 1)
+	assert.Equal(t, int64(2), page.NextCursor)
+}
+
+func TestModerationService_Moderate(t *testing.T) {
+	moderatorID := uuid.New()
+	authorID := uuid.New()
+	postID := uuid.New()
+	reportID := uuid.New()
+	postReport := &model.Report{ID: reportID, TargetType: model.ReportTargetPost, TargetID: postID, Status: model.ReportStatusOpen}
+	userReport := &model.Report{ID: reportID, TargetType: model.ReportTargetUser, TargetID: authorID, Status: model.ReportStatusOpen}
+	until := time.Now().Add(24 * time.Hour)
+
+	recorded := func(m moderationMocks, typ model.ModerationActionType, status model.ReportStatus) {
+		m.reports.On("AddModerationAction", mock.MatchedBy(func(a *model.ModerationAction) bool {
+			return a.ActorID == moderatorID && a.Type == typ && !a.CreatedAt.IsZero()
+		}), status).Return(&model.Report{ID: reportID, Status: status}, nil)
+	}
+
+	tests := []struct {
+		name    string
+		actorID uuid.UUID
+		action  model.ModerationAction
+		setup   func(m moderationMocks)
+		wantErr error
+	}{
+		{
+			name:    "not a moderator",
+			actorID: authorID,
+			action:  model.ModerationAction{Type: model.ModerationResolve},
+			setup:   func(m moderationMocks) {},
+			wantErr: model.ErrForbidden,
+		},
+		{
+			name:    "unknown action",
+			actorID: moderatorID,
+			action:  model.ModerationAction{Type: "ban"},
+			setup:   func(m moderationMocks) {},
+			wantErr: model.ErrInvalidModerationAction,
+		},
+		{
+			name:    "closed report",
+			actorID: moderatorID,
+			action:  model.ModerationAction{Type: model.ModerationResolve},
+			setup: func(m moderationMocks) {
+				m.reports.On("GetReport", reportID).Return(&model.Report{ID: reportID, Status: model.ReportStatusDismissed}, nil)
+			},
+			wantErr: model.ErrReportClosed,
+		},
+		{
+			name:    "dismiss",
+			actorID: moderatorID,
+			action:  model.ModerationAction{Type: model.ModerationDismiss},
+			setup: func(m moderationMocks) {
+				m.reports.On("GetReport", reportID).Return(postReport, nil)
+				recorded(m, model.ModerationDismiss, model.ReportStatusDismissed)
+			},
+		},
+		{
+			name:    "remove user content",
+			actorID: moderatorID,
+			action:  model.ModerationAction{Type: model.ModerationRemoveContent},
+			setup: func(m moderationMocks) {
+				m.reports.On("GetReport", reportID).Return(userReport, nil)
+			},
+			wantErr: model.ErrInvalidModerationAction,
+		},
+		{
+			name:    "remove post",
+			actorID: moderatorID,
+			action:  model.ModerationAction{Type: model.ModerationRemoveContent},
+			setup: func(m moderationMocks) {
+				m.reports.On("GetReport", reportID).Return(postReport, nil)
+				m.posts.On("DeletePost", postID).Return(nil)
+				recorded(m, model.ModerationRemoveContent, model.ReportStatusResolved)
+			},
+		},
+		{
+			name:    "mark user sensitive",
+			actorID: moderatorID,
+			action:  model.ModerationAction{Type: model.ModerationMarkSensitive},
+			setup: func(m moderationMocks) {
+				m.reports.On("GetReport", reportID).Return(userReport, nil)
+			},
+			wantErr: model.ErrInvalidModerationAction,
+		},
+		{
+			name:    "mark post sensitive",
+			actorID: moderatorID,
+			action:  model.ModerationAction{Type: model.ModerationMarkSensitive},
+			setup: func(m moderationMocks) {
+				m.reports.On("GetReport", reportID).Return(postReport, nil)
+				m.posts.On("MarkPostSensitive", postID, "").Return(&model.Post{ID: postID, Sensitive: true}, nil)
+				recorded(m, model.ModerationMarkSensitive, model.ReportStatusResolved)
+			},
+		},
+		{
+			name:    "suspend in the past",
+			actorID: moderatorID,
+			action:  model.ModerationAction{Type: model.ModerationSuspend, SuspendUntil: time.Now().Add(-time.Hour)},
+			setup: func(m moderationMocks) {
+				m.reports.On("GetReport", reportID).Return(userReport, nil)
+			},
+			wantErr: model.ErrInvalidModerationAction,
+		},
+		{
+			name:    "suspend post author",
+			actorID: moderatorID,
+			action:  model.ModerationAction{Type: model.ModerationSuspend, SuspendUntil: until, Note: "repeated spam"},
+			setup: func(m moderationMocks) {
+				m.reports.On("GetReport", reportID).Return(postReport, nil)
+				m.posts.On("GetPost", postID, uuid.Nil).Return(&model.Post{ID: postID, AuthorID: authorID}, nil)
+				m.users.On("SuspendUser", authorID, "repeated spam", until).Return(&model.User{ID: authorID}, nil)
+				recorded(m, model.ModerationSuspend, model.ReportStatusResolved)
+			},
+		},
+		{
+			name:    "approve held post",
+			actorID: moderatorID,
+			action:  model.ModerationAction{Type: model.ModerationDismiss},
+			setup: func(m moderationMocks) {
+				m.reports.On("GetReport", reportID).Return(&model.Report{
+					ID:         reportID,
+					TargetType: model.ReportTargetPost,
+					TargetID:   postID,
+					Reason:     model.ReportReasonPolicy,
+					Status:     model.ReportStatusOpen,
+				}, nil)
+				m.posts.On("ReleasePost", postID).Return(nil)
+				recorded(m, model.ModerationDismiss, model.ReportStatusDismissed)
+			},
+		},
+		{
+			name:    "remove held post",
+			actorID: moderatorID,
+			action:  model.ModerationAction{Type: model.ModerationRemoveContent},
+			setup: func(m moderationMocks) {
+				m.reports.On("GetReport", reportID).Return(&model.Report{
+					ID:         reportID,
+					TargetType: model.ReportTargetPost,
+					TargetID:   postID,
+					Reason:     model.ReportReasonPolicy,
+					Status:     model.ReportStatusOpen,
+				}, nil)
+				m.posts.On("DeletePost", postID).Return(nil)
+				recorded(m, model.ModerationRemoveContent, model.ReportStatusResolved)
+			},
+		},
+		{
+			name:    "shadow ban reported user",
+			actorID: moderatorID,
+			action:  model.ModerationAction{Type: model.ModerationShadowBan, SuspendUntil: until},
+			setup: func(m moderationMocks) {
+				m.reports.On("GetReport", reportID).Return(&model.Report{
+					ID:         reportID,
+					TargetType: model.ReportTargetUser,
+					TargetID:   authorID,
+					Reason:     model.ReportReasonSpam,
+					Status:     model.ReportStatusOpen,
+				}, nil)
+				m.users.On("ShadowBanUser", authorID, "spam", until).Return(&model.User{ID: authorID}, nil)
+				recorded(m, model.ModerationShadowBan, model.ReportStatusResolved)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newModerationService(t)
+			m.users.On("GetUserById", moderatorID).Return(&model.User{ID: moderatorID, Role: model.RoleAdmin}, nil).Maybe()
+			m.users.On("GetUserById", authorID).Return(&model.User{ID: authorID}, nil).Maybe()
+			tt.setup(m)
+
+			action := tt.action
+			action.ReportID = reportID
+			action.ActorID = tt.actorID
+			_, err := s.Moderate(context.Background(), &action)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestModerationService_Sanction(t *testing.T) {
+	moderatorID := uuid.New()
+	otherModeratorID := uuid.New()
+	userID := uuid.New()
+	until := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		actorID  uuid.UUID
+		userID   uuid.UUID
+		sanction model.Sanction
+		setup    func(m moderationMocks)
+		wantErr  error
+	}{
+		{
+			name:     "not a moderator",
+			actorID:  userID,
+			userID:   moderatorID,
+			sanction: model.Sanction{Type: model.SanctionSuspension, Until: until},
+			setup:    func(m moderationMocks) {},
+			wantErr:  model.ErrForbidden,
+		},
+		{
+			name:     "unknown type",
+			actorID:  moderatorID,
+			userID:   userID,
+			sanction: model.Sanction{Type: "exile", Until: until},
+			setup:    func(m moderationMocks) {},
+			wantErr:  model.ErrInvalidSanction,
+		},
+		{
+			name:     "already expired",
+			actorID:  moderatorID,
+			userID:   userID,
+			sanction: model.Sanction{Type: model.SanctionSuspension, Until: time.Now().Add(-time.Minute)},
+			setup:    func(m moderationMocks) {},
+			wantErr:  model.ErrInvalidSanction,
+		},
+		{
+			name:     "yourself",
+			actorID:  moderatorID,
+			userID:   moderatorID,
+			sanction: model.Sanction{Type: model.SanctionSuspension, Until: until},
+			setup:    func(m moderationMocks) {},
+			wantErr:  model.ErrForbidden,
+		},
+		{
+			name:     "moderator by moderator",
+			actorID:  moderatorID,
+			userID:   otherModeratorID,
+			sanction: model.Sanction{Type: model.SanctionShadowBan, Until: until},
+			setup:    func(m moderationMocks) {},
+			wantErr:  model.ErrForbidden,
+		},
+		{
+			name:     "suspend",
+			actorID:  moderatorID,
+			userID:   userID,
+			sanction: model.Sanction{Type: model.SanctionSuspension, Reason: "  spam  ", Until: until},
+			setup: func(m moderationMocks) {
+				m.users.On("SuspendUser", userID, "spam", until).Return(&model.User{ID: userID, SuspendedUntil: until}, nil)
+			},
+		},
+		{
+			name:     "shadow ban",
+			actorID:  moderatorID,
+			userID:   userID,
+			sanction: model.Sanction{Type: model.SanctionShadowBan, Until: until},
+			setup: func(m moderationMocks) {
+				m.users.On("ShadowBanUser", userID, "", until).Return(&model.User{ID: userID, ShadowBannedUntil: until}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newModerationService(t)
+			m.users.On("GetUserById", moderatorID).Return(&model.User{ID: moderatorID, Role: model.RoleModerator}, nil).Maybe()
+			m.users.On("GetUserById", otherModeratorID).Return(&model.User{ID: otherModeratorID, Role: model.RoleModerator}, nil).Maybe()
+			m.users.On("GetUserById", userID).Return(&model.User{ID: userID}, nil).Maybe()
+			tt.setup(m)
+
+			sanction := tt.sanction
+			_, err := s.Sanction(context.Background(), tt.actorID, tt.userID, &sanction)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestModerationService_LiftSanction(t *testing.T) {
+	moderatorID := uuid.New()
+	userID := uuid.New()
+	s, m := newModerationService(t)
+	m.users.On("GetUserById", moderatorID).Return(&model.User{ID: moderatorID, Role: model.RoleModerator}, nil)
+	m.users.On("GetUserById", userID).Return(&model.User{ID: userID}, nil)
+
+	_, err := s.LiftSanction(context.Background(), moderatorID, userID, "exile")
+	assert.ErrorIs(t, err, model.ErrInvalidSanction)
+
+	m.users.On("ShadowBanUser", userID, "", time.Time{}).Return(&model.User{ID: userID}, nil)
+	_, err = s.LiftSanction(context.Background(), moderatorID, userID, model.SanctionShadowBan)
+	assert.NoError(t, err)
+}
+
+func TestModerationService_MarkSensitive(t *testing.T) {
+	moderatorID := uuid.New()
+	userID := uuid.New()
+	postID := uuid.New()
+
+	s, m := newModerationService(t)
+	auditor := mocks.NewAuditor(t)
+	s.AttachAuditor(auditor)
+
+	m.users.On("GetUserById", moderatorID).Return(&model.User{ID: moderatorID, Role: model.RoleModerator}, nil)
+	m.users.On("GetUserById", userID).Return(&model.User{ID: userID}, nil)
+
+	_, err := s.MarkSensitive(context.Background(), userID, postID, "")
+	assert.ErrorIs(t, err, model.ErrForbidden)
+
+	_, err = s.MarkSensitive(context.Background(), moderatorID, postID, strings.Repeat("!", 101))
+	assert.ErrorIs(t, err, model.ErrContentWarningTooLong)
+
+	m.posts.On("MarkPostSensitive", postID, "Spoilers").
+		Return(&model.Post{ID: postID, Sensitive: true, ContentWarning: "Spoilers"}, nil)
+	auditor.On("Record", mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.Action == model.AuditPostFlagged && entry.ActorID == moderatorID &&
+			entry.TargetID == postID && entry.Details["content_warning"] == "Spoilers"
+	})).Return(nil)
+
+	post, err := s.MarkSensitive(context.Background(), moderatorID, postID, "  Spoilers ")
+	require.NoError(t, err)
+	assert.True(t, post.Sensitive)
+	assert.Equal(t, "Spoilers", post.ContentWarning)
+}
+
+func TestModerationService_Moderate_Audit(t *testing.T) {
+	moderatorID := uuid.New()
+	postID := uuid.New()
+	reportID := uuid.New()
+
+	s, m := newModerationService(t)
+	auditor := mocks.NewAuditor(t)
+	s.AttachAuditor(auditor)
+
+	m.users.On("GetUserById", moderatorID).Return(&model.User{ID: moderatorID, Role: model.RoleModerator}, nil)
+	m.reports.On("GetReport", reportID).
+		Return(&model.Report{ID: reportID, TargetType: model.ReportTargetPost, TargetID: postID, Status: model.ReportStatusOpen}, nil)
+	m.posts.On("DeletePost", postID).Return(nil)
+	m.reports.On("AddModerationAction", mock.Anything, model.ReportStatusResolved).Return(&model.Report{ID: reportID}, nil)
+
+	var actions []model.AuditAction
+	auditor.On("Record", mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.ActorID == moderatorID
+	})).Run(func(args mock.Arguments) {
+		actions = append(actions, args.Get(1).(*model.AuditEntry).Action)
+	}).Return(nil)
+
+	_, err := s.Moderate(context.Background(), &model.ModerationAction{
+		ReportID: reportID,
+		ActorID:  moderatorID,
+		Type:     model.ModerationRemoveContent,
+		Note:     "gore",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []model.AuditAction{model.AuditReportModerated, model.AuditPostRemoved}, actions)
+}
+
+func TestModerationService_Moderate_RevertsOnFailure(t *testing.T) {
+	moderatorID := uuid.New()
+	authorID := uuid.New()
+	reportID := uuid.New()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	s, m := newModerationService(t)
+	s.SetClock(clock.NewFake(now))
+	auditor := mocks.NewAuditor(t)
+	s.AttachAuditor(auditor)
+
+	// Модератор не вправе наказать другого модератора, но это выясняется
+	// только при применении решения.
+	m.users.On("GetUserById", moderatorID).Return(&model.User{ID: moderatorID, Role: model.RoleModerator}, nil)
+	m.users.On("GetUserById", authorID).Return(&model.User{ID: authorID, Role: model.RoleModerator}, nil)
+	m.reports.On("GetReport", reportID).
+		Return(&model.Report{ID: reportID, TargetType: model.ReportTargetUser, TargetID: authorID, Status: model.ReportStatusOpen}, nil)
+	m.reports.On("AddModerationAction", mock.MatchedBy(func(a *model.ModerationAction) bool {
+		return a.CreatedAt.Equal(now)
+	}), model.ReportStatusResolved).Return(&model.Report{ID: reportID}, nil).Once()
+	m.reports.On("RevertModerationAction", reportID).Return(&model.Report{ID: reportID, Status: model.ReportStatusOpen}, nil).Once()
+
+	var actions []model.AuditAction
+	auditor.On("Record", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		actions = append(actions, args.Get(1).(*model.AuditEntry).Action)
+	}).Return(nil)
+
+	_, err := s.Moderate(context.Background(), &model.ModerationAction{
+		ReportID:     reportID,
+		ActorID:      moderatorID,
+		Type:         model.ModerationSuspend,
+		SuspendUntil: now.Add(time.Hour),
+	})
+	assert.ErrorIs(t, err, model.ErrForbidden)
+	assert.Equal(t, []model.AuditAction{model.AuditReportModerated, model.AuditModerationReverted}, actions)
+}
+
+func TestModerationService_Moderate_KeepsResolvedAfterRemoval(t *testing.T) {
+	moderatorID := uuid.New()
+	postID := uuid.New()
+	reportID := uuid.New()
+	auditErr := errors.New("audit store is down")
+
+	s, m := newModerationService(t)
+	auditor := mocks.NewAuditor(t)
+	s.AttachAuditor(auditor)
+
+	m.users.On("GetUserById", moderatorID).Return(&model.User{ID: moderatorID, Role: model.RoleModerator}, nil)
+	m.reports.On("GetReport", reportID).
+		Return(&model.Report{ID: reportID, TargetType: model.ReportTargetPost, TargetID: postID, Status: model.ReportStatusOpen}, nil)
+	m.posts.On("DeletePost", postID).Return(nil).Once()
+	m.reports.On("AddModerationAction", mock.Anything, model.ReportStatusResolved).Return(&model.Report{ID: reportID}, nil).Once()
+	auditor.On("Record", mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.Action == model.AuditReportModerated
+	})).Return(nil)
+	auditor.On("Record", mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.Action == model.AuditPostRemoved
+	})).Return(auditErr)
+
+	// Пост уже удален, поэтому жалоба не открывается снова:
+	// RevertModerationAction не ожидается.
+	_, err := s.Moderate(context.Background(), &model.ModerationAction{
+		ReportID: reportID,
+		ActorID:  moderatorID,
+		Type:     model.ModerationRemoveContent,
+	})
+	assert.ErrorIs(t, err, auditErr)
+}
+
+func TestModerationService_Moderate_RemovesAlreadyDeletedPost(t *testing.T) {
+	moderatorID := uuid.New()
+	postID := uuid.New()
+	reportID := uuid.New()
+
+	s, m := newModerationService(t)
+
+	m.users.On("GetUserById", moderatorID).Return(&model.User{ID: moderatorID, Role: model.RoleModerator}, nil)
+	m.reports.On("GetReport", reportID).
+		Return(&model.Report{ID: reportID, TargetType: model.ReportTargetPost, TargetID: postID, Status: model.ReportStatusOpen}, nil)
+	m.reports.On("AddModerationAction", mock.Anything, model.ReportStatusResolved).Return(&model.Report{ID: reportID}, nil).Once()
+	m.posts.On("DeletePost", postID).Return(model.ErrPostNotFound).Once()
+
+	_, err := s.Moderate(context.Background(), &model.ModerationAction{
+		ReportID: reportID,
+		ActorID:  moderatorID,
+		Type:     model.ModerationRemoveContent,
+	})
+	assert.NoError(t, err)
+}
+
+func TestModerationService_HoldForReview(t *testing.T) {
+	postID := uuid.New()
+	violations := []model.PolicyViolation{
+		{Rule: "blocked_word", Action: model.PolicyHold, Detail: "spam"},
+		{Rule: "max_mentions", Action: model.PolicySensitive, Detail: "11 mentions, max 10"},
+	}
+
+	s, m := newModerationService(t)
+	m.reports.On("CreateReport", mock.MatchedBy(func(r *model.Report) bool {
+		return r.ReporterID == uuid.Nil && r.TargetType == model.ReportTargetPost && r.TargetID == postID &&
+			r.Reason == model.ReportReasonPolicy &&
+			r.Comment == "blocked_word (hold): spam; max_mentions (sensitive): 11 mentions, max 10"
+	})).Return(&model.Report{ID: uuid.New()}, nil).Once()
+
+	require.NoError(t, s.HoldForReview(context.Background(), &model.Post{ID: postID}, violations))
+
+	// Повторная задержка того же поста не плодит жалобы.
+	m.reports.On("CreateReport", mock.Anything).Return(nil, model.ErrAlreadyReported).Once()
+	assert.NoError(t, s.HoldForReview(context.Background(), &model.Post{ID: postID}, violations))
+}
@@ -3,22 +3,32 @@ package service_test
 import (
 	"context"
 	"errors"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/clock"
 	"micro-blog/internal/model"
 	"micro-blog/internal/service"
+	mockfollow "micro-blog/internal/service/mocks"
+	mockmedia "micro-blog/internal/service/mocks"
 	mockpost "micro-blog/internal/service/mocks"
 	mockqueue "micro-blog/internal/service/mocks"
 	mockuser "micro-blog/internal/service/mocks"
 )
 
+var testPostLimits = model.PostLimits{MaxTextLength: 500}
+
 func TestPostService_CreatePost(t *testing.T) {
 	type fields struct {
-		userRepo *mockuser.UserRepository
-		postRepo *mockpost.PostRepository
+		userRepo  *mockuser.UserRepository
+		postRepo  *mockpost.PostRepository
+		mediaRepo *mockmedia.MediaRepository
 	}
 
 	tests := []struct {
@@ -55,15 +65,39 @@ func TestPostService_CreatePost(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "4) Attachment uploaded by author",
+			post: &model.Post{AuthorID: uuid.New(), Text: "Photo", AttachmentIDs: []uuid.UUID{uuid.New()}},
+			setupMocks: func(f fields, post *model.Post) {
+				user := &model.User{ID: post.AuthorID, Name: "Alice"}
+				media := &model.Media{ID: post.AttachmentIDs[0], OwnerID: post.AuthorID}
+				f.userRepo.On("GetUserById", post.AuthorID).Return(user, nil)
+				f.mediaRepo.On("GetMedia", media.ID).Return(media, nil)
+				f.postRepo.On("CreatePost", post).Return(post, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "5) Attachment uploaded by someone else",
+			post: &model.Post{AuthorID: uuid.New(), Text: "Stolen", AttachmentIDs: []uuid.UUID{uuid.New()}},
+			setupMocks: func(f fields, post *model.Post) {
+				user := &model.User{ID: post.AuthorID, Name: "Mallory"}
+				media := &model.Media{ID: post.AttachmentIDs[0], OwnerID: uuid.New()}
+				f.userRepo.On("GetUserById", post.AuthorID).Return(user, nil)
+				f.mediaRepo.On("GetMedia", media.ID).Return(media, nil)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			userRepo := mockuser.NewUserRepository(t)
 			postRepo := mockpost.NewPostRepository(t)
-			tt.setupMocks(fields{userRepo, postRepo}, tt.post)
+			mediaRepo := mockmedia.NewMediaRepository(t)
+			tt.setupMocks(fields{userRepo, postRepo, mediaRepo}, tt.post)
 
-			s := service.NewPostService(postRepo, userRepo)
+			s := service.NewPostService(postRepo, withPublicAuthors(userRepo), newFollowRepo(t), mediaRepo, testPostLimits)
 			got, err := s.CreatePost(context.Background(), tt.post)
 
 			if tt.wantErr {
@@ -77,6 +111,898 @@ func TestPostService_CreatePost(t *testing.T) {
 	}
 }
 
+func TestPostService_CreatePost_Text(t *testing.T) {
+	authorID := uuid.New()
+	bobID := uuid.New()
+	limits := model.PostLimits{MaxTextLength: 12}
+
+	tests := []struct {
+		name         string
+		text         string
+		setup        func(ur *mockuser.UserRepository, pr *mockpost.PostRepository)
+		wantText     string
+		wantEntities []model.Entity
+		wantErr      error
+	}{
+		{
+			name:    "empty text",
+			text:    "",
+			wantErr: model.ErrEmptyPost,
+		},
+		{
+			name:    "whitespace and control characters only",
+			text:    " \t\n\x00\u202E ",
+			wantErr: model.ErrEmptyPost,
+		},
+		{
+			name:    "invisible format characters only",
+			text:    "\u200B\u2060\uFEFF",
+			wantErr: model.ErrEmptyPost,
+		},
+		{
+			name:    "too long",
+			text:    "hello world!!",
+			wantErr: model.ErrPostTooLong,
+		},
+		{
+			name: "length counted in graphemes",
+			text: "👍🏽👍🏽👍🏽👍🏽👍🏽👍🏽👍🏽👍🏽👍🏽👍🏽👍🏽👍🏽",
+			setup: func(ur *mockuser.UserRepository, pr *mockpost.PostRepository) {
+				pr.On("CreatePost", mock.Anything).Return(&model.Post{}, nil)
+			},
+			wantText: "👍🏽👍🏽👍🏽👍🏽👍🏽👍🏽👍🏽👍🏽👍🏽👍🏽👍🏽👍🏽",
+		},
+		{
+			name: "control characters stripped",
+			text: "  hi\x07\r\nyou\u202E ",
+			setup: func(ur *mockuser.UserRepository, pr *mockpost.PostRepository) {
+				pr.On("CreatePost", mock.Anything).Return(&model.Post{}, nil)
+			},
+			wantText: "hi\nyou",
+		},
+		{
+			name: "mention resolved",
+			text: "hey @Bob #go",
+			setup: func(ur *mockuser.UserRepository, pr *mockpost.PostRepository) {
+				ur.On("GetUserByName", "bob").Return(&model.User{ID: bobID, Name: "bob"}, nil)
+				pr.On("CreatePost", mock.Anything).Return(&model.Post{}, nil)
+			},
+			wantText: "hey @Bob #go",
+			wantEntities: []model.Entity{
+				{Type: model.EntityMention, Start: 4, End: 8, Text: "Bob", UserID: bobID},
+				{Type: model.EntityHashtag, Start: 9, End: 12, Text: "go"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := mockuser.NewUserRepository(t)
+			postRepo := mockpost.NewPostRepository(t)
+			userRepo.On("GetUserById", authorID).Return(&model.User{ID: authorID}, nil)
+			if tt.setup != nil {
+				tt.setup(userRepo, postRepo)
+			}
+
+			s := service.NewPostService(postRepo, withPublicAuthors(userRepo), newFollowRepo(t), mockmedia.NewMediaRepository(t), limits)
+			post := &model.Post{AuthorID: authorID, Text: tt.text}
+			_, err := s.CreatePost(context.Background(), post)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantText, post.Text)
+			assert.Equal(t, tt.wantEntities, post.Entities)
+		})
+	}
+}
+
+func TestPostService_CreatePost_TTL(t *testing.T) {
+	authorID := uuid.New()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limits := model.PostLimits{MaxTextLength: 500, MaxTTL: time.Hour}
+
+	tests := []struct {
+		name          string
+		ttl           time.Duration
+		wantExpiresAt time.Time
+		wantErr       error
+	}{
+		{name: "permanent post", ttl: 0},
+		{name: "ttl within limit", ttl: 10 * time.Minute, wantExpiresAt: now.Add(10 * time.Minute)},
+		{name: "ttl over limit", ttl: 2 * time.Hour, wantErr: model.ErrInvalidTTL},
+		{name: "negative ttl", ttl: -time.Second, wantErr: model.ErrInvalidTTL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := mockuser.NewUserRepository(t)
+			postRepo := mockpost.NewPostRepository(t)
+			userRepo.On("GetUserById", authorID).Return(&model.User{ID: authorID}, nil)
+			if tt.wantErr == nil {
+				postRepo.On("CreatePost", mock.Anything).Return(&model.Post{}, nil)
+			}
+
+			s := service.NewPostService(postRepo, withPublicAuthors(userRepo), newFollowRepo(t), mockmedia.NewMediaRepository(t), limits)
+			s.SetClock(clock.NewFake(now))
+			post := &model.Post{AuthorID: authorID, Text: "text", TTL: tt.ttl}
+			_, err := s.CreatePost(context.Background(), post)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, now, post.CreatedAt)
+			assert.Equal(t, tt.wantExpiresAt, post.ExpiresAt)
+		})
+	}
+}
+
+func TestPostService_CreatePost_Poll(t *testing.T) {
+	authorID := uuid.New()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	options := func(texts ...string) []model.PollOption {
+		out := make([]model.PollOption, len(texts))
+		for i, text := range texts {
+			out[i] = model.PollOption{Text: text}
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		poll    *model.Poll
+		wantErr error
+	}{
+		{name: "valid poll", poll: &model.Poll{Options: options("a", "b"), ClosesAt: now.Add(time.Hour)}},
+		{name: "one option", poll: &model.Poll{Options: options("a"), ClosesAt: now.Add(time.Hour)}, wantErr: model.ErrInvalidPoll},
+		{name: "five options", poll: &model.Poll{Options: options("a", "b", "c", "d", "e"), ClosesAt: now.Add(time.Hour)}, wantErr: model.ErrInvalidPoll},
+		{name: "empty option", poll: &model.Poll{Options: options("a", " "), ClosesAt: now.Add(time.Hour)}, wantErr: model.ErrInvalidPoll},
+		{name: "closes in past", poll: &model.Poll{Options: options("a", "b"), ClosesAt: now}, wantErr: model.ErrInvalidPoll},
+		{name: "closes too late", poll: &model.Poll{Options: options("a", "b"), ClosesAt: now.Add(31 * 24 * time.Hour)}, wantErr: model.ErrInvalidPoll},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := mockuser.NewUserRepository(t)
+			postRepo := mockpost.NewPostRepository(t)
+			userRepo.On("GetUserById", authorID).Return(&model.User{ID: authorID}, nil)
+			if tt.wantErr == nil {
+				postRepo.On("CreatePost", mock.Anything).Return(&model.Post{Poll: tt.poll}, nil)
+			}
+
+			s := service.NewPostService(postRepo, withPublicAuthors(userRepo), newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
+			s.SetClock(clock.NewFake(now))
+			_, err := s.CreatePost(context.Background(), &model.Post{AuthorID: authorID, Text: "text", Poll: tt.poll})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPostService_VotePoll(t *testing.T) {
+	userID := uuid.New()
+	postID := uuid.New()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	newPoll := func() *model.Poll {
+		return &model.Poll{
+			Options:  []model.PollOption{{Text: "a"}, {Text: "b"}, {Text: "c"}},
+			ClosesAt: now.Add(time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		poll      func() *model.Poll
+		choices   []int
+		noQueue   bool
+		wantErr   error
+		wantQueue bool
+	}{
+		{
+			name:    "post without poll",
+			poll:    func() *model.Poll { return nil },
+			choices: []int{0},
+			wantErr: model.ErrPollNotFound,
+		},
+		{
+			name: "poll closed",
+			poll: func() *model.Poll {
+				p := newPoll()
+				p.ClosesAt = now
+				return p
+			},
+			choices: []int{0},
+			wantErr: model.ErrPollClosed,
+		},
+		{
+			name: "already voted",
+			poll: func() *model.Poll {
+				p := newPoll()
+				p.MyChoices = []int{1}
+				return p
+			},
+			choices: []int{0},
+			wantErr: model.ErrAlreadyVoted,
+		},
+		{name: "choice out of range", poll: newPoll, choices: []int{3}, wantErr: model.ErrInvalidVote},
+		{name: "several choices in single poll", poll: newPoll, choices: []int{0, 1}, wantErr: model.ErrInvalidVote},
+		{
+			name: "duplicate choices",
+			poll: func() *model.Poll {
+				p := newPoll()
+				p.Multiple = true
+				return p
+			},
+			choices: []int{1, 1},
+			wantErr: model.ErrInvalidVote,
+		},
+		{name: "queue not attached", poll: newPoll, choices: []int{0}, noQueue: true, wantErr: model.ErrVoteQueue},
+		{name: "single choice", poll: newPoll, choices: []int{2}, wantQueue: true},
+		{
+			name: "multiple choices",
+			poll: func() *model.Poll {
+				p := newPoll()
+				p.Multiple = true
+				return p
+			},
+			choices:   []int{0, 2},
+			wantQueue: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := mockpost.NewPostRepository(t)
+			postRepo.On("GetPost", postID, userID).Return(&model.Post{ID: postID, Poll: tt.poll()}, nil)
+
+			s := service.NewPostService(postRepo, withPublicAuthors(mockuser.NewUserRepository(t)), newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
+			s.SetClock(clock.NewFake(now))
+
+			voteQueue := new(mockqueue.MockVoteQueue)
+			if !tt.noQueue {
+				s.AttachVoteQueue(voteQueue)
+			}
+			if tt.wantQueue {
+				voteQueue.On("Enqueue", mock.MatchedBy(func(v *model.PollVote) bool {
+					return v.PostID == postID && v.UserID == userID && v.VotedAt.Equal(now)
+				})).Return()
+			}
+
+			err := s.VotePoll(context.Background(), &model.PollVote{PostID: postID, UserID: userID, Choices: tt.choices})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				voteQueue.AssertNotCalled(t, "Enqueue", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			voteQueue.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPostService_GetPost_Expired(t *testing.T) {
+	postID := uuid.New()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
+
+	postRepo := mockpost.NewPostRepository(t)
+	postRepo.On("GetPost", postID, uuid.Nil).Return(&model.Post{ID: postID, ExpiresAt: now.Add(time.Minute)}, nil)
+
+	s := service.NewPostService(postRepo, withPublicAuthors(mockuser.NewUserRepository(t)), newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
+	s.SetClock(clk)
+
+	_, err := s.GetPost(context.Background(), uuid.Nil, postID)
+	assert.NoError(t, err)
+
+	clk.Advance(time.Minute)
+	_, err = s.GetPost(context.Background(), uuid.Nil, postID)
+	assert.ErrorIs(t, err, model.ErrPostNotFound)
+}
+
+func TestPostService_BlockedAndMuted(t *testing.T) {
+	viewerID := uuid.New()
+	blockedID := uuid.New()
+	mutedID := uuid.New()
+	blockedPost := &model.Post{ID: uuid.New(), AuthorID: blockedID}
+	mutedPost := &model.Post{ID: uuid.New(), AuthorID: mutedID}
+
+	newService := func(t *testing.T) (*service.PostService, *mockpost.PostRepository) {
+		postRepo := mockpost.NewPostRepository(t)
+		followRepo := mockfollow.NewFollowRepository(t)
+		followRepo.On("IsBlocked", viewerID, blockedID).Return(true).Maybe()
+		followRepo.On("IsBlocked", viewerID, mutedID).Return(false).Maybe()
+		followRepo.On("IsMuted", viewerID, blockedID).Return(false).Maybe()
+		followRepo.On("IsMuted", viewerID, mutedID).Return(true).Maybe()
+		return service.NewPostService(postRepo, withPublicAuthors(mockuser.NewUserRepository(t)), followRepo, mockmedia.NewMediaRepository(t), testPostLimits), postRepo
+	}
+
+	t.Run("blocked author's post is hidden", func(t *testing.T) {
+		s, postRepo := newService(t)
+		postRepo.On("GetPost", blockedPost.ID, viewerID).Return(blockedPost, nil)
+
+		_, err := s.GetPost(context.Background(), viewerID, blockedPost.ID)
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+	})
+
+	t.Run("muted author's post is reachable directly", func(t *testing.T) {
+		s, postRepo := newService(t)
+		postRepo.On("GetPost", mutedPost.ID, viewerID).Return(mutedPost, nil)
+
+		_, err := s.GetPost(context.Background(), viewerID, mutedPost.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("feed skips blocked and muted authors", func(t *testing.T) {
+		s, postRepo := newService(t)
+		postRepo.On("GetListPost", viewerID).Return([]*model.Post{blockedPost, mutedPost}, nil)
+
+		posts, err := s.GetListPost(context.Background(), viewerID)
+		require.NoError(t, err)
+		assert.Empty(t, posts)
+	})
+}
+
+func TestPostService_GetPost_PrivateAuthor(t *testing.T) {
+	authorID := uuid.New()
+	followerID := uuid.New()
+	strangerID := uuid.New()
+	postID := uuid.New()
+
+	tests := []struct {
+		name     string
+		viewerID uuid.UUID
+		wantErr  error
+	}{
+		{name: "author", viewerID: authorID},
+		{name: "approved follower", viewerID: followerID},
+		{name: "stranger", viewerID: strangerID, wantErr: model.ErrPostNotFound},
+		{name: "anonymous", viewerID: uuid.Nil, wantErr: model.ErrPostNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := mockpost.NewPostRepository(t)
+			postRepo.On("GetPost", postID, tt.viewerID).Return(&model.Post{ID: postID, AuthorID: authorID}, nil)
+
+			userRepo := mockuser.NewUserRepository(t)
+			userRepo.On("GetUserById", authorID).Return(&model.User{ID: authorID, Private: true}, nil).Maybe()
+			userRepo.On("ListShadowBanned", mock.Anything).Return(nil, nil).Maybe()
+
+			followRepo := newFollowRepo(t)
+			followRepo.On("IsFollowing", followerID, authorID).Return(true).Maybe()
+			followRepo.On("IsFollowing", strangerID, authorID).Return(false).Maybe()
+
+			s := service.NewPostService(postRepo, userRepo, followRepo, mockmedia.NewMediaRepository(t), testPostLimits)
+			_, err := s.GetPost(context.Background(), tt.viewerID, postID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPostService_GetPost_SanctionedAuthor(t *testing.T) {
+	authorID := uuid.New()
+	viewerID := uuid.New()
+	postID := uuid.New()
+	until := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		author   model.User
+		viewerID uuid.UUID
+		wantErr  error
+	}{
+		{name: "suspended, viewer", author: model.User{SuspendedUntil: until}, viewerID: viewerID, wantErr: model.ErrPostNotFound},
+		{name: "suspended, anonymous", author: model.User{SuspendedUntil: until}, viewerID: uuid.Nil, wantErr: model.ErrPostNotFound},
+		{name: "shadow banned, viewer", author: model.User{ShadowBannedUntil: until}, viewerID: viewerID, wantErr: model.ErrPostNotFound},
+		{name: "shadow banned, author", author: model.User{ShadowBannedUntil: until}, viewerID: authorID},
+		{name: "expired suspension", author: model.User{SuspendedUntil: time.Now().Add(-time.Hour)}, viewerID: viewerID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := mockpost.NewPostRepository(t)
+			postRepo.On("GetPost", postID, tt.viewerID).Return(&model.Post{ID: postID, AuthorID: authorID}, nil)
+
+			author := tt.author
+			author.ID = authorID
+			userRepo := mockuser.NewUserRepository(t)
+			userRepo.On("GetUserById", authorID).Return(&author, nil).Maybe()
+			userRepo.On("ListShadowBanned", mock.Anything).Return(nil, nil).Maybe()
+
+			s := service.NewPostService(postRepo, userRepo, newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
+			_, err := s.GetPost(context.Background(), tt.viewerID, postID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPostService_ShadowBannedReactionsHidden(t *testing.T) {
+	authorID := uuid.New()
+	bannedID := uuid.New()
+	postID := uuid.New()
+
+	tests := []struct {
+		name      string
+		viewerID  uuid.UUID
+		wantLikes int
+	}{
+		{name: "others do not see the like", viewerID: uuid.New(), wantLikes: 1},
+		{name: "banned user sees own like", viewerID: bannedID, wantLikes: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := mockpost.NewPostRepository(t)
+			postRepo.On("GetPost", postID, tt.viewerID).Return(&model.Post{
+				ID:        postID,
+				AuthorID:  authorID,
+				Reactions: map[model.ReactionType]int{model.ReactionLike: 2},
+			}, nil)
+			postRepo.On("CountReactionsBy", []uuid.UUID{postID}, []uuid.UUID{bannedID}).
+				Return(map[uuid.UUID]map[model.ReactionType]int{postID: {model.ReactionLike: 1}}, nil).Maybe()
+
+			userRepo := mockuser.NewUserRepository(t)
+			userRepo.On("ListShadowBanned", mock.Anything).Return([]uuid.UUID{bannedID}, nil)
+			userRepo.On("GetUserById", authorID).Return(&model.User{ID: authorID}, nil)
+
+			s := service.NewPostService(postRepo, userRepo, newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
+			post, err := s.GetPost(context.Background(), tt.viewerID, postID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLikes, post.Reactions[model.ReactionLike])
+		})
+	}
+}
+
+func TestPostService_PinPost(t *testing.T) {
+	authorID := uuid.New()
+	postID := uuid.New()
+	limits := model.PostLimits{MaxTextLength: 500, MaxPinned: 2}
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		setup   func(pr *mockpost.PostRepository, userID uuid.UUID)
+		wantErr error
+	}{
+		{
+			name:   "post not found",
+			userID: authorID,
+			setup: func(pr *mockpost.PostRepository, userID uuid.UUID) {
+				pr.On("GetPost", postID, userID).Return(nil, model.ErrPostNotFound)
+			},
+			wantErr: model.ErrPostNotFound,
+		},
+		{
+			name:   "someone else's post",
+			userID: uuid.New(),
+			setup: func(pr *mockpost.PostRepository, userID uuid.UUID) {
+				pr.On("GetPost", postID, userID).Return(&model.Post{ID: postID, AuthorID: authorID}, nil)
+			},
+			wantErr: model.ErrForbidden,
+		},
+		{
+			name:   "limit reached",
+			userID: authorID,
+			setup: func(pr *mockpost.PostRepository, userID uuid.UUID) {
+				pr.On("GetPost", postID, userID).Return(&model.Post{ID: postID, AuthorID: authorID}, nil)
+				pr.On("PinPost", postID, mock.Anything, 2).Return(model.ErrPinLimitReached)
+			},
+			wantErr: model.ErrPinLimitReached,
+		},
+		{
+			name:   "pinned",
+			userID: authorID,
+			setup: func(pr *mockpost.PostRepository, userID uuid.UUID) {
+				pr.On("GetPost", postID, userID).Return(&model.Post{ID: postID, AuthorID: authorID}, nil)
+				pr.On("PinPost", postID, mock.Anything, 2).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := mockpost.NewPostRepository(t)
+			tt.setup(postRepo, tt.userID)
+
+			s := service.NewPostService(postRepo, withPublicAuthors(mockuser.NewUserRepository(t)), newFollowRepo(t), mockmedia.NewMediaRepository(t), limits)
+			err := s.PinPost(context.Background(), tt.userID, postID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPostService_EditPost(t *testing.T) {
+	authorID := uuid.New()
+	postID := uuid.New()
+	limits := model.PostLimits{MaxTextLength: 500, EditWindow: time.Hour}
+
+	tests := []struct {
+		name     string
+		editorID uuid.UUID
+		text     string
+		stored   *model.Post
+		getErr   error
+		wantEdit bool
+		wantErr  error
+	}{
+		{
+			name:     "post not found",
+			editorID: authorID,
+			text:     "new",
+			getErr:   model.ErrPostNotFound,
+			wantErr:  model.ErrPostNotFound,
+		},
+		{
+			name:     "not an author",
+			editorID: uuid.New(),
+			text:     "new",
+			stored:   &model.Post{ID: postID, AuthorID: authorID, CreatedAt: time.Now()},
+			wantErr:  model.ErrForbidden,
+		},
+		{
+			name:     "edit window closed",
+			editorID: authorID,
+			text:     "new",
+			stored:   &model.Post{ID: postID, AuthorID: authorID, CreatedAt: time.Now().Add(-2 * time.Hour)},
+			wantErr:  model.ErrEditWindowClosed,
+		},
+		{
+			name:     "empty text",
+			editorID: authorID,
+			text:     "  ",
+			stored:   &model.Post{ID: postID, AuthorID: authorID, CreatedAt: time.Now()},
+			wantErr:  model.ErrEmptyPost,
+		},
+		{
+			name:     "success",
+			editorID: authorID,
+			text:     " new #text ",
+			stored:   &model.Post{ID: postID, AuthorID: authorID, CreatedAt: time.Now()},
+			wantEdit: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := mockpost.NewPostRepository(t)
+			postRepo.On("GetPost", postID, tt.editorID).Return(tt.stored, tt.getErr)
+			if tt.wantEdit {
+				postRepo.On("EditPost", mock.MatchedBy(func(edit *model.PostEdit) bool {
+					return edit.PostID == postID && edit.Text == "new #text" &&
+						len(edit.Entities) == 1 && !edit.EditedAt.IsZero()
+				})).Return(&model.Post{ID: postID}, nil)
+			}
+
+			s := service.NewPostService(postRepo, withPublicAuthors(mockuser.NewUserRepository(t)), newFollowRepo(t), mockmedia.NewMediaRepository(t), limits)
+			_, err := s.EditPost(context.Background(), tt.editorID, postID, tt.text)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPostService_ContentPolicy(t *testing.T) {
+	authorID := uuid.New()
+	postID := uuid.New()
+	reject := model.PolicyViolation{Rule: "blocked_domain", Action: model.PolicyReject, Detail: "bad.example"}
+	hold := model.PolicyViolation{Rule: "max_mentions", Action: model.PolicyHold, Detail: "11 mentions, max 10"}
+	sensitive := model.PolicyViolation{Rule: "blocked_word", Action: model.PolicySensitive, Detail: "gore"}
+
+	tests := []struct {
+		name          string
+		violations    []model.PolicyViolation
+		wantErr       bool
+		wantHeld      bool
+		wantSensitive bool
+	}{
+		{name: "clean"},
+		{name: "sensitive", violations: []model.PolicyViolation{sensitive}, wantSensitive: true},
+		{name: "hold", violations: []model.PolicyViolation{hold, sensitive}, wantHeld: true, wantSensitive: true},
+		{name: "reject", violations: []model.PolicyViolation{sensitive, reject, hold}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := mockuser.NewUserRepository(t)
+			userRepo.On("GetUserById", authorID).Return(&model.User{ID: authorID}, nil)
+
+			contentPolicy := mockpost.NewContentPolicy(t)
+			contentPolicy.On("Check", mock.Anything).Return(tt.violations)
+			reviewer := mockpost.NewPostReviewer(t)
+
+			postRepo := mockpost.NewPostRepository(t)
+			if !tt.wantErr {
+				postRepo.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+					return post.Held == tt.wantHeld && post.Sensitive == tt.wantSensitive
+				})).Return(func(post *model.Post) *model.Post {
+					created := *post
+					created.ID = postID
+					return &created
+				}, nil)
+			}
+			if tt.wantHeld {
+				reviewer.On("HoldForReview", mock.Anything, mock.MatchedBy(func(post *model.Post) bool {
+					return post.ID == postID
+				}), tt.violations).Return(nil)
+			}
+
+			s := service.NewPostService(postRepo, userRepo, newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
+			s.AttachContentPolicy(contentPolicy, reviewer)
+			post, err := s.CreatePost(context.Background(), &model.Post{AuthorID: authorID, Text: "hello"})
+
+			if tt.wantErr {
+				var policyErr *model.PolicyError
+				require.ErrorAs(t, err, &policyErr)
+				assert.ErrorIs(t, err, model.ErrContentPolicy)
+				assert.Equal(t, tt.violations, policyErr.Violations)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHeld, post.Held)
+			assert.Equal(t, tt.wantSensitive, post.Sensitive)
+		})
+	}
+}
+
+func TestPostService_EditPost_ContentPolicy(t *testing.T) {
+	authorID := uuid.New()
+	postID := uuid.New()
+	limits := model.PostLimits{MaxTextLength: 500, EditWindow: time.Hour}
+	hold := []model.PolicyViolation{{Rule: "blocked_word", Action: model.PolicyHold, Detail: "spam"}}
+
+	newService := func(t *testing.T, postRepo *mockpost.PostRepository, violations []model.PolicyViolation) (*service.PostService, *mockpost.PostReviewer) {
+		contentPolicy := mockpost.NewContentPolicy(t)
+		contentPolicy.On("Check", mock.Anything).Return(violations)
+		reviewer := mockpost.NewPostReviewer(t)
+
+		s := service.NewPostService(postRepo, withPublicAuthors(mockuser.NewUserRepository(t)), newFollowRepo(t), mockmedia.NewMediaRepository(t), limits)
+		s.AttachContentPolicy(contentPolicy, reviewer)
+		return s, reviewer
+	}
+
+	t.Run("rejected edit is not saved", func(t *testing.T) {
+		postRepo := mockpost.NewPostRepository(t)
+		postRepo.On("GetPost", postID, authorID).Return(&model.Post{ID: postID, AuthorID: authorID, CreatedAt: time.Now()}, nil)
+		s, _ := newService(t, postRepo, []model.PolicyViolation{{Rule: "blocked_word", Action: model.PolicyReject}})
+
+		_, err := s.EditPost(context.Background(), authorID, postID, "spam")
+		assert.ErrorIs(t, err, model.ErrContentPolicy)
+	})
+
+	t.Run("held edit goes to review", func(t *testing.T) {
+		postRepo := mockpost.NewPostRepository(t)
+		postRepo.On("GetPost", postID, authorID).Return(&model.Post{ID: postID, AuthorID: authorID, CreatedAt: time.Now()}, nil)
+		postRepo.On("EditPost", mock.MatchedBy(func(edit *model.PostEdit) bool {
+			return edit.Held && !edit.Sensitive
+		})).Return(&model.Post{ID: postID, AuthorID: authorID, Held: true}, nil)
+		s, reviewer := newService(t, postRepo, hold)
+		reviewer.On("HoldForReview", mock.Anything, mock.Anything, hold).Return(nil)
+
+		post, err := s.EditPost(context.Background(), authorID, postID, "spam")
+		require.NoError(t, err)
+		assert.True(t, post.Held)
+	})
+
+	t.Run("clean edit keeps earlier flags", func(t *testing.T) {
+		postRepo := mockpost.NewPostRepository(t)
+		postRepo.On("GetPost", postID, authorID).
+			Return(&model.Post{ID: postID, AuthorID: authorID, CreatedAt: time.Now(), Held: true, Sensitive: true}, nil)
+		postRepo.On("EditPost", mock.MatchedBy(func(edit *model.PostEdit) bool {
+			return edit.Held && edit.Sensitive
+		})).Return(&model.Post{ID: postID, AuthorID: authorID, Held: true, Sensitive: true}, nil)
+		s, _ := newService(t, postRepo, nil)
+
+		_, err := s.EditPost(context.Background(), authorID, postID, "clean")
+		assert.NoError(t, err)
+	})
+}
+
+func TestPostService_SpamGuard(t *testing.T) {
+	authorID := uuid.New()
+	postID := uuid.New()
+
+	newService := func(t *testing.T, postRepo *mockpost.PostRepository) (*service.PostService, *mockpost.SpamGuard, *mockpost.PostReviewer) {
+		userRepo := mockuser.NewUserRepository(t)
+		userRepo.On("GetUserById", authorID).Return(&model.User{ID: authorID}, nil)
+		withPublicAuthors(userRepo)
+		guard := mockpost.NewSpamGuard(t)
+		reviewer := mockpost.NewPostReviewer(t)
+
+		s := service.NewPostService(postRepo, userRepo, newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
+		s.AttachContentPolicy(nil, reviewer)
+		s.AttachSpamGuard(guard)
+		return s, guard, reviewer
+	}
+
+	t.Run("throttled post is not saved", func(t *testing.T) {
+		s, guard, _ := newService(t, mockpost.NewPostRepository(t))
+		guard.On("CheckPost", mock.Anything, mock.Anything, mock.Anything).Return(false, model.ErrRateLimited)
+
+		_, err := s.CreatePost(context.Background(), &model.Post{AuthorID: authorID, Text: "hello"})
+		assert.ErrorIs(t, err, model.ErrRateLimited)
+	})
+
+	t.Run("quarantined author's post is held", func(t *testing.T) {
+		postRepo := mockpost.NewPostRepository(t)
+		postRepo.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+			return post.Held
+		})).Return(&model.Post{ID: postID, AuthorID: authorID, Held: true}, nil)
+		s, guard, reviewer := newService(t, postRepo)
+		guard.On("CheckPost", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+		reviewer.On("HoldForReview", mock.Anything, mock.Anything, mock.MatchedBy(func(violations []model.PolicyViolation) bool {
+			return len(violations) == 1 && violations[0].Rule == "spam_quarantine" && violations[0].Action == model.PolicyHold
+		})).Return(nil)
+
+		post, err := s.CreatePost(context.Background(), &model.Post{AuthorID: authorID, Text: "hello"})
+		require.NoError(t, err)
+		assert.True(t, post.Held)
+	})
+
+	t.Run("quarantined user cannot react", func(t *testing.T) {
+		postRepo := mockpost.NewPostRepository(t)
+		postRepo.On("GetPost", postID, authorID).Return(&model.Post{ID: postID, AuthorID: uuid.New()}, nil)
+		s, guard, _ := newService(t, postRepo)
+		guard.On("CheckLike", mock.Anything, mock.Anything).Return(model.ErrQuarantined)
+
+		err := s.ReactToPost(context.Background(), &model.Reaction{PostID: postID, UserID: authorID, Type: model.ReactionLike})
+		assert.ErrorIs(t, err, model.ErrQuarantined)
+	})
+}
+
+func TestPostService_CreatePost_ContentWarning(t *testing.T) {
+	authorID := uuid.New()
+	photoID := uuid.New()
+
+	tests := []struct {
+		name      string
+		warning   string
+		sensitive []uuid.UUID
+		wantErr   error
+	}{
+		{name: "warning too long", warning: strings.Repeat("spoiler ", 20), wantErr: model.ErrContentWarningTooLong},
+		{name: "foreign sensitive attachment", sensitive: []uuid.UUID{uuid.New()}, wantErr: model.ErrInvalidAttachment},
+		{name: "warning and sensitive photo", warning: " Finale spoilers\u202e ", sensitive: []uuid.UUID{photoID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := mockuser.NewUserRepository(t)
+			postRepo := mockpost.NewPostRepository(t)
+			mediaRepo := mockmedia.NewMediaRepository(t)
+			userRepo.On("GetUserById", authorID).Return(&model.User{ID: authorID}, nil)
+			mediaRepo.On("GetMedia", photoID).Return(&model.Media{ID: photoID, OwnerID: authorID}, nil)
+			if tt.wantErr == nil {
+				postRepo.On("CreatePost", mock.Anything).Return(func(post *model.Post) (*model.Post, error) {
+					return post, nil
+				})
+			}
+
+			s := service.NewPostService(postRepo, withPublicAuthors(userRepo), newFollowRepo(t), mediaRepo, testPostLimits)
+			post, err := s.CreatePost(context.Background(), &model.Post{
+				AuthorID:               authorID,
+				Text:                   "He was dead all along",
+				AttachmentIDs:          []uuid.UUID{photoID},
+				ContentWarning:         tt.warning,
+				SensitiveAttachmentIDs: tt.sensitive,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Finale spoilers", post.ContentWarning)
+			assert.True(t, post.Collapsed)
+		})
+	}
+}
+
+func TestPostService_GetPost_Collapsed(t *testing.T) {
+	authorID := uuid.New()
+	viewerID := uuid.New()
+	expanderID := uuid.New()
+	plainID := uuid.New()
+	warnedID := uuid.New()
+
+	tests := []struct {
+		name          string
+		viewerID      uuid.UUID
+		postID        uuid.UUID
+		wantCollapsed bool
+	}{
+		{name: "anonymous viewer", viewerID: uuid.Nil, postID: warnedID, wantCollapsed: true},
+		{name: "default preference", viewerID: viewerID, postID: warnedID, wantCollapsed: true},
+		{name: "expand preference", viewerID: expanderID, postID: warnedID, wantCollapsed: false},
+		{name: "plain post", viewerID: viewerID, postID: plainID, wantCollapsed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := mockuser.NewUserRepository(t)
+			userRepo.On("GetUserById", expanderID).Return(&model.User{ID: expanderID, ExpandSensitive: true}, nil).Maybe()
+			postRepo := mockpost.NewPostRepository(t)
+			postRepo.On("GetPost", plainID, tt.viewerID).Return(&model.Post{ID: plainID, AuthorID: authorID}, nil).Maybe()
+			postRepo.On("GetPost", warnedID, tt.viewerID).
+				Return(&model.Post{ID: warnedID, AuthorID: authorID, ContentWarning: "Spoilers"}, nil).Maybe()
+
+			s := service.NewPostService(postRepo, withPublicAuthors(userRepo), newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
+			post, err := s.GetPost(context.Background(), tt.viewerID, tt.postID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCollapsed, post.Collapsed)
+		})
+	}
+}
+
+func TestPostService_GetPost_Held(t *testing.T) {
+	authorID := uuid.New()
+	postID := uuid.New()
+
+	for _, viewerID := range []uuid.UUID{uuid.Nil, uuid.New()} {
+		postRepo := mockpost.NewPostRepository(t)
+		postRepo.On("GetPost", postID, viewerID).Return(&model.Post{ID: postID, AuthorID: authorID, Held: true}, nil)
+
+		s := service.NewPostService(postRepo, withPublicAuthors(mockuser.NewUserRepository(t)), newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
+		_, err := s.GetPost(context.Background(), viewerID, postID)
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+	}
+
+	postRepo := mockpost.NewPostRepository(t)
+	postRepo.On("GetPost", postID, authorID).Return(&model.Post{ID: postID, AuthorID: authorID, Held: true}, nil)
+	s := service.NewPostService(postRepo, withPublicAuthors(mockuser.NewUserRepository(t)), newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
+	post, err := s.GetPost(context.Background(), authorID, postID)
+	require.NoError(t, err)
+	assert.True(t, post.Held)
+}
+
+func TestPostService_GetAuthorPosts(t *testing.T) {
+	authorID := uuid.New()
+	viewerID := uuid.New()
+	pinned := &model.Post{ID: uuid.New(), AuthorID: authorID, PinnedAt: time.Now()}
+	visible := &model.Post{ID: uuid.New(), AuthorID: authorID}
+	held := &model.Post{ID: uuid.New(), AuthorID: authorID, Held: true}
+	older := &model.Post{ID: uuid.New(), AuthorID: authorID}
+
+	postRepo := mockpost.NewPostRepository(t)
+	postRepo.On("GetAuthorPosts", authorID, viewerID, model.AuthorFeedFilter{}, int64(0), 2).
+		Return([]*model.Post{pinned, visible, held}, int64(5), nil).Once()
+	postRepo.On("GetAuthorPosts", authorID, viewerID, model.AuthorFeedFilter{}, int64(5), 1).
+		Return([]*model.Post{older}, int64(3), nil).Once()
+
+	s := service.NewPostService(postRepo, withPublicAuthors(mockuser.NewUserRepository(t)), newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
+	page, err := s.GetAuthorPosts(context.Background(), viewerID, authorID, model.AuthorFeedFilter{}, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Post{pinned, visible, older}, page.Posts, "held post is skipped and the page is topped up")
+	assert.Equal(t, int64(3), page.NextCursor)
+}
+
 func TestPostService_GetListPost(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -109,10 +1035,11 @@ func TestPostService_GetListPost(t *testing.T) {
 			postRepo := mockpost.NewPostRepository(t)
 			userRepo := mockuser.NewUserRepository(t) // Не используется, но требуется в конструкторе
 
-			postRepo.On("GetListPost").Return(tt.mockReturn, tt.mockError)
+			viewerID := uuid.New()
+			postRepo.On("GetListPost", viewerID).Return(tt.mockReturn, tt.mockError)
 
-			s := service.NewPostService(postRepo, userRepo)
-			got, err := s.GetListPost(context.Background())
+			s := service.NewPostService(postRepo, withPublicAuthors(userRepo), newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
+			got, err := s.GetListPost(context.Background(), viewerID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -125,13 +1052,82 @@ func TestPostService_GetListPost(t *testing.T) {
 	}
 }
 
-func TestPostService_LikePost(t *testing.T) {
+func TestPostService_GetPost_Visibility(t *testing.T) {
+	authorID := uuid.New()
+	followerID := uuid.New()
+	mentionedID := uuid.New()
+	strangerID := uuid.New()
+	postID := uuid.New()
+	mention := []model.Entity{{Type: model.EntityMention, Text: "bob", UserID: mentionedID}}
+
+	tests := []struct {
+		name       string
+		visibility model.Visibility
+		viewerID   uuid.UUID
+		following  *bool
+		wantErr    error
+	}{
+		{name: "public to anonymous", visibility: model.VisibilityPublic, viewerID: uuid.Nil},
+		{name: "unlisted by link", visibility: model.VisibilityUnlisted, viewerID: strangerID},
+		{name: "followers to author", visibility: model.VisibilityFollowers, viewerID: authorID},
+		{name: "followers to follower", visibility: model.VisibilityFollowers, viewerID: followerID, following: ptr(true)},
+		{
+			name:       "followers to stranger",
+			visibility: model.VisibilityFollowers,
+			viewerID:   strangerID,
+			following:  ptr(false),
+			wantErr:    model.ErrPostNotFound,
+		},
+		{
+			name:       "followers to anonymous",
+			visibility: model.VisibilityFollowers,
+			viewerID:   uuid.Nil,
+			wantErr:    model.ErrPostNotFound,
+		},
+		{name: "mentioned to mentioned user", visibility: model.VisibilityMentioned, viewerID: mentionedID},
+		{
+			name:       "mentioned to stranger",
+			visibility: model.VisibilityMentioned,
+			viewerID:   strangerID,
+			wantErr:    model.ErrPostNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := mockpost.NewPostRepository(t)
+			followRepo := newFollowRepo(t)
+			postRepo.On("GetPost", postID, tt.viewerID).Return(&model.Post{
+				ID:         postID,
+				AuthorID:   authorID,
+				Entities:   mention,
+				Visibility: tt.visibility,
+			}, nil)
+			if tt.following != nil {
+				followRepo.On("IsFollowing", tt.viewerID, authorID).Return(*tt.following)
+			}
+
+			s := service.NewPostService(postRepo, withPublicAuthors(mockuser.NewUserRepository(t)), followRepo, mockmedia.NewMediaRepository(t), testPostLimits)
+			post, err := s.GetPost(context.Background(), tt.viewerID, postID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, post)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPostService_ReactToPost(t *testing.T) {
 	type args struct {
-		like *model.Like
+		reaction *model.Reaction
 	}
 
 	userID := uuid.New()
 	postID := uuid.New()
+	authorID := uuid.New()
 
 	tests := []struct {
 		name           string
@@ -145,7 +1141,7 @@ func TestPostService_LikePost(t *testing.T) {
 	}{
 		{
 			name: "user not found",
-			args: args{like: &model.Like{PostID: postID, UserID: userID}},
+			args: args{reaction: &model.Reaction{PostID: postID, UserID: userID, Type: model.ReactionLike}},
 			mockUser: func(ur *mockuser.UserRepository) {
 				ur.On("GetUserById", userID).Return(nil, model.ErrUserNotFound)
 			},
@@ -155,18 +1151,56 @@ func TestPostService_LikePost(t *testing.T) {
 		},
 		{
 			name: "send enqueue like",
-			args: args{like: &model.Like{PostID: postID, UserID: userID}},
+			args: args{reaction: &model.Reaction{PostID: postID, UserID: userID, Type: model.ReactionLike}},
 			mockUser: func(ur *mockuser.UserRepository) {
 				ur.On("GetUserById", userID).Return(&model.User{ID: userID, Name: "Alice"}, nil)
 			},
 			mockPost: func(pr *mockpost.PostRepository) {
+				pr.On("GetPost", postID, userID).Return(&model.Post{ID: postID, AuthorID: authorID}, nil)
 			},
 			mockLikeQueue: func(lq *mockqueue.MockLikeQueue) {
 				lq.On("Enqueue", mock.Anything).Once()
-
 			},
 			expectedErrMsg: nil,
 		},
+		{
+			name: "send enqueue custom emoji",
+			args: args{reaction: &model.Reaction{PostID: postID, UserID: userID, Type: ":party_parrot:"}},
+			mockUser: func(ur *mockuser.UserRepository) {
+				ur.On("GetUserById", userID).Return(&model.User{ID: userID, Name: "Alice"}, nil)
+			},
+			mockPost: func(pr *mockpost.PostRepository) {
+				pr.On("GetPost", postID, userID).Return(&model.Post{ID: postID, AuthorID: authorID}, nil)
+			},
+			mockLikeQueue: func(lq *mockqueue.MockLikeQueue) {
+				lq.On("Enqueue", mock.Anything).Once()
+			},
+			expectedErrMsg: nil,
+		},
+		{
+			name: "post hidden from user",
+			args: args{reaction: &model.Reaction{PostID: postID, UserID: userID, Type: model.ReactionLike}},
+			mockUser: func(ur *mockuser.UserRepository) {
+				ur.On("GetUserById", userID).Return(&model.User{ID: userID, Name: "Alice"}, nil)
+			},
+			mockPost: func(pr *mockpost.PostRepository) {
+				pr.On("GetPost", postID, userID).Return(&model.Post{
+					ID:         postID,
+					AuthorID:   authorID,
+					Visibility: model.VisibilityMentioned,
+				}, nil)
+			},
+			mockLikeQueue:  func(lq *mockqueue.MockLikeQueue) {},
+			expectedErrMsg: model.ErrPostNotFound,
+		},
+		{
+			name:           "invalid reaction type",
+			args:           args{reaction: &model.Reaction{PostID: postID, UserID: userID, Type: "wow!!"}},
+			mockUser:       func(ur *mockuser.UserRepository) {},
+			mockPost:       func(pr *mockpost.PostRepository) {},
+			mockLikeQueue:  func(lq *mockqueue.MockLikeQueue) {},
+			expectedErrMsg: model.ErrInvalidReaction,
+		},
 	}
 
 	for _, tt := range tests {
@@ -179,10 +1213,10 @@ func TestPostService_LikePost(t *testing.T) {
 			tt.mockPost(postRepo)
 			tt.mockLikeQueue(likeQueue)
 
-			ps := service.NewPostService(postRepo, userRepo)
+			ps := service.NewPostService(postRepo, withPublicAuthors(userRepo), newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
 			ps.AttachLikeQueue(likeQueue)
 
-			err := ps.LikePost(context.Background(), tt.args.like)
+			err := ps.ReactToPost(context.Background(), tt.args.reaction)
 			assert.Equal(t, tt.expectedErrMsg, err)
 
 			userRepo.AssertExpectations(t)
@@ -192,7 +1226,68 @@ func TestPostService_LikePost(t *testing.T) {
 	}
 }
 
-func BenchmarkPostService_LikePost(b *testing.B) {
+func TestPostService_GetPostReactions(t *testing.T) {
+	postID := uuid.New()
+	alice := &model.User{ID: uuid.New(), Name: "Alice"}
+	bob := &model.User{ID: uuid.New(), Name: "Bob"}
+
+	tests := []struct {
+		name      string
+		setup     func(pr *mockpost.PostRepository, ur *mockuser.UserRepository)
+		wantNames []string
+		wantNext  int64
+		wantErr   error
+	}{
+		{
+			name: "post not found",
+			setup: func(pr *mockpost.PostRepository, ur *mockuser.UserRepository) {
+				pr.On("GetPost", postID, alice.ID).Return(nil, model.ErrPostNotFound)
+			},
+			wantErr: model.ErrPostNotFound,
+		},
+		{
+			name: "reactors resolved to names",
+			setup: func(pr *mockpost.PostRepository, ur *mockuser.UserRepository) {
+				pr.On("GetPost", postID, alice.ID).Return(&model.Post{ID: postID, AuthorID: bob.ID}, nil)
+				pr.On("GetPostReactions", postID, model.ReactionNone, int64(0), 2).Return([]*model.Reaction{
+					{UserID: alice.ID, PostID: postID, Type: model.ReactionLike},
+					{UserID: bob.ID, PostID: postID, Type: model.ReactionLove},
+				}, int64(7), nil)
+				ur.On("GetUserById", alice.ID).Return(alice, nil)
+				ur.On("GetUserById", bob.ID).Return(bob, nil)
+			},
+			wantNames: []string{"Alice:like", "Bob:love"},
+			wantNext:  7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := mockpost.NewPostRepository(t)
+			userRepo := mockuser.NewUserRepository(t)
+			tt.setup(postRepo, userRepo)
+
+			s := service.NewPostService(postRepo, withPublicAuthors(userRepo), newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
+			page, err := s.GetPostReactions(context.Background(), alice.ID, postID, model.ReactionNone, 0, 2)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, page)
+				return
+			}
+
+			assert.NoError(t, err)
+			names := make([]string, len(page.Reactions))
+			for i, r := range page.Reactions {
+				names[i] = r.User.Name + ":" + string(r.Type)
+			}
+			assert.Equal(t, tt.wantNames, names)
+			assert.Equal(t, tt.wantNext, page.NextCursor)
+		})
+	}
+}
+
+func BenchmarkPostService_ReactToPost(b *testing.B) {
 	userID := uuid.New()
 	postID := uuid.New()
 
@@ -201,20 +1296,21 @@ func BenchmarkPostService_LikePost(b *testing.B) {
 	likeQueue := new(mockqueue.MockLikeQueue)
 
 	userRepo.On("GetUserById", mock.Anything).Return(&model.User{ID: userID, Name: "BenchUser"}, nil)
+	postRepo.On("GetPost", postID, userID).Return(&model.Post{ID: postID}, nil)
 	likeQueue.On("Enqueue", mock.Anything).Return()
 
-	service := service.NewPostService(postRepo, userRepo)
+	service := service.NewPostService(postRepo, withPublicAuthors(userRepo), newFollowRepo(b), mockmedia.NewMediaRepository(b), testPostLimits)
 	service.AttachLikeQueue(likeQueue)
 
-	like := &model.Like{PostID: postID, UserID: userID}
+	like := &model.Reaction{PostID: postID, UserID: userID, Type: model.ReactionLike}
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = service.LikePost(context.Background(), like)
+		_ = service.ReactToPost(context.Background(), like)
 	}
 }
 
-func TestPostService_LikePost_Concurrent(t *testing.T) {
+func TestPostService_ReactToPost_Concurrent(t *testing.T) {
 	userID := uuid.New()
 	postID := uuid.New()
 
@@ -223,20 +1319,49 @@ func TestPostService_LikePost_Concurrent(t *testing.T) {
 	likeQueue := new(mockqueue.MockLikeQueue)
 
 	userRepo.On("GetUserById", userID).Return(&model.User{ID: userID, Name: "ConcurrentUser"}, nil)
+	postRepo.On("GetPost", postID, userID).Return(&model.Post{ID: postID}, nil)
 	likeQueue.On("Enqueue", mock.Anything).Return()
 
-	service := service.NewPostService(postRepo, userRepo)
+	service := service.NewPostService(postRepo, withPublicAuthors(userRepo), newFollowRepo(t), mockmedia.NewMediaRepository(t), testPostLimits)
 	service.AttachLikeQueue(likeQueue)
 
-	like := &model.Like{PostID: postID, UserID: userID}
+	like := &model.Reaction{PostID: postID, UserID: userID, Type: model.ReactionLike}
 
 	t.Run("parallel likes", func(t *testing.T) {
-		t.Parallel()
+		var wg sync.WaitGroup
 		for i := 0; i < 100; i++ {
+			wg.Add(1)
 			go func() {
-				err := service.LikePost(context.Background(), like)
+				defer wg.Done()
+				err := service.ReactToPost(context.Background(), like)
 				assert.NoError(t, err)
 			}()
 		}
+		wg.Wait()
 	})
 }
+
+// withPublicAuthors разрешает сервису проверять, закрыт ли аккаунт автора
+// поста и нет ли на нем санкций: авторы без явных ожиданий считаются
+// открытыми, теневых банов нет.
+func withPublicAuthors(userRepo *mockuser.UserRepository) *mockuser.UserRepository {
+	userRepo.On("GetUserById", mock.Anything).Return(&model.User{}, nil).Maybe()
+	userRepo.On("ListShadowBanned", mock.Anything).Return(nil, nil).Maybe()
+	return userRepo
+}
+
+// newFollowRepo возвращает мок графа подписок, в котором нет блокировок
+// и заглушений.
+func newFollowRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockfollow.FollowRepository {
+	followRepo := mockfollow.NewFollowRepository(t)
+	followRepo.On("IsBlocked", mock.Anything, mock.Anything).Return(false).Maybe()
+	followRepo.On("IsMuted", mock.Anything, mock.Anything).Return(false).Maybe()
+	return followRepo
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
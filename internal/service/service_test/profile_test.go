@@ -0,0 +1,201 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/model"
+	"micro-blog/internal/service"
+	"micro-blog/internal/service/mocks"
+)
+
+func TestProfileService_GetProfile(t *testing.T) {
+	user := &model.User{ID: uuid.New(), Name: "alice", Bio: "hi"}
+
+	tests := []struct {
+		name    string
+		setup   func(ur *mocks.UserRepository, pr *mocks.PostRepository, fr *mocks.FollowRepository)
+		want    *model.Profile
+		wantErr error
+	}{
+		{
+			name: "user not found",
+			setup: func(ur *mocks.UserRepository, pr *mocks.PostRepository, fr *mocks.FollowRepository) {
+				ur.On("GetUserById", user.ID).Return(nil, model.ErrUserNotFound)
+			},
+			wantErr: model.ErrUserNotFound,
+		},
+		{
+			name: "profile with counters",
+			setup: func(ur *mocks.UserRepository, pr *mocks.PostRepository, fr *mocks.FollowRepository) {
+				ur.On("GetUserById", user.ID).Return(user, nil)
+				pr.On("CountPostsByAuthor", user.ID, mock.Anything).Return(3)
+				fr.On("CountFollowers", user.ID).Return(2)
+				fr.On("CountFollowing", user.ID).Return(1)
+			},
+			want: &model.Profile{User: user, PostsCount: 3, FollowersCount: 2, FollowingCount: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := mocks.NewUserRepository(t)
+			postRepo := mocks.NewPostRepository(t)
+			followRepo := mocks.NewFollowRepository(t)
+			tt.setup(userRepo, postRepo, followRepo)
+
+			s := service.NewProfileService(userRepo, postRepo, followRepo, mocks.NewMediaRepository(t))
+			got, err := s.GetProfile(context.Background(), user.ID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProfileService_UpdateProfile(t *testing.T) {
+	userID := uuid.New()
+	ptr := func(s string) *string { return &s }
+
+	t.Run("sanitizes text fields", func(t *testing.T) {
+		userRepo := mocks.NewUserRepository(t)
+		postRepo := mocks.NewPostRepository(t)
+		followRepo := mocks.NewFollowRepository(t)
+
+		update := &model.ProfileUpdate{
+			DisplayName: ptr(" \u202Ealice\u202C "),
+			Bio:         ptr("line\r\nnext\x07"),
+			Website:     ptr("HTTPS://example.com"),
+		}
+		user := &model.User{ID: userID}
+		userRepo.On("UpdateUser", userID, mock.MatchedBy(func(u *model.ProfileUpdate) bool {
+			return *u.DisplayName == "alice" && *u.Bio == "line\nnext"
+		})).Return(user, nil)
+		postRepo.On("CountPostsByAuthor", userID, mock.Anything).Return(0)
+		followRepo.On("CountFollowers", userID).Return(0)
+		followRepo.On("CountFollowing", userID).Return(0)
+
+		s := service.NewProfileService(userRepo, postRepo, followRepo, mocks.NewMediaRepository(t))
+		_, err := s.UpdateProfile(context.Background(), userID, update)
+		require.NoError(t, err)
+	})
+
+	for _, website := range []string{"javascript:alert(1)", "data:text/html,x", "//example.com", "example.com"} {
+		t.Run("rejects "+website, func(t *testing.T) {
+			s := service.NewProfileService(mocks.NewUserRepository(t), mocks.NewPostRepository(t), mocks.NewFollowRepository(t), mocks.NewMediaRepository(t))
+			_, err := s.UpdateProfile(context.Background(), userID, &model.ProfileUpdate{Website: ptr(website)})
+			assert.ErrorIs(t, err, model.ErrInvalidWebsite)
+		})
+	}
+}
+
+func TestProfileService_Follow(t *testing.T) {
+	alice := uuid.New()
+	bob := uuid.New()
+
+	tests := []struct {
+		name     string
+		follower uuid.UUID
+		followee uuid.UUID
+		setup    func(ur *mocks.UserRepository, fr *mocks.FollowRepository)
+		want     model.FollowStatus
+		wantErr  error
+	}{
+		{
+			name:     "cannot follow yourself",
+			follower: alice,
+			followee: alice,
+			setup:    func(ur *mocks.UserRepository, fr *mocks.FollowRepository) {},
+			wantErr:  model.ErrSelfFollow,
+		},
+		{
+			name:     "followee not found",
+			follower: alice,
+			followee: bob,
+			setup: func(ur *mocks.UserRepository, fr *mocks.FollowRepository) {
+				ur.On("GetUserById", alice).Return(&model.User{ID: alice}, nil)
+				ur.On("GetUserById", bob).Return(nil, model.ErrUserNotFound)
+			},
+			wantErr: model.ErrUserNotFound,
+		},
+		{
+			name:     "blocked",
+			follower: alice,
+			followee: bob,
+			setup: func(ur *mocks.UserRepository, fr *mocks.FollowRepository) {
+				ur.On("GetUserById", alice).Return(&model.User{ID: alice}, nil)
+				ur.On("GetUserById", bob).Return(&model.User{ID: bob}, nil)
+				fr.On("IsBlocked", alice, bob).Return(true)
+			},
+			wantErr: model.ErrBlocked,
+		},
+		{
+			name:     "follow",
+			follower: alice,
+			followee: bob,
+			setup: func(ur *mocks.UserRepository, fr *mocks.FollowRepository) {
+				ur.On("GetUserById", alice).Return(&model.User{ID: alice}, nil)
+				ur.On("GetUserById", bob).Return(&model.User{ID: bob}, nil)
+				fr.On("IsBlocked", alice, bob).Return(false)
+				fr.On("Follow", alice, bob).Return(nil)
+			},
+			want: model.FollowStatusFollowing,
+		},
+		{
+			name:     "private account gets a request",
+			follower: alice,
+			followee: bob,
+			setup: func(ur *mocks.UserRepository, fr *mocks.FollowRepository) {
+				ur.On("GetUserById", alice).Return(&model.User{ID: alice}, nil)
+				ur.On("GetUserById", bob).Return(&model.User{ID: bob, Private: true}, nil)
+				fr.On("IsBlocked", alice, bob).Return(false)
+				fr.On("IsFollowing", alice, bob).Return(false)
+				fr.On("RequestFollow", mock.MatchedBy(func(r *model.FollowRequest) bool {
+					return r.FollowerID == alice && r.FolloweeID == bob
+				})).Return(nil)
+			},
+			want: model.FollowStatusRequested,
+		},
+		{
+			name:     "already approved follower",
+			follower: alice,
+			followee: bob,
+			setup: func(ur *mocks.UserRepository, fr *mocks.FollowRepository) {
+				ur.On("GetUserById", alice).Return(&model.User{ID: alice}, nil)
+				ur.On("GetUserById", bob).Return(&model.User{ID: bob, Private: true}, nil)
+				fr.On("IsBlocked", alice, bob).Return(false)
+				fr.On("IsFollowing", alice, bob).Return(true)
+				fr.On("Follow", alice, bob).Return(nil)
+			},
+			want: model.FollowStatusFollowing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := mocks.NewUserRepository(t)
+			postRepo := mocks.NewPostRepository(t)
+			followRepo := mocks.NewFollowRepository(t)
+			tt.setup(userRepo, followRepo)
+
+			s := service.NewProfileService(userRepo, postRepo, followRepo, mocks.NewMediaRepository(t))
+			status, err := s.Follow(context.Background(), tt.follower, tt.followee)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, status)
+		})
+	}
+}
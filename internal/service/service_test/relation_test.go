@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/model"
+	"micro-blog/internal/service"
+	"micro-blog/internal/service/mocks"
+)
+
+func TestRelationService_Block(t *testing.T) {
+	ownerID := uuid.New()
+	targetID := uuid.New()
+
+	tests := []struct {
+		name     string
+		targetID uuid.UUID
+		setup    func(ur *mocks.UserRepository, fr *mocks.FollowRepository)
+		wantErr  error
+	}{
+		{
+			name:     "yourself",
+			targetID: ownerID,
+			setup:    func(ur *mocks.UserRepository, fr *mocks.FollowRepository) {},
+			wantErr:  model.ErrSelfRelation,
+		},
+		{
+			name:     "unknown user",
+			targetID: targetID,
+			setup: func(ur *mocks.UserRepository, fr *mocks.FollowRepository) {
+				ur.On("GetUserById", targetID).Return(nil, model.ErrUserNotFound)
+			},
+			wantErr: model.ErrUserNotFound,
+		},
+		{
+			name:     "blocked",
+			targetID: targetID,
+			setup: func(ur *mocks.UserRepository, fr *mocks.FollowRepository) {
+				ur.On("GetUserById", targetID).Return(&model.User{ID: targetID}, nil)
+				fr.On("Block", mock.MatchedBy(func(r *model.Relation) bool {
+					return r.OwnerID == ownerID && r.TargetID == targetID && !r.CreatedAt.IsZero()
+				})).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := mocks.NewUserRepository(t)
+			followRepo := mocks.NewFollowRepository(t)
+			tt.setup(userRepo, followRepo)
+
+			s := service.NewRelationService(userRepo, followRepo)
+			err := s.Block(context.Background(), ownerID, tt.targetID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRelationService_ListMuted_SkipsDeletedUsers(t *testing.T) {
+	ownerID := uuid.New()
+	gone := &model.Relation{OwnerID: ownerID, TargetID: uuid.New()}
+	kept := &model.Relation{OwnerID: ownerID, TargetID: uuid.New()}
+
+	userRepo := mocks.NewUserRepository(t)
+	userRepo.On("GetUserById", gone.TargetID).Return(nil, model.ErrUserNotFound)
+	userRepo.On("GetUserById", kept.TargetID).Return(&model.User{ID: kept.TargetID}, nil)
+
+	followRepo := mocks.NewFollowRepository(t)
+	followRepo.On("ListMuted", ownerID, int64(7), 2).Return([]*model.Relation{gone, kept}, int64(3), nil)
+
+	s := service.NewRelationService(userRepo, followRepo)
+	page, err := s.ListMuted(context.Background(), ownerID, 7, 2)
+
+	require.NoError(t, err)
+	require.Len(t, page.Users, 1)
+	assert.Equal(t, kept.TargetID, page.Users[0].User.ID)
+	assert.Equal(t, int64(3), page.NextCursor)
+}
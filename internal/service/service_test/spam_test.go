@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/model"
+	"micro-blog/internal/service"
+	"micro-blog/internal/service/mocks"
+	"micro-blog/pkg/pkglogger"
+)
+
+type spamMocks struct {
+	spam     *mocks.SpamRepository
+	users    *mocks.UserRepository
+	detector *mocks.SpamDetector
+}
+
+func newSpamService(t *testing.T, now time.Time) (*service.SpamService, spamMocks) {
+	m := spamMocks{
+		spam:     mocks.NewSpamRepository(t),
+		users:    mocks.NewUserRepository(t),
+		detector: mocks.NewSpamDetector(t),
+	}
+	s := service.NewSpamService(m.spam, m.users)
+	s.AttachDetector(m.detector, 4, time.Hour)
+	s.SetClock(clock.NewFake(now))
+	return s, m
+}
+
+func TestSpamService_CheckPost(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: uuid.New()}
+	post := &model.Post{Text: "buy followers"}
+	ctx := context.WithValue(context.Background(), pkglogger.ClientIPKey, "10.0.0.1")
+
+	tests := []struct {
+		name            string
+		verdict         model.SpamVerdict
+		newAccount      bool
+		quarantined     bool
+		points          int
+		score           int
+		wantQuarantine  bool
+		wantQuarantined bool
+		wantErr         error
+	}{
+		{name: "clean"},
+		{name: "already quarantined", quarantined: true, wantQuarantined: true},
+		{
+			name:    "duplicate text",
+			verdict: model.SpamVerdict{Signals: []model.SpamSignal{model.SpamDuplicateText}},
+			points:  2,
+			score:   2,
+		},
+		{
+			name:    "throttled",
+			verdict: model.SpamVerdict{Throttled: true, Signals: []model.SpamSignal{model.SpamPostRate}},
+			points:  1,
+			score:   1,
+			wantErr: model.ErrRateLimited,
+		},
+		{
+			name:            "new account reaches quarantine score",
+			verdict:         model.SpamVerdict{Signals: []model.SpamSignal{model.SpamDuplicateText}},
+			newAccount:      true,
+			points:          4,
+			score:           4,
+			wantQuarantine:  true,
+			wantQuarantined: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newSpamService(t, now)
+			m.detector.On("CheckPost", user, "10.0.0.1", post.Text, now).Return(tt.verdict)
+			m.spam.On("IsQuarantined", user.ID).Return(tt.quarantined)
+			if tt.points > 0 {
+				m.detector.On("NewAccount", user, now).Return(tt.newAccount)
+				m.spam.On("AddSpamSignals", user.ID, tt.verdict.Signals, tt.points, "10.0.0.1", now, time.Hour).
+					Return(&model.SpamFlag{UserID: user.ID, Score: tt.score}, nil)
+			}
+			if tt.wantQuarantine {
+				m.spam.On("QuarantineUser", user.ID, now).Return(&model.SpamFlag{UserID: user.ID, QuarantinedAt: now}, nil)
+			}
+
+			quarantined, err := s.CheckPost(ctx, user, post)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantQuarantined, quarantined)
+		})
+	}
+}
+
+func TestSpamService_CheckLike_Quarantined(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: uuid.New()}
+	s, m := newSpamService(t, now)
+	m.detector.On("CheckLike", user, "", now).Return(model.SpamVerdict{})
+	m.spam.On("IsQuarantined", user.ID).Return(true)
+
+	assert.ErrorIs(t, s.CheckLike(context.Background(), user), model.ErrQuarantined)
+}
+
+func TestSpamService_WithoutDetector(t *testing.T) {
+	s := service.NewSpamService(mocks.NewSpamRepository(t), mocks.NewUserRepository(t))
+	user := &model.User{ID: uuid.New()}
+
+	assert.NoError(t, s.CheckRegistration(context.Background()))
+	assert.NoError(t, s.CheckLike(context.Background(), user))
+	quarantined, err := s.CheckPost(context.Background(), user, &model.Post{Text: "hello"})
+	assert.NoError(t, err)
+	assert.False(t, quarantined)
+}
+
+func TestSpamService_ListFlaggedAccounts(t *testing.T) {
+	adminID := uuid.New()
+	userID := uuid.New()
+	deletedID := uuid.New()
+
+	t.Run("admins only", func(t *testing.T) {
+		s, m := newSpamService(t, time.Now())
+		m.users.On("GetUserById", adminID).Return(&model.User{ID: adminID, Role: model.RoleModerator}, nil)
+
+		_, err := s.ListFlaggedAccounts(context.Background(), adminID, false, 0, 10)
+		assert.ErrorIs(t, err, model.ErrForbidden)
+	})
+
+	t.Run("deleted users are skipped", func(t *testing.T) {
+		s, m := newSpamService(t, time.Now())
+		m.users.On("GetUserById", adminID).Return(&model.User{ID: adminID, Role: model.RoleAdmin}, nil)
+		m.users.On("GetUserById", userID).Return(&model.User{ID: userID, Name: "bot"}, nil)
+		m.users.On("GetUserById", deletedID).Return(nil, model.ErrUserNotFound)
+		m.spam.On("ListSpamFlags", true, int64(0), 10).
+			Return([]*model.SpamFlag{{UserID: userID, Score: 5}, {UserID: deletedID}}, int64(7), nil)
+
+		page, err := s.ListFlaggedAccounts(context.Background(), adminID, true, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, page.Accounts, 1)
+		assert.Equal(t, "bot", page.Accounts[0].User.Name)
+		assert.Equal(t, 5, page.Accounts[0].Flag.Score)
+		assert.Equal(t, int64(7), page.NextCursor)
+	})
+}
+
+func TestSpamService_ClearSpamFlag(t *testing.T) {
+	adminID := uuid.New()
+	userID := uuid.New()
+	s, m := newSpamService(t, time.Now())
+	m.users.On("GetUserById", adminID).Return(&model.User{ID: adminID, Role: model.RoleAdmin}, nil)
+	m.spam.On("ClearSpamFlag", userID).Return(nil)
+
+	assert.NoError(t, s.ClearSpamFlag(context.Background(), adminID, userID))
+	m.spam.AssertCalled(t, "ClearSpamFlag", userID)
+}
@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"micro-blog/internal/model"
+	"micro-blog/internal/service"
+	"micro-blog/internal/service/mocks"
+)
+
+func TestAccessTokenService_Issue(t *testing.T) {
+	adminID := uuid.New()
+	moderatorID := uuid.New()
+	userID := uuid.New()
+
+	users := mocks.NewUserRepository(t)
+	users.On("GetUserById", adminID).Return(&model.User{ID: adminID, Role: model.RoleAdmin}, nil)
+	users.On("GetUserById", moderatorID).Return(&model.User{ID: moderatorID, Role: model.RoleModerator}, nil)
+	users.On("GetUserById", userID).Return(&model.User{ID: userID}, nil)
+	tokens := mocks.NewAccessTokenRepository(t)
+	s := service.NewAccessTokenService(tokens, users)
+
+	_, err := s.IssueAccessToken(context.Background(), moderatorID, moderatorID)
+	assert.ErrorIs(t, err, model.ErrForbidden, "only admins issue tokens")
+	_, err = s.IssueAccessToken(context.Background(), adminID, userID)
+	assert.ErrorIs(t, err, model.ErrForbidden, "tokens are for staff only")
+
+	var stored *model.AccessToken
+	tokens.On("CreateAccessToken", mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*model.AccessToken)
+	}).Return(nil).Once()
+
+	token, err := s.IssueAccessToken(context.Background(), adminID, moderatorID)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, "mb_"))
+	require.NotNil(t, stored)
+	assert.Equal(t, moderatorID, stored.UserID)
+	assert.NotContains(t, stored.Hash, token, "only the hash is stored")
+
+	tokens.On("GetAccessToken", stored.Hash).Return(stored, nil)
+	owner, err := s.ResolveAccessToken(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, moderatorID, owner)
+
+	tokens.On("GetAccessToken", mock.Anything).Return(nil, model.ErrInvalidToken)
+	_, err = s.ResolveAccessToken(context.Background(), token+"x")
+	assert.ErrorIs(t, err, model.ErrInvalidToken)
+	_, err = s.ResolveAccessToken(context.Background(), moderatorID.String())
+	assert.ErrorIs(t, err, model.ErrInvalidToken)
+}
+
+func TestAccessTokenService_IssueUserToken(t *testing.T) {
+	userID := uuid.New()
+
+	users := mocks.NewUserRepository(t)
+	users.On("GetUserById", userID).Return(&model.User{ID: userID}, nil)
+	users.On("GetUserById", mock.Anything).Return(nil, model.ErrUserNotFound)
+	tokens := mocks.NewAccessTokenRepository(t)
+	s := service.NewAccessTokenService(tokens, users)
+
+	_, err := s.IssueUserToken(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, model.ErrUserNotFound)
+
+	tokens.On("CreateAccessToken", mock.MatchedBy(func(token *model.AccessToken) bool {
+		return token.UserID == userID
+	})).Return(nil).Once()
+	token, err := s.IssueUserToken(context.Background(), userID)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, "mb_"))
+}
+
+func TestAccessTokenService_Revoke(t *testing.T) {
+	adminID := uuid.New()
+	moderatorID := uuid.New()
+
+	users := mocks.NewUserRepository(t)
+	users.On("GetUserById", adminID).Return(&model.User{ID: adminID, Role: model.RoleAdmin}, nil)
+	users.On("GetUserById", moderatorID).Return(&model.User{ID: moderatorID, Role: model.RoleModerator}, nil)
+	tokens := mocks.NewAccessTokenRepository(t)
+	auditor := mocks.NewAuditor(t)
+	s := service.NewAccessTokenService(tokens, users)
+	s.AttachAuditor(auditor)
+
+	assert.ErrorIs(t, s.RevokeAccessTokens(context.Background(), moderatorID, adminID), model.ErrForbidden)
+
+	tokens.On("DeleteAccessTokens", moderatorID).Return(2, nil).Once()
+	auditor.On("Record", mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.Action == model.AuditTokensRevoked && entry.ActorID == adminID && entry.TargetID == moderatorID
+	})).Return(nil).Once()
+	assert.NoError(t, s.RevokeAccessTokens(context.Background(), adminID, moderatorID))
+}
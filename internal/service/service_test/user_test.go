@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"micro-blog/internal/model"
 	"micro-blog/internal/service"
@@ -13,9 +16,12 @@ import (
 )
 
 func TestUserService_Authenticate(t *testing.T) {
+	vovaID := uuid.New()
+
 	tests := []struct {
 		name         string
 		inputUser    *model.User
+		callerID     uuid.UUID
 		mockSetup    func(repo *mocks.UserRepository)
 		expectedUser *model.User
 		expectErr    bool
@@ -23,14 +29,38 @@ func TestUserService_Authenticate(t *testing.T) {
 		{
 			name:      "existing user",
 			inputUser: &model.User{Name: "vova"},
+			callerID:  vovaID,
 			mockSetup: func(repo *mocks.UserRepository) {
 				repo.On("GetUserByName", "vova").
-					Return(&model.User{Name: "vova"}, nil).
+					Return(&model.User{ID: vovaID, Name: "vova"}, nil).
 					Once()
 			},
 			expectedUser: &model.User{Name: "vova"},
 			expectErr:    false,
 		},
+		{
+			name:      "existing user without a token",
+			inputUser: &model.User{Name: "vova"},
+			mockSetup: func(repo *mocks.UserRepository) {
+				repo.On("GetUserByName", "vova").
+					Return(&model.User{ID: vovaID, Name: "vova"}, nil).
+					Once()
+			},
+			expectedUser: nil,
+			expectErr:    true,
+		},
+		{
+			name:      "existing user, another caller",
+			inputUser: &model.User{Name: "vova"},
+			callerID:  uuid.New(),
+			mockSetup: func(repo *mocks.UserRepository) {
+				repo.On("GetUserByName", "vova").
+					Return(&model.User{ID: vovaID, Name: "vova"}, nil).
+					Once()
+			},
+			expectedUser: nil,
+			expectErr:    true,
+		},
 		{
 			name:      "new user",
 			inputUser: &model.User{Name: "new_user"},
@@ -45,6 +75,51 @@ func TestUserService_Authenticate(t *testing.T) {
 			expectedUser: &model.User{Name: "new_user"},
 			expectErr:    false,
 		},
+		{
+			name:      "name is normalized",
+			inputUser: &model.User{Name: " ＶＯＶＡ "},
+			callerID:  vovaID,
+			mockSetup: func(repo *mocks.UserRepository) {
+				repo.On("GetUserByName", "vova").
+					Return(&model.User{ID: vovaID, Name: "vova"}, nil).
+					Once()
+			},
+			expectedUser: &model.User{Name: "vova"},
+			expectErr:    false,
+		},
+		{
+			name:      "suspended user",
+			inputUser: &model.User{Name: "vova"},
+			callerID:  vovaID,
+			mockSetup: func(repo *mocks.UserRepository) {
+				repo.On("GetUserByName", "vova").
+					Return(&model.User{ID: vovaID, Name: "vova", SuspendedUntil: time.Now().Add(time.Hour)}, nil).
+					Once()
+			},
+			expectedUser: nil,
+			expectErr:    true,
+		},
+		{
+			name:         "invalid characters",
+			inputUser:    &model.User{Name: "vo va!"},
+			mockSetup:    func(repo *mocks.UserRepository) {},
+			expectedUser: nil,
+			expectErr:    true,
+		},
+		{
+			name:         "too short",
+			inputUser:    &model.User{Name: "vo"},
+			mockSetup:    func(repo *mocks.UserRepository) {},
+			expectedUser: nil,
+			expectErr:    true,
+		},
+		{
+			name:         "reserved name",
+			inputUser:    &model.User{Name: "Admin"},
+			mockSetup:    func(repo *mocks.UserRepository) {},
+			expectedUser: nil,
+			expectErr:    true,
+		},
 		{
 			name:      "create user fails",
 			inputUser: &model.User{Name: "fail_user"},
@@ -68,7 +143,7 @@ func TestUserService_Authenticate(t *testing.T) {
 
 			svc := service.NewUserService(mockRepo)
 
-			user, err := svc.Authenticate(context.Background(), tt.inputUser)
+			user, err := svc.Authenticate(context.Background(), tt.inputUser, tt.callerID)
 
 			if tt.expectErr {
 				assert.Error(t, err)
@@ -83,26 +158,209 @@ func TestUserService_Authenticate(t *testing.T) {
 	}
 }
 
+func TestUserService_Authenticate_SpamGuard(t *testing.T) {
+	vovaID := uuid.New()
+
+	mockRepo := mocks.NewUserRepository(t)
+	mockRepo.On("GetUserByName", "vova").Return(&model.User{ID: vovaID, Name: "vova"}, nil).Once()
+	mockRepo.On("GetUserByName", "bot").Return(nil, model.ErrUserNotFound).Once()
+	guard := mocks.NewRegistrationGuard(t)
+	guard.On("CheckRegistration", mock.Anything).Return(model.ErrRateLimited).Once()
+
+	svc := service.NewUserService(mockRepo)
+	svc.AttachSpamGuard(guard)
+
+	// Вход существующего пользователя не ограничивается.
+	_, err := svc.Authenticate(context.Background(), &model.User{Name: "vova"}, vovaID)
+	assert.NoError(t, err)
+
+	_, err = svc.Authenticate(context.Background(), &model.User{Name: "bot"}, uuid.Nil)
+	assert.ErrorIs(t, err, model.ErrRateLimited)
+}
+
+func TestUserService_Authenticate_Audit(t *testing.T) {
+	userID := uuid.New()
+	bannedID := uuid.New()
+
+	mockRepo := mocks.NewUserRepository(t)
+	mockRepo.On("GetUserByName", "vova").Return(&model.User{ID: userID, Name: "vova"}, nil).Once()
+	mockRepo.On("GetUserByName", "banned").
+		Return(&model.User{ID: bannedID, Name: "banned", SuspendedUntil: time.Now().Add(time.Hour)}, nil).Once()
+
+	auditor := mocks.NewAuditor(t)
+	auditor.On("Record", mock.Anything, &model.AuditEntry{
+		Action:     model.AuditLogin,
+		ActorID:    userID,
+		TargetType: model.AuditTargetUser,
+		TargetID:   userID,
+	}).Return(nil).Once()
+	auditor.On("Record", mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.Action == model.AuditLoginFailed && entry.ActorID == uuid.Nil &&
+			entry.TargetID == bannedID && entry.Details["name"] == "banned"
+	})).Return(nil).Once()
+
+	svc := service.NewUserService(mockRepo)
+	svc.AttachAuditor(auditor)
+
+	_, err := svc.Authenticate(context.Background(), &model.User{Name: "vova"}, userID)
+	assert.NoError(t, err)
+	_, err = svc.Authenticate(context.Background(), &model.User{Name: "banned"}, bannedID)
+	assert.ErrorIs(t, err, model.ErrSuspended)
+}
+
+func TestUserService_RenameUser(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		newName   string
+		mockSetup func(repo *mocks.UserRepository)
+		wantName  string
+		wantErr   error
+	}{
+		{
+			name:    "renamed with hold period",
+			newName: "New_Name",
+			mockSetup: func(repo *mocks.UserRepository) {
+				repo.On("RenameUser", userID, "new_name", mock.MatchedBy(func(until time.Time) bool {
+					return until.After(time.Now().Add(service.UsernameHoldPeriod - time.Minute))
+				})).Return(&model.User{ID: userID, Name: "new_name"}, nil).Once()
+			},
+			wantName: "new_name",
+		},
+		{
+			name:      "reserved name",
+			newName:   "root",
+			mockSetup: func(repo *mocks.UserRepository) {},
+			wantErr:   model.ErrReservedUsername,
+		},
+		{
+			name:    "name taken",
+			newName: "taken",
+			mockSetup: func(repo *mocks.UserRepository) {
+				repo.On("RenameUser", userID, "taken", mock.Anything).Return(nil, model.ErrUsernameTaken).Once()
+			},
+			wantErr: model.ErrUsernameTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewUserRepository(t)
+			tt.mockSetup(mockRepo)
+
+			svc := service.NewUserService(mockRepo)
+			user, err := svc.RenameUser(context.Background(), userID, tt.newName)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, user)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantName, user.Name)
+		})
+	}
+}
+
+func TestUserService_ChangeRole(t *testing.T) {
+	adminID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		actorID   uuid.UUID
+		role      model.Role
+		mockSetup func(repo *mocks.UserRepository)
+		wantErr   error
+	}{
+		{
+			name:      "unknown role",
+			actorID:   adminID,
+			role:      "owner",
+			mockSetup: func(repo *mocks.UserRepository) {},
+			wantErr:   model.ErrInvalidRole,
+		},
+		{
+			name:    "actor is not admin",
+			actorID: userID,
+			role:    model.RoleModerator,
+			mockSetup: func(repo *mocks.UserRepository) {
+				repo.On("GetUserById", userID).Return(&model.User{ID: userID, Role: model.RoleModerator}, nil).Once()
+			},
+			wantErr: model.ErrForbidden,
+		},
+		{
+			name:    "promoted",
+			actorID: adminID,
+			role:    model.RoleModerator,
+			mockSetup: func(repo *mocks.UserRepository) {
+				repo.On("GetUserById", adminID).Return(&model.User{ID: adminID, Role: model.RoleAdmin}, nil).Once()
+				repo.On("SetRole", userID, model.RoleModerator).
+					Return(&model.User{ID: userID, Role: model.RoleModerator}, nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewUserRepository(t)
+			tt.mockSetup(mockRepo)
+
+			svc := service.NewUserService(mockRepo)
+			user, err := svc.ChangeRole(context.Background(), tt.actorID, userID, tt.role)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, user)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.role, user.Role)
+		})
+	}
+}
+
+func TestUserService_BootstrapAdmin(t *testing.T) {
+	userID := uuid.New()
+
+	mockRepo := mocks.NewUserRepository(t)
+	mockRepo.On("GetUserByName", "operator").Return(nil, model.ErrUserNotFound).Once()
+	mockRepo.On("CreateUser", &model.User{Name: "operator"}).Return(&model.User{ID: userID, Name: "operator"}, nil).Once()
+	mockRepo.On("SetRole", userID, model.RoleAdmin).
+		Return(&model.User{ID: userID, Name: "operator", Role: model.RoleAdmin}, nil).Once()
+
+	svc := service.NewUserService(mockRepo)
+	admin, err := svc.BootstrapAdmin(context.Background(), "Operator")
+	assert.NoError(t, err)
+	assert.Equal(t, model.RoleAdmin, admin.Role)
+
+	_, err = svc.BootstrapAdmin(context.Background(), "root")
+	assert.ErrorIs(t, err, model.ErrReservedUsername)
+}
+
 func BenchmarkUserService_Authenticate(b *testing.B) {
+	userID := uuid.New()
 	mockRepo := new(mocks.UserRepository)
 
 	mockRepo.On("GetUserByName", "bench_user").
-		Return(&model.User{Name: "bench_user"}, nil).
+		Return(&model.User{ID: userID, Name: "bench_user"}, nil).
 		Maybe()
 
 	svc := service.NewUserService(mockRepo)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = svc.Authenticate(context.Background(), &model.User{Name: "bench_user"})
+		_, _ = svc.Authenticate(context.Background(), &model.User{Name: "bench_user"}, userID)
 	}
 }
 
 func TestUserService_Authenticate_Race(t *testing.T) {
+	userID := uuid.New()
 	mockRepo := new(mocks.UserRepository)
 
 	mockRepo.On("GetUserByName", "race_user").
-		Return(&model.User{Name: "race_user"}, nil).
+		Return(&model.User{ID: userID, Name: "race_user"}, nil).
 		Maybe()
 
 	svc := service.NewUserService(mockRepo)
@@ -112,7 +370,7 @@ func TestUserService_Authenticate_Race(t *testing.T) {
 
 	for i := 0; i < goroutines; i++ {
 		go func() {
-			_, err := svc.Authenticate(context.Background(), &model.User{Name: "race_user"})
+			_, err := svc.Authenticate(context.Background(), &model.User{Name: "race_user"}, userID)
 			errCh <- err
 		}()
 	}
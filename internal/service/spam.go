@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/model"
+	"micro-blog/pkg/pkglogger"
+)
+
+// spamQuarantineRule - правило, по которому задерживаются посты аккаунта
+// в карантине.
+const spamQuarantineRule = "spam_quarantine"
+
+// spamSignalPoints - штрафные очки за срабатывание признака.
+var spamSignalPoints = map[model.SpamSignal]int{
+	model.SpamPostRate:      1,
+	model.SpamLikeVelocity:  1,
+	model.SpamDuplicateText: 2,
+}
+
+type SpamRepository interface {
+	AddSpamSignals(userID uuid.UUID, signals []model.SpamSignal, points int, ip string, now time.Time, decay time.Duration) (*model.SpamFlag, error)
+	QuarantineUser(userID uuid.UUID, now time.Time) (*model.SpamFlag, error)
+	IsQuarantined(userID uuid.UUID) bool
+	ListSpamFlags(quarantinedOnly bool, before int64, limit int) ([]*model.SpamFlag, int64, error)
+	ClearSpamFlag(userID uuid.UUID) error
+}
+
+// SpamDetector оценивает отдельные действия по признакам спама.
+type SpamDetector interface {
+	CheckRegistration(ip string, now time.Time) model.SpamVerdict
+	CheckPost(user *model.User, ip, text string, now time.Time) model.SpamVerdict
+	CheckLike(user *model.User, ip string, now time.Time) model.SpamVerdict
+	NewAccount(user *model.User, now time.Time) bool
+}
+
+// SpamService защищает регистрацию, публикацию и реакции от ботов.
+// Действия сверх лимита частоты отклоняются, а за каждый признак спама
+// аккаунт получает штрафные очки. Набравший quarantineScore аккаунт
+// попадает в карантин: его посты задерживаются до проверки модератором,
+// реакции отклоняются. Без новых признаков счет постепенно забывается.
+// Без детектора проверки выключены.
+type SpamService struct {
+	spamRepo        SpamRepository
+	userRepo        UserRepository
+	detector        SpamDetector
+	auditor         Auditor
+	quarantineScore int
+	scoreDecay      time.Duration
+	clock           clock.Clock
+}
+
+func NewSpamService(sr SpamRepository, ur UserRepository) *SpamService {
+	return &SpamService{
+		spamRepo: sr,
+		userRepo: ur,
+		clock:    clock.Real{},
+	}
+}
+
+// AttachDetector включает проверки. Нулевой quarantineScore отключает
+// карантин, нулевой scoreDecay - забывание очков.
+func (s *SpamService) AttachDetector(detector SpamDetector, quarantineScore int, scoreDecay time.Duration) {
+	s.detector = detector
+	s.quarantineScore = quarantineScore
+	s.scoreDecay = scoreDecay
+}
+
+// AttachAuditor включает запись снятия подозрений в журнал аудита.
+func (s *SpamService) AttachAuditor(auditor Auditor) {
+	s.auditor = auditor
+}
+
+// SetClock подменяет источник времени сервиса.
+func (s *SpamService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// CheckRegistration ограничивает число новых аккаунтов с одного IP.
+func (s *SpamService) CheckRegistration(ctx context.Context) error {
+	if s.detector == nil {
+		return nil
+	}
+
+	if s.detector.CheckRegistration(clientIP(ctx), s.clock.Now()).Throttled {
+		return model.ErrRateLimited
+	}
+	return nil
+}
+
+// CheckPost оценивает новый пост автора и сообщает, что автор в карантине.
+func (s *SpamService) CheckPost(ctx context.Context, author *model.User, post *model.Post) (bool, error) {
+	if s.detector == nil {
+		return false, nil
+	}
+
+	now := s.clock.Now()
+	ip := clientIP(ctx)
+	return s.record(author, ip, s.detector.CheckPost(author, ip, post.Text, now), now)
+}
+
+// CheckLike оценивает реакцию пользователя. Реакции аккаунта в карантине
+// отклоняются.
+func (s *SpamService) CheckLike(ctx context.Context, user *model.User) error {
+	if s.detector == nil {
+		return nil
+	}
+
+	now := s.clock.Now()
+	ip := clientIP(ctx)
+	quarantined, err := s.record(user, ip, s.detector.CheckLike(user, ip, now), now)
+	if err != nil {
+		return err
+	}
+	if quarantined {
+		return model.ErrQuarantined
+	}
+	return nil
+}
+
+// ListFlaggedAccounts возвращает аккаунты с подозрениями на спам, начиная
+// с недавно замеченных. Доступно только администраторам.
+func (s *SpamService) ListFlaggedAccounts(
+	ctx context.Context,
+	actorID uuid.UUID,
+	quarantinedOnly bool,
+	cursor int64,
+	limit int,
+) (*model.FlaggedAccountPage, error) {
+	if _, err := actorWithRole(s.userRepo, actorID, model.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	flags, next, err := s.spamRepo.ListSpamFlags(quarantinedOnly, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*model.FlaggedAccount, 0, len(flags))
+	for _, flag := range flags {
+		user, err := s.userRepo.GetUserById(flag.UserID)
+		if err != nil {
+			continue
+		}
+		accounts = append(accounts, &model.FlaggedAccount{User: user, Flag: flag})
+	}
+
+	return &model.FlaggedAccountPage{
+		Accounts:   accounts,
+		NextCursor: next,
+	}, nil
+}
+
+// ClearSpamFlag снимает с аккаунта подозрения и карантин. Задержанные
+// посты остаются в очереди модерации.
+func (s *SpamService) ClearSpamFlag(ctx context.Context, actorID, userID uuid.UUID) error {
+	if _, err := actorWithRole(s.userRepo, actorID, model.RoleAdmin); err != nil {
+		return err
+	}
+
+	if err := s.spamRepo.ClearSpamFlag(userID); err != nil {
+		return err
+	}
+
+	return audit(ctx, s.auditor, &model.AuditEntry{
+		Action:     model.AuditSpamFlagCleared,
+		ActorID:    actorID,
+		TargetType: model.AuditTargetUser,
+		TargetID:   userID,
+	})
+}
+
+// record начисляет очки за сработавшие признаки, при необходимости
+// помещает аккаунт в карантин и возвращает ErrRateLimited, если действие
+// нужно отклонить.
+func (s *SpamService) record(user *model.User, ip string, verdict model.SpamVerdict, now time.Time) (bool, error) {
+	quarantined := s.spamRepo.IsQuarantined(user.ID)
+
+	if len(verdict.Signals) > 0 {
+		points := 0
+		for _, signal := range verdict.Signals {
+			points += spamSignalPoints[signal]
+		}
+		if s.detector.NewAccount(user, now) {
+			points *= 2
+		}
+
+		flag, err := s.spamRepo.AddSpamSignals(user.ID, verdict.Signals, points, ip, now, s.scoreDecay)
+		if err != nil {
+			return false, err
+		}
+
+		if !quarantined && s.quarantineScore > 0 && flag.Score >= s.quarantineScore {
+			if _, err = s.spamRepo.QuarantineUser(user.ID, now); err != nil {
+				return false, err
+			}
+			quarantined = true
+		}
+	}
+
+	if verdict.Throttled {
+		return quarantined, model.ErrRateLimited
+	}
+	return quarantined, nil
+}
+
+// clientIP возвращает адрес клиента, который middleware.ClientIP кладет
+// в контекст запроса; пустая строка, если адрес неизвестен.
+func clientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(pkglogger.ClientIPKey).(string)
+	return ip
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/clock"
+	"micro-blog/internal/model"
+)
+
+// accessTokenPrefix отличает ключи доступа от других секретов в логах и
+// конфигах.
+const accessTokenPrefix = "mb_"
+
+type AccessTokenRepository interface {
+	CreateAccessToken(token *model.AccessToken) error
+	GetAccessToken(hash string) (*model.AccessToken, error)
+	DeleteAccessTokens(userID uuid.UUID) (int, error)
+}
+
+// AccessTokenService выдает и проверяет ключи доступа. Все ручки,
+// действующие от имени пользователя, доверяют только предъявленному
+// ключу: заголовок X-User-ID ничем не подтвержден.
+type AccessTokenService struct {
+	tokenRepo AccessTokenRepository
+	userRepo  UserRepository
+	auditor   Auditor
+	clock     clock.Clock
+}
+
+func NewAccessTokenService(tr AccessTokenRepository, ur UserRepository) *AccessTokenService {
+	return &AccessTokenService{
+		tokenRepo: tr,
+		userRepo:  ur,
+		clock:     clock.Real{},
+	}
+}
+
+// SetClock подменяет источник времени сервиса.
+func (s *AccessTokenService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// AttachAuditor включает запись выдачи и отзыва ключей в журнал аудита.
+func (s *AccessTokenService) AttachAuditor(auditor Auditor) {
+	s.auditor = auditor
+}
+
+// IssueAccessToken выдает модератору или администратору userID новый
+// ключ. Выдавать ключи может только администратор; ключ возвращается
+// один раз и нигде не хранится в открытом виде.
+func (s *AccessTokenService) IssueAccessToken(ctx context.Context, actorID, userID uuid.UUID) (string, error) {
+	if _, err := actorWithRole(s.userRepo, actorID, model.RoleAdmin); err != nil {
+		return "", err
+	}
+
+	user, err := s.userRepo.GetUserById(userID)
+	if err != nil {
+		return "", err
+	}
+	if !user.Role.AtLeast(model.RoleModerator) {
+		return "", model.ErrForbidden
+	}
+
+	return s.issue(ctx, actorID, userID)
+}
+
+// IssueUserToken выдает пользователю ключ при регистрации или входе.
+// Ключ возвращается один раз и нигде не хранится в открытом виде; в
+// журнал аудита эти события уже попадают как вход и регистрация.
+func (s *AccessTokenService) IssueUserToken(ctx context.Context, userID uuid.UUID) (string, error) {
+	if _, err := s.userRepo.GetUserById(userID); err != nil {
+		return "", err
+	}
+	return s.create(userID)
+}
+
+// BootstrapAccessToken выдает ключ администратору, назначенному при
+// запуске: без него первый администратор не попадет в /admin.
+func (s *AccessTokenService) BootstrapAccessToken(ctx context.Context, adminID uuid.UUID) (string, error) {
+	return s.issue(ctx, uuid.Nil, adminID)
+}
+
+// RevokeAccessTokens отзывает все ключи пользователя userID.
+func (s *AccessTokenService) RevokeAccessTokens(ctx context.Context, actorID, userID uuid.UUID) error {
+	if _, err := actorWithRole(s.userRepo, actorID, model.RoleAdmin); err != nil {
+		return err
+	}
+	if _, err := s.userRepo.GetUserById(userID); err != nil {
+		return err
+	}
+
+	if _, err := s.tokenRepo.DeleteAccessTokens(userID); err != nil {
+		return err
+	}
+
+	return audit(ctx, s.auditor, &model.AuditEntry{
+		Action:     model.AuditTokensRevoked,
+		ActorID:    actorID,
+		TargetType: model.AuditTargetUser,
+		TargetID:   userID,
+	})
+}
+
+// ResolveAccessToken возвращает владельца ключа.
+func (s *AccessTokenService) ResolveAccessToken(ctx context.Context, token string) (uuid.UUID, error) {
+	if !strings.HasPrefix(token, accessTokenPrefix) {
+		return uuid.Nil, model.ErrInvalidToken
+	}
+
+	stored, err := s.tokenRepo.GetAccessToken(hashAccessToken(token))
+	if err != nil {
+		return uuid.Nil, model.ErrInvalidToken
+	}
+	return stored.UserID, nil
+}
+
+func (s *AccessTokenService) issue(ctx context.Context, actorID, userID uuid.UUID) (string, error) {
+	token, err := s.create(userID)
+	if err != nil {
+		return "", err
+	}
+
+	err = audit(ctx, s.auditor, &model.AuditEntry{
+		Action:     model.AuditTokenIssued,
+		ActorID:    actorID,
+		TargetType: model.AuditTargetUser,
+		TargetID:   userID,
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// create выдает пользователю новый ключ, сохраняя только его хеш.
+func (s *AccessTokenService) create(userID uuid.UUID) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	token := accessTokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	err := s.tokenRepo.CreateAccessToken(&model.AccessToken{
+		Hash:      hashAccessToken(token),
+		UserID:    userID,
+		CreatedAt: s.clock.Now(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func hashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
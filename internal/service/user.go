@@ -2,34 +2,234 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"micro-blog/internal/clock"
 	"micro-blog/internal/model"
 )
 
+// UsernameHoldPeriod - сколько старое имя после переименования остается
+// закрепленным за пользователем и перенаправляет на новое.
+const UsernameHoldPeriod = 30 * 24 * time.Hour
+
 type UserRepository interface {
 	CreateUser(user *model.User) (*model.User, error)
 	GetUserByName(name string) (*model.User, error)
 	GetUserById(id uuid.UUID) (*model.User, error)
+	GetUserByAlias(name string) (*model.User, error)
+	UpdateUser(id uuid.UUID, update *model.ProfileUpdate) (*model.User, error)
+	RenameUser(id uuid.UUID, newName string, holdUntil time.Time) (*model.User, error)
+	SuspendUser(id uuid.UUID, reason string, until time.Time) (*model.User, error)
+	SetRole(id uuid.UUID, role model.Role) (*model.User, error)
+	ShadowBanUser(id uuid.UUID, reason string, until time.Time) (*model.User, error)
+	ListShadowBanned(now time.Time) ([]uuid.UUID, error)
+	LiftExpiredSanctions(now time.Time) (int, error)
+}
+
+// RegistrationGuard ограничивает регистрацию новых аккаунтов.
+type RegistrationGuard interface {
+	CheckRegistration(ctx context.Context) error
 }
 
 type UserService struct {
-	repo UserRepository
+	repo    UserRepository
+	guard   RegistrationGuard
+	auditor Auditor
+	clock   clock.Clock
 }
 
 func NewUserService(repo UserRepository) *UserService {
-	return &UserService{repo: repo}
+	return &UserService{repo: repo, clock: clock.Real{}}
+}
+
+// SetClock подменяет источник времени сервиса.
+func (s *UserService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// AttachSpamGuard включает ограничение частоты регистраций.
+func (s *UserService) AttachSpamGuard(guard RegistrationGuard) {
+	s.guard = guard
+}
+
+// AttachAuditor включает запись входов и смены ролей в журнал аудита.
+func (s *UserService) AttachAuditor(auditor Auditor) {
+	s.auditor = auditor
 }
 
-func (s *UserService) Authenticate(ctx context.Context, user *model.User) (*model.User, error) {
+// Authenticate регистрирует нового пользователя или входит под
+// существующим. Паролей нет, поэтому войти под занятым именем может
+// только сам владелец, уже подтвердивший личность ключом доступа
+// (callerID), - например, чтобы получить новый ключ. Заблокированный
+// модератором пользователь войти не может.
+func (s *UserService) Authenticate(ctx context.Context, user *model.User, callerID uuid.UUID) (*model.User, error) {
+	name, err := model.NormalizeUsername(user.Name)
+	if err != nil {
+		return nil, err
+	}
+	user.Name = name
+
 	if u, err := s.repo.GetUserByName(user.Name); err == nil {
-		return u, nil
+		if callerID == uuid.Nil || u.ID != callerID {
+			return nil, s.loginFailed(ctx, u.ID, user.Name, model.ErrUsernameTaken)
+		}
+		if err = checkSuspension(u, s.clock.Now()); err != nil {
+			return nil, s.loginFailed(ctx, u.ID, user.Name, err)
+		}
+		return s.loggedIn(ctx, u, model.AuditLogin)
+	}
+
+	return s.register(ctx, user)
+}
+
+// register создает пользователя с уже нормализованным именем.
+func (s *UserService) register(ctx context.Context, user *model.User) (*model.User, error) {
+	if s.guard != nil {
+		if err := s.guard.CheckRegistration(ctx); err != nil {
+			return nil, s.loginFailed(ctx, uuid.Nil, user.Name, err)
+		}
 	}
 
-	var err error
-	if user, err = s.repo.CreateUser(user); err != nil {
+	created, err := s.repo.CreateUser(user)
+	if err != nil {
 		return nil, err
 	}
 
+	return s.loggedIn(ctx, created, model.AuditUserRegistered)
+}
+
+// loggedIn записывает в журнал вход или регистрацию пользователя.
+func (s *UserService) loggedIn(ctx context.Context, user *model.User, action model.AuditAction) (*model.User, error) {
+	err := audit(ctx, s.auditor, &model.AuditEntry{
+		Action:     action,
+		ActorID:    user.ID,
+		TargetType: model.AuditTargetUser,
+		TargetID:   user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
 	return user, nil
 }
+
+// loginFailed записывает в журнал отказ во входе и возвращает причину
+// отказа. userID нулевой, если отказано в регистрации нового аккаунта.
+func (s *UserService) loginFailed(ctx context.Context, userID uuid.UUID, name string, cause error) error {
+	err := audit(ctx, s.auditor, &model.AuditEntry{
+		Action:     model.AuditLoginFailed,
+		TargetType: model.AuditTargetUser,
+		TargetID:   userID,
+		Details:    map[string]string{"name": name, "error": cause.Error()},
+	})
+	if err != nil {
+		return err
+	}
+	return cause
+}
+
+func (s *UserService) RenameUser(ctx context.Context, userID uuid.UUID, newName string) (*model.User, error) {
+	name, err := model.NormalizeUsername(newName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.RenameUser(userID, name, s.clock.Now().Add(UsernameHoldPeriod))
+}
+
+// UserRole возвращает роль пользователя для проверки доступа к ручкам.
+func (s *UserService) UserRole(ctx context.Context, userID uuid.UUID) (model.Role, error) {
+	user, err := s.repo.GetUserById(userID)
+	if err != nil {
+		return "", err
+	}
+	if user.Role == "" {
+		return model.RoleUser, nil
+	}
+	return user.Role, nil
+}
+
+// ChangeRole назначает пользователю роль. Менять роли может только
+// администратор.
+func (s *UserService) ChangeRole(ctx context.Context, actorID, userID uuid.UUID, role model.Role) (*model.User, error) {
+	if !role.Valid() {
+		return nil, fmt.Errorf("%w: %q", model.ErrInvalidRole, role)
+	}
+
+	actorRole, err := s.UserRole(ctx, actorID)
+	if err != nil || !actorRole.AtLeast(model.RoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	return s.setRole(ctx, actorID, userID, role)
+}
+
+// BootstrapAdmin делает пользователя с именем name администратором,
+// создавая его при необходимости. Так при запуске появляется первый
+// администратор, который дальше раздает роли через API.
+func (s *UserService) BootstrapAdmin(ctx context.Context, name string) (*model.User, error) {
+	normalized, err := model.NormalizeUsername(name)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserByName(normalized)
+	if err != nil {
+		user, err = s.register(ctx, &model.User{Name: normalized})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.setRole(ctx, uuid.Nil, user.ID, model.RoleAdmin)
+}
+
+// setRole назначает роль и записывает это в журнал; нулевой actorID -
+// назначение при запуске.
+func (s *UserService) setRole(ctx context.Context, actorID, userID uuid.UUID, role model.Role) (*model.User, error) {
+	user, err := s.repo.SetRole(userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = audit(ctx, s.auditor, &model.AuditEntry{
+		Action:     model.AuditRoleChanged,
+		ActorID:    actorID,
+		TargetType: model.AuditTargetUser,
+		TargetID:   userID,
+		Details:    map[string]string{"role": string(role)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// actorWithRole возвращает пользователя actorID, если его роль не ниже min.
+func actorWithRole(repo UserRepository, actorID uuid.UUID, min model.Role) (*model.User, error) {
+	actor, err := repo.GetUserById(actorID)
+	if err != nil || !actor.Role.AtLeast(min) {
+		return nil, model.ErrForbidden
+	}
+	return actor, nil
+}
+
+// activeUser возвращает пользователя, если его аккаунт не заблокирован.
+// Через него проходят все действия, которые заблокированному запрещены.
+func activeUser(repo UserRepository, userID uuid.UUID, now time.Time) (*model.User, error) {
+	user, err := repo.GetUserById(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkSuspension(user, now); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func checkSuspension(user *model.User, now time.Time) error {
+	if !user.Suspended(now) {
+		return nil
+	}
+	return fmt.Errorf("%w until %s: %s", model.ErrSuspended, user.SuspendedUntil.Format(time.RFC3339), user.SuspensionReason)
+}
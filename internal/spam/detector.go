@@ -0,0 +1,209 @@
+package spam
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"micro-blog/internal/model"
+)
+
+// Detector оценивает действия пользователей по признакам спама. Все
+// счетчики хранятся в памяти и считаются в скользящем окне
+// limits.Window отдельно по пользователю и по IP.
+type Detector struct {
+	limits model.SpamLimits
+
+	mu     sync.Mutex
+	events map[eventKey][]time.Time
+	recent map[string][]map[uint64]struct{}
+	// checks считает учтенные события, чтобы периодически удалять
+	// счетчики неактивных пользователей и адресов.
+	checks int
+}
+
+// pruneEvery - через сколько учтенных событий удалять устаревшие счетчики.
+const pruneEvery = 1024
+
+type eventKind int
+
+const (
+	eventPost eventKind = iota
+	eventLike
+	eventRegistration
+	eventDuplicate
+)
+
+type eventKey struct {
+	kind eventKind
+	// subject - ID пользователя или "ip:" и адрес, чтобы ключи
+	// пользователей и адресов не пересекались.
+	subject string
+}
+
+func NewDetector(limits model.SpamLimits) *Detector {
+	if limits.Window <= 0 {
+		limits.Window = time.Minute
+	}
+
+	return &Detector{
+		limits: limits,
+		events: make(map[eventKey][]time.Time),
+		recent: make(map[string][]map[uint64]struct{}),
+	}
+}
+
+// CheckRegistration учитывает создание аккаунта с адреса ip.
+func (d *Detector) CheckRegistration(ip string, now time.Time) model.SpamVerdict {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var verdict model.SpamVerdict
+	if ip != "" && d.exceeds(eventKey{eventRegistration, ipSubject(ip)}, d.limits.MaxRegistrationsPerIP, now) {
+		verdict.Throttled = true
+		verdict.Signals = append(verdict.Signals, model.SpamRegistrationRate)
+	}
+	return verdict
+}
+
+// CheckPost учитывает пост пользователя и сравнивает его текст с
+// недавними текстами этого пользователя и его IP. Повтор своего текста -
+// признак спама аккаунта. Повтор чужого текста с того же IP учитывается
+// за адресом: с общего адреса (NAT, офис) могут писать разные люди, поэтому
+// аккаунт за него очков не получает, а сверх MaxDuplicatesPerIP за окно
+// посты с адреса отклоняются.
+func (d *Detector) CheckPost(user *model.User, ip, text string, now time.Time) model.SpamVerdict {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	verdict := d.checkRate(eventPost, user, ip, d.limits.MaxPosts, d.limits.MaxPostsPerIP, now, model.SpamPostRate)
+	if verdict.Throttled {
+		return verdict
+	}
+
+	shingles := Shingles(text)
+	if len(shingles) == 0 {
+		return verdict
+	}
+
+	userSubject := user.ID.String()
+	if d.repeats(userSubject, shingles) {
+		verdict.Signals = append(verdict.Signals, model.SpamDuplicateText)
+	} else if ip != "" && d.repeats(ipSubject(ip), shingles) {
+		if d.exceeds(eventKey{eventDuplicate, ipSubject(ip)}, d.limits.MaxDuplicatesPerIP, now) {
+			verdict.Throttled = true
+		}
+	}
+
+	d.remember(userSubject, shingles)
+	if ip != "" {
+		d.remember(ipSubject(ip), shingles)
+	}
+	return verdict
+}
+
+// repeats сообщает, что текст похож на один из недавних текстов subject.
+func (d *Detector) repeats(subject string, shingles map[uint64]struct{}) bool {
+	for _, previous := range d.recent[subject] {
+		if Similarity(shingles, previous) >= d.limits.DuplicateSimilarity {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckLike учитывает реакцию пользователя.
+func (d *Detector) CheckLike(user *model.User, ip string, now time.Time) model.SpamVerdict {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.checkRate(eventLike, user, ip, d.limits.MaxLikes, d.limits.MaxLikesPerIP, now, model.SpamLikeVelocity)
+}
+
+// NewAccount сообщает, что аккаунт моложе limits.NewAccountAge.
+// Возраст аккаунтов без времени регистрации неизвестен, они не считаются
+// новыми; нулевой NewAccountAge отключает проверку.
+func (d *Detector) NewAccount(user *model.User, now time.Time) bool {
+	if d.limits.NewAccountAge <= 0 || user.CreatedAt.IsZero() {
+		return false
+	}
+	return now.Sub(user.CreatedAt) < d.limits.NewAccountAge
+}
+
+func (d *Detector) checkRate(
+	kind eventKind,
+	user *model.User,
+	ip string,
+	maxPerUser int,
+	maxPerIP int,
+	now time.Time,
+	signal model.SpamSignal,
+) model.SpamVerdict {
+	if maxPerUser > 0 && d.NewAccount(user, now) {
+		maxPerUser = max(maxPerUser/2, 1)
+	}
+
+	throttled := d.exceeds(eventKey{kind, user.ID.String()}, maxPerUser, now)
+	if ip != "" && d.exceeds(eventKey{kind, ipSubject(ip)}, maxPerIP, now) {
+		throttled = true
+	}
+
+	if !throttled {
+		return model.SpamVerdict{}
+	}
+	return model.SpamVerdict{Throttled: true, Signals: []model.SpamSignal{signal}}
+}
+
+// exceeds учитывает событие и сообщает, что их в окне стало больше limit.
+// Отклоненные попытки тоже учитываются, так что непрерывный поток
+// запросов остается ограниченным. Нулевой limit отключает проверку.
+func (d *Detector) exceeds(key eventKey, limit int, now time.Time) bool {
+	if limit <= 0 {
+		return false
+	}
+
+	d.checks++
+	if d.checks%pruneEvery == 0 {
+		d.prune(now)
+	}
+
+	cutoff := now.Add(-d.limits.Window)
+	events := d.events[key]
+	fresh := 0
+	for fresh < len(events) && !events[fresh].After(cutoff) {
+		fresh++
+	}
+	events = append(slices.Delete(events, 0, fresh), now)
+	d.events[key] = events
+
+	return len(events) > limit
+}
+
+// prune удаляет счетчики, в которых не осталось событий за окно, и
+// историю текстов тех, кто не публиковал посты в окне.
+func (d *Detector) prune(now time.Time) {
+	cutoff := now.Add(-d.limits.Window)
+	for key, events := range d.events {
+		if len(events) == 0 || !events[len(events)-1].After(cutoff) {
+			delete(d.events, key)
+		}
+	}
+	for subject := range d.recent {
+		if _, ok := d.events[eventKey{eventPost, subject}]; !ok {
+			delete(d.recent, subject)
+		}
+	}
+}
+
+func (d *Detector) remember(subject string, shingles map[uint64]struct{}) {
+	history := max(d.limits.DuplicateHistory, 1)
+	recent := append(d.recent[subject], shingles)
+	if len(recent) > history {
+		recent = recent[len(recent)-history:]
+	}
+	d.recent[subject] = recent
+}
+
+func ipSubject(ip string) string {
+	return "ip:" + ip
+}
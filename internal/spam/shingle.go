@@ -0,0 +1,48 @@
+package spam
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// shingleSize - сколько слов подряд образуют шингл.
+const shingleSize = 3
+
+// Shingles разбивает текст на перекрывающиеся последовательности из
+// shingleSize слов и возвращает множество их хешей. Регистр, знаки
+// препинания и число пробелов не учитываются. Короткие тексты вроде
+// "спасибо" повторяются и у людей, поэтому текст короче шингла
+// шинглов не дает.
+func Shingles(text string) map[uint64]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if len(words) < shingleSize {
+		return nil
+	}
+
+	set := make(map[uint64]struct{})
+	for start := 0; start+shingleSize <= len(words); start++ {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(strings.Join(words[start:start+shingleSize], " ")))
+		set[h.Sum64()] = struct{}{}
+	}
+	return set
+}
+
+// Similarity - коэффициент Жаккара двух множеств шинглов: доля общих
+// шинглов среди всех. Пустые множества ни на что не похожи.
+func Similarity(a, b map[uint64]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	common := 0
+	for shingle := range a {
+		if _, ok := b[shingle]; ok {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
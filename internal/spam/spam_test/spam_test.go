@@ -0,0 +1,137 @@
+package spam_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"micro-blog/internal/model"
+	"micro-blog/internal/spam"
+)
+
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func oldUser() *model.User {
+	return &model.User{ID: uuid.New(), CreatedAt: testNow.Add(-30 * 24 * time.Hour)}
+}
+
+func TestSimilarity(t *testing.T) {
+	text := "buy cheap followers now at our totally legit shop today"
+
+	tests := []struct {
+		name string
+		a, b string
+		min  float64
+		max  float64
+	}{
+		{name: "same text", a: text, b: text, min: 1, max: 1},
+		{name: "case and punctuation", a: text, b: "Buy CHEAP followers, now at our totally legit shop today!", min: 1, max: 1},
+		{name: "one word changed", a: text, b: "buy cheap followers now at our totally legit store today", min: 0.5, max: 0.9},
+		{name: "unrelated", a: text, b: "the weather in the mountains was lovely this weekend", min: 0, max: 0},
+		{name: "too short", a: "hi", b: "hi", min: 0, max: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := spam.Similarity(spam.Shingles(tt.a), spam.Shingles(tt.b))
+			assert.GreaterOrEqual(t, got, tt.min)
+			assert.LessOrEqual(t, got, tt.max)
+		})
+	}
+}
+
+func TestDetector_PostRate(t *testing.T) {
+	detector := spam.NewDetector(model.SpamLimits{Window: time.Minute, MaxPosts: 2, MaxPostsPerIP: 3})
+	alice, bob := oldUser(), oldUser()
+
+	assert.False(t, detector.CheckPost(alice, "10.0.0.1", "first", testNow).Throttled)
+	assert.False(t, detector.CheckPost(alice, "10.0.0.1", "second", testNow).Throttled)
+
+	verdict := detector.CheckPost(alice, "10.0.0.1", "third", testNow)
+	assert.True(t, verdict.Throttled)
+	assert.Equal(t, []model.SpamSignal{model.SpamPostRate}, verdict.Signals)
+
+	// Лимит адреса общий для всех его пользователей.
+	assert.True(t, detector.CheckPost(bob, "10.0.0.1", "hello", testNow).Throttled)
+	assert.False(t, detector.CheckPost(bob, "10.0.0.2", "hello", testNow).Throttled)
+
+	// Окно скользит.
+	later := testNow.Add(2 * time.Minute)
+	assert.False(t, detector.CheckPost(alice, "10.0.0.1", "fourth", later).Throttled)
+}
+
+func TestDetector_NewAccount(t *testing.T) {
+	detector := spam.NewDetector(model.SpamLimits{Window: time.Minute, MaxLikes: 4, NewAccountAge: 24 * time.Hour})
+	fresh := &model.User{ID: uuid.New(), CreatedAt: testNow.Add(-time.Hour)}
+
+	assert.True(t, detector.NewAccount(fresh, testNow))
+	assert.False(t, detector.NewAccount(oldUser(), testNow))
+	assert.False(t, detector.NewAccount(&model.User{ID: uuid.New()}, testNow))
+
+	// Новому аккаунту достается половина лимита.
+	assert.False(t, detector.CheckLike(fresh, "", testNow).Throttled)
+	assert.False(t, detector.CheckLike(fresh, "", testNow).Throttled)
+	assert.Equal(t,
+		model.SpamVerdict{Throttled: true, Signals: []model.SpamSignal{model.SpamLikeVelocity}},
+		detector.CheckLike(fresh, "", testNow),
+	)
+}
+
+func TestDetector_DuplicateText(t *testing.T) {
+	detector := spam.NewDetector(model.SpamLimits{DuplicateSimilarity: 0.8, DuplicateHistory: 5})
+	text := "buy cheap followers now at our totally legit shop today"
+	alice, bob, carol := oldUser(), oldUser(), oldUser()
+
+	assert.Empty(t, detector.CheckPost(alice, "10.0.0.1", text, testNow).Signals)
+	assert.Equal(t,
+		[]model.SpamSignal{model.SpamDuplicateText},
+		detector.CheckPost(alice, "10.0.0.9", "Buy cheap followers now at our totally legit shop today!", testNow).Signals,
+	)
+
+	// Тот же текст другого пользователя с того же адреса не штрафует аккаунт.
+	assert.Equal(t, model.SpamVerdict{}, detector.CheckPost(bob, "10.0.0.1", text, testNow))
+	// С другого адреса чужой текст не повтор.
+	assert.Empty(t, detector.CheckPost(carol, "10.0.0.2", text, testNow).Signals)
+}
+
+func TestDetector_DuplicateTextPerIP(t *testing.T) {
+	detector := spam.NewDetector(model.SpamLimits{
+		Window:              time.Minute,
+		DuplicateSimilarity: 0.8,
+		DuplicateHistory:    5,
+		MaxDuplicatesPerIP:  2,
+	})
+	text := "buy cheap followers now at our totally legit shop today"
+
+	assert.Equal(t, model.SpamVerdict{}, detector.CheckPost(oldUser(), "10.0.0.1", text, testNow))
+	assert.Equal(t, model.SpamVerdict{}, detector.CheckPost(oldUser(), "10.0.0.1", text, testNow))
+	assert.Equal(t, model.SpamVerdict{}, detector.CheckPost(oldUser(), "10.0.0.1", text, testNow))
+
+	// Сверх лимита отклоняются посты адреса, но очков аккаунту не начисляется.
+	assert.Equal(t, model.SpamVerdict{Throttled: true}, detector.CheckPost(oldUser(), "10.0.0.1", text, testNow))
+	assert.Equal(t, model.SpamVerdict{}, detector.CheckPost(oldUser(), "10.0.0.2", text, testNow))
+
+	later := testNow.Add(2 * time.Minute)
+	assert.Equal(t, model.SpamVerdict{}, detector.CheckPost(oldUser(), "10.0.0.1", text, later))
+}
+
+func TestDetector_Registration(t *testing.T) {
+	detector := spam.NewDetector(model.SpamLimits{Window: time.Minute, MaxRegistrationsPerIP: 1})
+
+	assert.False(t, detector.CheckRegistration("10.0.0.1", testNow).Throttled)
+	assert.True(t, detector.CheckRegistration("10.0.0.1", testNow).Throttled)
+	assert.False(t, detector.CheckRegistration("10.0.0.2", testNow).Throttled)
+	// Адрес неизвестен - ограничивать нечего.
+	assert.False(t, detector.CheckRegistration("", testNow).Throttled)
+	assert.False(t, detector.CheckRegistration("", testNow).Throttled)
+}
+
+func TestDetector_DisabledLimits(t *testing.T) {
+	detector := spam.NewDetector(model.SpamLimits{NewAccountAge: 24 * time.Hour})
+	fresh := &model.User{ID: uuid.New(), CreatedAt: testNow}
+
+	for range 100 {
+		assert.False(t, detector.CheckLike(fresh, "10.0.0.1", testNow).Throttled)
+	}
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"micro-blog/internal/model"
+)
+
+// LocalStore хранит медиафайлы в каталоге на диске, имя файла - ID медиа.
+type LocalStore struct {
+	dir string
+}
+
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating media dir: %w", err)
+	}
+
+	return &LocalStore{dir: dir}, nil
+}
+
+// Save пишет файл во временный файл и атомарно переименовывает его,
+// чтобы читатели не увидели недописанные данные.
+func (s *LocalStore) Save(id uuid.UUID, r io.Reader) (int64, error) {
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, r)
+	if err != nil {
+		_ = tmp.Close()
+		return 0, err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return 0, err
+	}
+
+	if err = os.Rename(tmp.Name(), s.path(id)); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (s *LocalStore) Open(id uuid.UUID) (io.ReadSeekCloser, error) {
+	f, err := os.Open(s.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, model.ErrMediaNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (s *LocalStore) Delete(id uuid.UUID) error {
+	err := os.Remove(s.path(id))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStore) path(id uuid.UUID) string {
+	return filepath.Join(s.dir, id.String())
+}
@@ -0,0 +1,17 @@
+package testutil
+
+import (
+	"context"
+	"log/slog"
+
+	"micro-blog/internal/logger"
+)
+
+// NopLogger - логгер для тестов, отбрасывающий все записи.
+type NopLogger struct{}
+
+func (NopLogger) Info(string, ...slog.Attr)                          {}
+func (NopLogger) Error(string, ...slog.Attr)                         {}
+func (NopLogger) InfoContext(context.Context, string, ...slog.Attr)  {}
+func (NopLogger) ErrorContext(context.Context, string, ...slog.Attr) {}
+func (l NopLogger) With(...any) logger.Logger                        { return l }
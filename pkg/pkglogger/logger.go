@@ -7,8 +7,10 @@ import (
 )
 
 const (
-	ErrorKey  string = "error"
-	UserIDKey string = "userID"
+	ErrorKey     string = "error"
+	UserIDKey    string = "userID"
+	ClientIPKey  string = "clientIP"
+	RequestIDKey string = "requestID"
 )
 
 type Handler struct {
@@ -28,6 +30,12 @@ func (h *Handler) Handle(ctx context.Context, rec slog.Record) error {
 	if userID, ok := ctx.Value(UserIDKey).(string); ok && userID != "" {
 		rec.Add(UserIDKey, slog.StringValue(userID))
 	}
+	if clientIP, ok := ctx.Value(ClientIPKey).(string); ok && clientIP != "" {
+		rec.Add(ClientIPKey, slog.StringValue(clientIP))
+	}
+	if requestID, ok := ctx.Value(RequestIDKey).(string); ok && requestID != "" {
+		rec.Add(RequestIDKey, slog.StringValue(requestID))
+	}
 
 	return h.next.Handle(ctx, rec)
 }